@@ -0,0 +1,100 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type AbortIncompleteUploadsInput struct {
+	Bucket string
+	Prefix string // optional, only uploads of keys with Prefix are aborted
+	// OlderThan only uploads initiated at least OlderThan ago are aborted, so that uploads in progress are kept
+	OlderThan time.Duration
+	TaskNum   int // number of concurrent AbortMultipartUpload requests, the default is 1
+}
+
+type AbortIncompleteUploadsOutput struct {
+	Aborted []ListedUpload     // uploads aborted, including ones completed or aborted by others meanwhile
+	Errors  []AbortUploadError // uploads failed to abort
+}
+
+type AbortUploadError struct {
+	Key      string
+	UploadID string
+	Err      error
+}
+
+// AbortIncompleteUploads abort multipart uploads under Prefix initiated before OlderThan ago, which are listed page by
+// page and aborted concurrently. Uploads failed to abort are returned in Errors of output, while a failed listing
+// stops aborting and its error is returned. Parts of incomplete uploads are charged until they're aborted.
+func (cli *ClientV2) AbortIncompleteUploads(ctx context.Context, input *AbortIncompleteUploadsInput) (*AbortIncompleteUploadsOutput, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if input.OlderThan < 0 {
+		return nil, newTosClientError("tos: negative OlderThan of AbortIncompleteUploads", nil)
+	}
+	taskNum := input.TaskNum
+	if taskNum < 1 {
+		taskNum = 1
+	}
+	before := time.Now().Add(-input.OlderThan)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		output  AbortIncompleteUploadsOutput
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		uploads = make(chan ListedUpload)
+	)
+	for i := 0; i < taskNum; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for upload := range uploads {
+				_, err := cli.AbortMultipartUpload(ctx, &AbortMultipartUploadInput{
+					Bucket:   input.Bucket,
+					Key:      upload.Key,
+					UploadID: upload.UploadID,
+				})
+				mu.Lock()
+				if err == nil || hasStatusCode(err, http.StatusNotFound) {
+					output.Aborted = append(output.Aborted, upload)
+				} else {
+					output.Errors = append(output.Errors, AbortUploadError{Key: upload.Key, UploadID: upload.UploadID, Err: err})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	err := func() error {
+		defer close(uploads)
+		it := cli.NewMultipartUploadsIterator(&ListMultipartUploadsV2Input{Bucket: input.Bucket, Prefix: input.Prefix})
+		for {
+			upload, err := it.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if upload == nil {
+				return nil
+			}
+			if !upload.Initiated.Before(before) {
+				continue
+			}
+			select {
+			case uploads <- *upload:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}()
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
@@ -0,0 +1,74 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// uploadsTransport list uploads of the page, and abort them, "bad" can't be aborted and "gone" doesn't exist
+type uploadsTransport struct {
+	mu      sync.Mutex
+	page    string
+	aborted []string
+}
+
+func (rt *uploadsTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if req.Method == http.MethodGet {
+		return &Response{StatusCode: http.StatusOK, Header: make(http.Header),
+			Body: ioutil.NopCloser(strings.NewReader(rt.page))}, nil
+	}
+	uploadID := req.Query.Get("uploadId")
+	switch uploadID {
+	case "bad":
+		return &Response{StatusCode: http.StatusForbidden, Header: make(http.Header),
+			Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"Code":"AccessDenied"}`)))}, nil
+	case "gone":
+		return &Response{StatusCode: http.StatusNotFound, Header: make(http.Header),
+			Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"Code":"NoSuchUpload"}`)))}, nil
+	}
+	rt.aborted = append(rt.aborted, uploadID)
+	return &Response{StatusCode: http.StatusNoContent, Header: make(http.Header),
+		Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestAbortIncompleteUploads(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().UTC().Format(time.RFC3339)
+	transport := &uploadsTransport{page: `{"IsTruncated":false,"Uploads":[
+{"Key":"a","UploadId":"a1","Initiated":"` + old + `"},
+{"Key":"a","UploadId":"a2","Initiated":"` + recent + `"},
+{"Key":"b","UploadId":"bad","Initiated":"` + old + `"},
+{"Key":"c","UploadId":"gone","Initiated":"` + old + `"},
+{"Key":"d","UploadId":"d1","Initiated":"` + old + `"}]}`}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(0))
+	require.Nil(t, err)
+
+	out, err := client.AbortIncompleteUploads(context.Background(), &AbortIncompleteUploadsInput{
+		Bucket: "bucket", OlderThan: 24 * time.Hour, TaskNum: 3})
+	require.Nil(t, err)
+	sort.Strings(transport.aborted)
+	require.Equal(t, []string{"a1", "d1"}, transport.aborted)
+	require.Len(t, out.Aborted, 3)
+	require.Len(t, out.Errors, 1)
+	require.Equal(t, "bad", out.Errors[0].UploadID)
+	require.Equal(t, http.StatusForbidden, StatusCode(out.Errors[0].Err))
+
+	_, err = client.AbortIncompleteUploads(context.Background(), &AbortIncompleteUploadsInput{
+		Bucket: "bucket", OlderThan: -time.Hour})
+	require.NotNil(t, err)
+
+	transport.page = `{"IsTruncated":true}`
+	_, err = client.AbortIncompleteUploads(context.Background(), &AbortIncompleteUploadsInput{Bucket: "bucket"})
+	require.NotNil(t, err)
+}
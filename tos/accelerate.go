@@ -0,0 +1,57 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// PutBucketAccelerateConfigurationV2 enable or suspend transfer acceleration of a bucket, see WithAccelerate
+func (cli *ClientV2) PutBucketAccelerateConfigurationV2(ctx context.Context, input *PutBucketAccelerateConfigurationV2Input, options ...Option) (*PutBucketAccelerateConfigurationV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if input.Status != enum.AccelerateStatusEnabled && input.Status != enum.AccelerateStatusSuspended {
+		return nil, newTosClientError("tos: Status of transfer acceleration must be Enabled or Suspended", nil)
+	}
+	in, contentMD5, err := marshalInput("PutBucketAccelerateConfigurationV2Input", struct {
+		Status enum.AccelerateStatusType `json:"Status"`
+	}{input.Status})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketAccelerateConfiguration).
+		WithQuery("accelerate", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketAccelerateConfigurationV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketAccelerateConfigurationV2 get whether transfer acceleration of a bucket is enabled
+func (cli *ClientV2) GetBucketAccelerateConfigurationV2(ctx context.Context, input *GetBucketAccelerateConfigurationV2Input, options ...Option) (*GetBucketAccelerateConfigurationV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketAccelerateConfiguration).
+		WithQuery("accelerate", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketAccelerateConfigurationV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
@@ -0,0 +1,55 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketAccelerateConfigurationV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	_, err := client.PutBucketAccelerateConfigurationV2(context.Background(),
+		&PutBucketAccelerateConfigurationV2Input{Bucket: "bucket", Status: enum.AccelerateStatusEnabled})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "accelerate")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Status":"Enabled"}`, string(data))
+	require.NotEmpty(t, req.Header.Get(HeaderContentMD5))
+
+	transport.respond(http.StatusOK, `{"Status":"Suspended"}`)
+	out, err := client.GetBucketAccelerateConfigurationV2(context.Background(),
+		&GetBucketAccelerateConfigurationV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, enum.AccelerateStatusSuspended, out.Status)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "accelerate")
+
+	_, err = client.PutBucketAccelerateConfigurationV2(context.Background(),
+		&PutBucketAccelerateConfigurationV2Input{Bucket: "bucket", Status: "Disabled"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 2)
+}
+
+func TestWithAccelerate(t *testing.T) {
+	transport := &recordTransport{res: newRecordResponse(http.StatusOK, "")}
+	client, err := NewClientV2("https://tos-cn-beijing.volces.com", WithTransport(transport), WithAccelerate(true))
+	require.Nil(t, err)
+
+	_, err = client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, "bucket."+AccelerateEndpoint, transport.requests[0].Host)
+	require.Equal(t, "https", transport.requests[0].Scheme)
+
+	// requests of buckets are not accelerated
+	_, err = client.HeadBucket(context.Background(), &HeadBucketInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, "bucket.tos-cn-beijing.volces.com", transport.requests[1].Host)
+}
@@ -27,6 +27,7 @@ func (bkt *Bucket) PutObjectAcl(ctx context.Context, input *PutObjectAclInput, o
 	}
 
 	builder := bkt.client.newBuilder(bkt.name, input.Key, options...).
+		WithOperation(OperationPutObjectACL).
 		WithQuery("acl", "").
 		WithQuery("versionId", input.VersionID)
 	if grant := input.AclGrant; grant != nil {
@@ -46,7 +47,7 @@ func (bkt *Bucket) PutObjectAcl(ctx context.Context, input *PutObjectAclInput, o
 }
 
 // PutObjectACL put object ACL
-func (cli *ClientV2) PutObjectACL(ctx context.Context, input *PutObjectACLInput) (*PutObjectACLOutput, error) {
+func (cli *ClientV2) PutObjectACL(ctx context.Context, input *PutObjectACLInput, options ...Option) (*PutObjectACLOutput, error) {
 	if err := isValidKey(input.Key); err != nil {
 		return nil, err
 	}
@@ -61,7 +62,8 @@ func (cli *ClientV2) PutObjectACL(ctx context.Context, input *PutObjectACLInput)
 		}
 		content = bytes.NewReader(data)
 	}
-	builder := cli.newBuilder(input.Bucket, input.Key).
+	builder := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationPutObjectACL).
 		WithQuery("acl", "").
 		WithParams(*input)
 	res, err := builder.Request(ctx, http.MethodPut, content, cli.roundTripper(http.StatusOK))
@@ -86,6 +88,7 @@ func (bkt *Bucket) GetObjectAcl(ctx context.Context, objectKey string, options .
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, objectKey, options...).
+		WithOperation(OperationGetObjectACL).
 		WithQuery("acl", "").
 		Request(ctx, http.MethodGet, nil, bkt.client.roundTripper(http.StatusOK))
 	if err != nil {
@@ -102,14 +105,15 @@ func (bkt *Bucket) GetObjectAcl(ctx context.Context, objectKey string, options .
 }
 
 // GetObjectACL get object ACL
-func (cli *ClientV2) GetObjectACL(ctx context.Context, input *GetObjectACLInput) (*GetObjectACLOutput, error) {
+func (cli *ClientV2) GetObjectACL(ctx context.Context, input *GetObjectACLInput, options ...Option) (*GetObjectACLOutput, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
 	if err := isValidKey(input.Key); err != nil {
 		return nil, err
 	}
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationGetObjectACL).
 		WithQuery("acl", "").
 		WithParams(*input).
 		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
@@ -125,3 +129,131 @@ func (cli *ClientV2) GetObjectACL(ctx context.Context, input *GetObjectACLInput)
 	out.VersionID = res.Header.Get(HeaderVersionID)
 	return &out, nil
 }
+
+// PutObjectACLV2 set ACL of an object, by one of a canned ACL, grant headers, or a full AccessControlPolicy
+func (cli *ClientV2) PutObjectACLV2(ctx context.Context, input *PutObjectACLV2Input, options ...Option) (*PutObjectACLV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	var content io.Reader
+	if policy := input.AccessControlPolicy; policy != nil {
+		if len(input.ACL) > 0 || len(input.GrantFullControl) > 0 || len(input.GrantRead) > 0 ||
+			len(input.GrantReadAcp) > 0 || len(input.GrantWriteAcp) > 0 {
+			return nil, newTosClientError("tos: AccessControlPolicy can not be set with ACL or grant headers", nil)
+		}
+		data, _, err := marshalInput("PutObjectACLV2Input", &accessControlList{
+			Owner:                policy.Owner,
+			Grants:               policy.Grants,
+			BucketOwnerEntrusted: policy.BucketOwnerEntrusted,
+		})
+		if err != nil {
+			return nil, err
+		}
+		content = bytes.NewReader(data)
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationPutObjectACL).
+		WithQuery("acl", "").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, content, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutObjectACLV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetObjectACLV2 get ACL of an object
+func (cli *ClientV2) GetObjectACLV2(ctx context.Context, input *GetObjectACLV2Input, options ...Option) (*GetObjectACLV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationGetObjectACL).
+		WithQuery("acl", "").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var acl accessControlList
+	if err = marshalOutput(res.RequestInfo().RequestID, res.Body, &acl); err != nil {
+		return nil, err
+	}
+	return &GetObjectACLV2Output{
+		RequestInfo: res.RequestInfo(),
+		VersionID:   res.Header.Get(HeaderVersionID),
+		AccessControlPolicy: AccessControlPolicy{
+			Owner:                acl.Owner,
+			Grants:               acl.Grants,
+			BucketOwnerEntrusted: acl.BucketOwnerEntrusted,
+		},
+	}, nil
+}
+
+// PutBucketACLV2 set ACL of a bucket, by one of a canned ACL, grant headers, or a full AccessControlPolicy
+func (cli *ClientV2) PutBucketACLV2(ctx context.Context, input *PutBucketACLV2Input, options ...Option) (*PutBucketACLV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if len(input.ACL) > 0 {
+		if err := isValidACL(input.ACL); err != nil {
+			return nil, err
+		}
+	}
+	var content io.Reader
+	if policy := input.AccessControlPolicy; policy != nil {
+		if len(input.ACL) > 0 || len(input.GrantFullControl) > 0 || len(input.GrantRead) > 0 ||
+			len(input.GrantReadAcp) > 0 || len(input.GrantWrite) > 0 || len(input.GrantWriteAcp) > 0 {
+			return nil, newTosClientError("tos: AccessControlPolicy can not be set with ACL or grant headers", nil)
+		}
+		data, _, err := marshalInput("PutBucketACLV2Input", &accessControlList{
+			Owner:  policy.Owner,
+			Grants: policy.Grants,
+		})
+		if err != nil {
+			return nil, err
+		}
+		content = bytes.NewReader(data)
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketACL).
+		WithQuery("acl", "").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, content, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketACLV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketACLV2 get ACL of a bucket
+func (cli *ClientV2) GetBucketACLV2(ctx context.Context, input *GetBucketACLV2Input, options ...Option) (*GetBucketACLV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketACL).
+		WithQuery("acl", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var acl accessControlList
+	if err = marshalOutput(res.RequestInfo().RequestID, res.Body, &acl); err != nil {
+		return nil, err
+	}
+	return &GetBucketACLV2Output{
+		RequestInfo:               res.RequestInfo(),
+		BucketAccessControlPolicy: BucketAccessControlPolicy{Owner: acl.Owner, Grants: acl.Grants},
+	}, nil
+}
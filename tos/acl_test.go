@@ -0,0 +1,114 @@
+package tos
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestPutObjectACLV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	_, err := client.PutObjectACLV2(context.Background(), &PutObjectACLV2Input{Bucket: "bucket", Key: "key",
+		ACL: enum.ACLPublicRead, GrantRead: `id="123"`})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "acl")
+	require.Equal(t, "public-read", req.Header.Get(HeaderACL))
+	require.Equal(t, `id="123"`, req.Header.Get(HeaderGrantRead))
+	require.Nil(t, req.Content)
+
+	policy := &AccessControlPolicy{
+		Owner: Owner{ID: "owner"},
+		Grants: []Grant{{
+			Grantee:    Grantee{ID: "123", Type: string(enum.GranteeUser)},
+			Permission: enum.PermissionRead,
+		}},
+		BucketOwnerEntrusted: true,
+	}
+	_, err = client.PutObjectACLV2(context.Background(), &PutObjectACLV2Input{Bucket: "bucket", Key: "key",
+		VersionID: "v1", AccessControlPolicy: policy})
+	require.Nil(t, err)
+	req = transport.requests[1]
+	require.Equal(t, "v1", req.Query.Get("versionId"))
+	body, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	var acl accessControlList
+	require.Nil(t, json.Unmarshal(body, &acl))
+	require.Equal(t, policy.Owner, acl.Owner)
+	require.Equal(t, policy.Grants, acl.Grants)
+	require.True(t, acl.BucketOwnerEntrusted)
+
+	_, err = client.PutObjectACLV2(context.Background(), &PutObjectACLV2Input{Bucket: "bucket", Key: "key",
+		ACL: enum.ACLPrivate, AccessControlPolicy: policy})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 2)
+}
+
+func TestGetObjectACLV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"Owner":{"ID":"owner"},"Grants":[{"Grantee":{"Type":"Group",
+"Canned":"AllUsers"},"Permission":"READ"}],"BucketOwnerEntrusted":true}`)
+	transport.res.Header.Set(HeaderVersionID, "v1")
+
+	output, err := client.GetObjectACLV2(context.Background(), &GetObjectACLV2Input{Bucket: "bucket", Key: "key",
+		VersionID: "v1"})
+	require.Nil(t, err)
+	require.Equal(t, "v1", output.VersionID)
+	require.Equal(t, "owner", output.Owner.ID)
+	require.Len(t, output.Grants, 1)
+	require.Equal(t, string(enum.CannedAllUsers), output.Grants[0].Grantee.URI)
+	require.Equal(t, enum.PermissionRead, output.Grants[0].Permission)
+	require.True(t, output.BucketOwnerEntrusted)
+}
+
+func TestBucketACLV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	_, err := client.PutBucketACLV2(context.Background(), &PutBucketACLV2Input{Bucket: "bucket",
+		ACL: enum.ACLPrivate, GrantWrite: `id="123"`})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "acl")
+	require.Equal(t, "private", req.Header.Get(HeaderACL))
+	require.Equal(t, `id="123"`, req.Header.Get(HeaderGrantWrite))
+	require.Nil(t, req.Content)
+
+	policy := BucketAccessControlPolicy{
+		Owner: Owner{ID: "2100000001"},
+		Grants: []Grant{{Grantee: Grantee{ID: "123", Type: "CanonicalUser"},
+			Permission: enum.PermissionFullControl}},
+	}
+	_, err = client.PutBucketACLV2(context.Background(), &PutBucketACLV2Input{Bucket: "bucket",
+		AccessControlPolicy: &policy})
+	require.Nil(t, err)
+	data, err := ioutil.ReadAll(transport.requests[1].Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Owner":{"ID":"2100000001"},"Grants":[{"Grantee":{"ID":"123","Type":"CanonicalUser"},
+"Permission":"FULL_CONTROL"}]}`, string(data))
+
+	// BucketOwnerEntrusted only applies to objects
+	transport.respond(http.StatusOK, `{"Owner":{"ID":"2100000001"},"Grants":[{"Grantee":{"Type":"Group",
+"Canned":"AllUsers"},"Permission":"READ"}],"BucketOwnerEntrusted":true}`)
+	out, err := client.GetBucketACLV2(context.Background(), &GetBucketACLV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, BucketAccessControlPolicy{Owner: Owner{ID: "2100000001"}, Grants: []Grant{{
+		Grantee:    Grantee{Type: "Group", URI: string(enum.CannedAllUsers)},
+		Permission: enum.PermissionRead,
+	}}}, out.BucketAccessControlPolicy)
+	require.Equal(t, http.MethodGet, transport.requests[2].Method)
+	require.Contains(t, transport.requests[2].Query, "acl")
+
+	_, err = client.PutBucketACLV2(context.Background(), &PutBucketACLV2Input{Bucket: "bucket",
+		GrantWrite: `id="123"`, AccessControlPolicy: &policy})
+	require.NotNil(t, err)
+	_, err = client.PutBucketACLV2(context.Background(), &PutBucketACLV2Input{Bucket: "bucket", ACL: "invalid"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 3)
+}
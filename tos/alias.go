@@ -0,0 +1,78 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+)
+
+// PutBucketAliasV2 bind an alias to a bucket
+func (cli *ClientV2) PutBucketAliasV2(ctx context.Context, input *PutBucketAliasV2Input, options ...Option) (*PutBucketAliasV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := IsValidBucketName(input.Alias); err != nil {
+		return nil, newTosClientError("tos: invalid alias, it must follow the naming rules of buckets", err)
+	}
+	if input.Alias == input.Bucket {
+		return nil, newTosClientError("tos: alias must be different from the bucket name", nil)
+	}
+	in, contentMD5, err := marshalInput("PutBucketAliasV2Input", struct {
+		Alias string `json:"Alias"`
+	}{input.Alias})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketAlias).
+		WithQuery("alias", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketAliasV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// ListBucketAliasV2 list aliases bound to a bucket
+func (cli *ClientV2) ListBucketAliasV2(ctx context.Context, input *ListBucketAliasV2Input, options ...Option) (*ListBucketAliasV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationListBucketAlias).
+		WithQuery("alias", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := ListBucketAliasV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketAliasV2 unbind an alias from a bucket, requests with the alias fail with NoSuchBucket afterwards
+func (cli *ClientV2) DeleteBucketAliasV2(ctx context.Context, input *DeleteBucketAliasV2Input, options ...Option) (*DeleteBucketAliasV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if len(input.Alias) == 0 {
+		return nil, newTosClientError("tos: Alias is required", nil)
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketAlias).
+		WithQuery("alias", input.Alias).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketAliasV2Output{RequestInfo: res.RequestInfo()}, nil
+}
@@ -0,0 +1,49 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketAliasV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	ctx := context.Background()
+
+	_, err := client.PutBucketAliasV2(ctx, &PutBucketAliasV2Input{Bucket: "new-bucket", Alias: "old-bucket"})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Equal(t, "new-bucket.tos-cn-beijing.volces.com", req.Host)
+	require.Contains(t, req.Query, "alias")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Alias":"old-bucket"}`, string(data))
+
+	transport.respond(http.StatusOK, `{"Aliases":[{"Alias":"old-bucket",
+"CreationDate":"2024-01-01T00:00:00.000Z"}]}`)
+	out, err := client.ListBucketAliasV2(ctx, &ListBucketAliasV2Input{Bucket: "new-bucket"})
+	require.Nil(t, err)
+	require.Equal(t, []BucketAlias{{Alias: "old-bucket", CreationDate: "2024-01-01T00:00:00.000Z"}}, out.Aliases)
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketAliasV2(ctx, &DeleteBucketAliasV2Input{Bucket: "new-bucket", Alias: "old-bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+	require.Equal(t, "old-bucket", transport.requests[2].Query.Get("alias"))
+
+	for _, input := range []PutBucketAliasV2Input{
+		{Bucket: "new-bucket"},
+		{Bucket: "new-bucket", Alias: "Old_Bucket"},
+		{Bucket: "new-bucket", Alias: "new-bucket"},
+	} {
+		_, err = client.PutBucketAliasV2(ctx, &input)
+		require.NotNil(t, err)
+	}
+	_, err = client.DeleteBucketAliasV2(ctx, &DeleteBucketAliasV2Input{Bucket: "new-bucket"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 3)
+}
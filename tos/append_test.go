@@ -0,0 +1,45 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendObjectV2(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderNextAppendOffset, "11")
+	header.Set(HeaderHashCrc64ecma, "12345")
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	output, err := client.AppendObjectV2(context.Background(), &AppendObjectV2Input{Bucket: "bucket", Key: "key",
+		Offset: 6, Content: strings.NewReader("world")})
+	require.Nil(t, err)
+	require.Equal(t, int64(11), output.NextAppendOffset)
+	require.Equal(t, uint64(12345), output.HashCrc64ecma)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, "6", req.Query.Get("offset"))
+	require.Equal(t, int64(5), *req.ContentLength)
+
+	_, err = client.AppendObjectV2(context.Background(), &AppendObjectV2Input{Bucket: "bucket", Key: "key",
+		Offset: -1, Content: strings.NewReader("world")})
+	require.NotNil(t, err)
+	_, err = client.AppendObjectV2(context.Background(), &AppendObjectV2Input{Bucket: "bucket", Key: "key",
+		Content: iotest.OneByteReader(strings.NewReader("world"))})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 1)
+}
+
+func TestAppendObjectV2NotAppendable(t *testing.T) {
+	client, _ := newRecordClient(t, http.StatusConflict, `{"Code":"NotAppendable"}`)
+	_, err := client.AppendObjectV2(context.Background(), &AppendObjectV2Input{Bucket: "bucket", Key: "key",
+		Content: strings.NewReader("hello")})
+	require.True(t, IsNotAppendable(err))
+	require.False(t, IsNotFound(err))
+}
@@ -0,0 +1,206 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBenchmarkKeyPrefix   = "tos-benchmark/"
+	DefaultBenchmarkObjectSize  = 1024 * 1024
+	DefaultBenchmarkObjectCount = 10
+)
+
+type BenchmarkInput struct {
+	Bucket      string
+	KeyPrefix   string // prefix of the synthetic objects, default is "tos-benchmark/"
+	ObjectSize  int64  // size of each synthetic object, default is 1MB
+	ObjectCount int    // number of objects to upload and download, default is 10
+	TaskNum     int    // number of concurrent requests, default is 1
+	KeepObjects bool   // keep the synthetic objects after benchmark, they are deleted by default
+}
+
+// BenchmarkResult the statistics of one kind of operation
+type BenchmarkResult struct {
+	Count      int           // number of succeeded requests
+	Failed     int           // number of failed requests
+	Err        error         // the first error occurs, nil if all requests succeed
+	TotalBytes int64         // bytes transferred by succeeded requests
+	Elapsed    time.Duration // wall time of the whole phase
+	Throughput float64       // bytes per second
+	LatencyP50 time.Duration
+	LatencyP90 time.Duration
+	LatencyP99 time.Duration
+	LatencyMax time.Duration
+}
+
+type BenchmarkOutput struct {
+	Upload   BenchmarkResult
+	Download BenchmarkResult
+}
+
+func validateBenchmarkInput(input *BenchmarkInput) error {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return err
+	}
+	if len(input.KeyPrefix) == 0 {
+		input.KeyPrefix = DefaultBenchmarkKeyPrefix
+	}
+	if input.ObjectSize <= 0 {
+		input.ObjectSize = DefaultBenchmarkObjectSize
+	}
+	if input.ObjectCount <= 0 {
+		input.ObjectCount = DefaultBenchmarkObjectCount
+	}
+	if input.TaskNum < 1 {
+		input.TaskNum = 1
+	}
+	if input.TaskNum > 1000 {
+		input.TaskNum = 1000
+	}
+	return isValidKey(input.KeyPrefix + strconv.Itoa(input.ObjectCount))
+}
+
+// latencyPercentile return the p-th percentile of sorted latencies, p is in (0, 1]
+func latencyPercentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+type benchmarkRecorder struct {
+	mu        sync.Mutex
+	result    BenchmarkResult
+	latencies []time.Duration
+}
+
+func (r *benchmarkRecorder) record(latency time.Duration, bytes int64, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.result.Failed++
+		if r.result.Err == nil {
+			r.result.Err = err
+		}
+		return
+	}
+	r.result.Count++
+	r.result.TotalBytes += bytes
+	r.latencies = append(r.latencies, latency)
+}
+
+func (r *benchmarkRecorder) summarize(elapsed time.Duration) BenchmarkResult {
+	result := r.result
+	result.Elapsed = elapsed
+	if elapsed > 0 {
+		result.Throughput = float64(result.TotalBytes) / elapsed.Seconds()
+	}
+	sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+	result.LatencyP50 = latencyPercentile(r.latencies, 0.5)
+	result.LatencyP90 = latencyPercentile(r.latencies, 0.9)
+	result.LatencyP99 = latencyPercentile(r.latencies, 0.99)
+	result.LatencyMax = latencyPercentile(r.latencies, 1)
+	return result
+}
+
+// runBenchmarkPhase run work on every key with taskNum goroutines
+func runBenchmarkPhase(ctx context.Context, keys []string, taskNum int,
+	work func(ctx context.Context, key string) (int64, error)) BenchmarkResult {
+	var (
+		recorder = benchmarkRecorder{latencies: make([]time.Duration, 0, len(keys))}
+		keyCh    = make(chan string)
+		wg       sync.WaitGroup
+		start    = time.Now()
+	)
+	for i := 0; i < min(taskNum, len(keys)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keyCh {
+				begin := time.Now()
+				n, err := work(ctx, key)
+				recorder.record(time.Since(begin), n, err)
+			}
+		}()
+	}
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			break
+		}
+		keyCh <- key
+	}
+	close(keyCh)
+	wg.Wait()
+	return recorder.summarize(time.Since(start))
+}
+
+// Benchmark uploads and downloads synthetic objects to the bucket and reports throughput and latency percentiles.
+// It's useful to validate the network path to TOS from a new environment.
+//
+// NOTICE: the synthetic objects are written under input.KeyPrefix, existing objects with the same keys will be overwritten.
+func (cli *ClientV2) Benchmark(ctx context.Context, input *BenchmarkInput) (*BenchmarkOutput, error) {
+	in := *input
+	if err := validateBenchmarkInput(&in); err != nil {
+		return nil, err
+	}
+	data := make([]byte, in.ObjectSize)
+	rand.Read(data)
+
+	keys := make([]string, 0, in.ObjectCount)
+	for i := 0; i < in.ObjectCount; i++ {
+		keys = append(keys, in.KeyPrefix+strconv.Itoa(i))
+	}
+
+	var (
+		uploadedMu sync.Mutex
+		uploaded   = make([]string, 0, len(keys))
+	)
+	upload := runBenchmarkPhase(ctx, keys, in.TaskNum, func(ctx context.Context, key string) (int64, error) {
+		_, err := cli.PutObjectV2(ctx, &PutObjectV2Input{
+			PutObjectBasicInput: PutObjectBasicInput{Bucket: in.Bucket, Key: key, ContentLength: in.ObjectSize},
+			Content:             bytes.NewReader(data),
+		})
+		if err != nil {
+			return 0, err
+		}
+		uploadedMu.Lock()
+		uploaded = append(uploaded, key)
+		uploadedMu.Unlock()
+		return in.ObjectSize, nil
+	})
+
+	download := runBenchmarkPhase(ctx, uploaded, in.TaskNum, func(ctx context.Context, key string) (int64, error) {
+		get, err := cli.GetObjectV2(ctx, &GetObjectV2Input{Bucket: in.Bucket, Key: key})
+		if err != nil {
+			return 0, err
+		}
+		defer get.Content.Close()
+		return io.Copy(ioutil.Discard, get.Content)
+	})
+
+	if !in.KeepObjects {
+		for start := 0; start < len(uploaded); start += 1000 {
+			objects := make([]ObjectTobeDeleted, 0, 1000)
+			for _, key := range uploaded[start:min(start+1000, len(uploaded))] {
+				objects = append(objects, ObjectTobeDeleted{Key: key})
+			}
+			// best effort, ignore error
+			_, _ = cli.DeleteMultiObjects(ctx, &DeleteMultiObjectsInput{Bucket: in.Bucket, Objects: objects, Quiet: true})
+		}
+	}
+
+	return &BenchmarkOutput{Upload: upload, Download: download}, nil
+}
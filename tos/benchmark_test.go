@@ -0,0 +1,43 @@
+package tos
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyPercentile(t *testing.T) {
+	require.Equal(t, time.Duration(0), latencyPercentile(nil, 0.5))
+
+	sorted := make([]time.Duration, 0, 100)
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i))
+	}
+	require.Equal(t, time.Duration(50), latencyPercentile(sorted, 0.5))
+	require.Equal(t, time.Duration(99), latencyPercentile(sorted, 0.99))
+	require.Equal(t, time.Duration(100), latencyPercentile(sorted, 1))
+}
+
+func TestRunBenchmarkPhase(t *testing.T) {
+	keys := make([]string, 0, 10)
+	for i := 0; i < 10; i++ {
+		keys = append(keys, strconv.Itoa(i))
+	}
+	failed := errors.New("failed")
+	result := runBenchmarkPhase(context.Background(), keys, 4, func(ctx context.Context, key string) (int64, error) {
+		if key == "3" {
+			return 0, failed
+		}
+		return 100, nil
+	})
+	require.Equal(t, 9, result.Count)
+	require.Equal(t, 1, result.Failed)
+	require.Equal(t, failed, result.Err)
+	require.Equal(t, int64(900), result.TotalBytes)
+	require.True(t, result.Throughput > 0)
+	require.True(t, result.LatencyMax >= result.LatencyP50)
+}
@@ -26,6 +26,7 @@ func (cli *Client) CreateBucket(ctx context.Context, input *CreateBucketInput) (
 	}
 
 	res, err := cli.newBuilder(input.Bucket, "").
+		WithOperation(OperationCreateBucket).
 		WithHeader(HeaderACL, input.ACL).
 		WithHeader(HeaderGrantFullControl, input.GrantFullControl).
 		WithHeader(HeaderGrantRead, input.GrantRead).
@@ -45,7 +46,7 @@ func (cli *Client) CreateBucket(ctx context.Context, input *CreateBucketInput) (
 }
 
 // CreateBucketV2 create a bucket
-func (cli *ClientV2) CreateBucketV2(ctx context.Context, input *CreateBucketV2Input) (*CreateBucketV2Output, error) {
+func (cli *ClientV2) CreateBucketV2(ctx context.Context, input *CreateBucketV2Input, options ...Option) (*CreateBucketV2Output, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
@@ -56,8 +57,19 @@ func (cli *ClientV2) CreateBucketV2(ctx context.Context, input *CreateBucketV2In
 			return nil, err
 		}
 	}
+	if len(input.StorageClass) != 0 {
+		if err := isValidStorageClass(input.StorageClass); err != nil {
+			return nil, err
+		}
+	}
+	if len(input.AzRedundancy) != 0 {
+		if err := isValidAzRedundancy(input.AzRedundancy); err != nil {
+			return nil, err
+		}
+	}
 
-	res, err := cli.newBuilder(input.Bucket, "").
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationCreateBucket).
 		WithParams(*input).
 		WithRetry(func(req *Request) {}, ServerErrorClassifier{}).
 		Request(ctx, http.MethodPut, nil, cli.roundTripper(http.StatusOK))
@@ -75,11 +87,12 @@ func (cli *ClientV2) CreateBucketV2(ctx context.Context, input *CreateBucketV2In
 // HeadBucket get some info of a bucket
 //
 // Deprecated: use HeadBucket of ClientV2 instead
-func (cli *Client) HeadBucket(ctx context.Context, bucket string) (*HeadBucketOutput, error) {
+func (cli *Client) HeadBucket(ctx context.Context, bucket string, options ...Option) (*HeadBucketOutput, error) {
 	if err := IsValidBucketName(bucket); err != nil {
 		return nil, err
 	}
-	res, err := cli.newBuilder(bucket, "").
+	res, err := cli.newBuilder(bucket, "", options...).
+		WithOperation(OperationHeadBucket).
 		Request(ctx, http.MethodHead, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
@@ -89,23 +102,26 @@ func (cli *Client) HeadBucket(ctx context.Context, bucket string) (*HeadBucketOu
 		RequestInfo:  res.RequestInfo(),
 		Region:       res.Header.Get(HeaderBucketRegion),
 		StorageClass: enum.StorageClassType(res.Header.Get(HeaderStorageClass)),
+		AzRedundancy: enum.AzRedundancyType(res.Header.Get(HeaderAzRedundancy)),
+		BucketType:   enum.BucketType(res.Header.Get(HeaderBucketType)),
 	}, nil
 }
 
 // HeadBucket get some info of a bucket
-func (cli *ClientV2) HeadBucket(ctx context.Context, input *HeadBucketInput) (*HeadBucketOutput, error) {
-	return cli.Client.HeadBucket(ctx, input.Bucket)
+func (cli *ClientV2) HeadBucket(ctx context.Context, input *HeadBucketInput, options ...Option) (*HeadBucketOutput, error) {
+	return cli.Client.HeadBucket(ctx, input.Bucket, options...)
 }
 
 // DeleteBucket delete a bucket
 //
 // Deprecated: use DeleteBucket of ClientV2 instead
-func (cli *Client) DeleteBucket(ctx context.Context, bucket string) (*DeleteBucketOutput, error) {
+func (cli *Client) DeleteBucket(ctx context.Context, bucket string, options ...Option) (*DeleteBucketOutput, error) {
 	if err := IsValidBucketName(bucket); err != nil {
 		return nil, err
 	}
 
-	res, err := cli.newBuilder(bucket, "").
+	res, err := cli.newBuilder(bucket, "", options...).
+		WithOperation(OperationDeleteBucket).
 		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
 	if err != nil {
 		return nil, err
@@ -117,8 +133,8 @@ func (cli *Client) DeleteBucket(ctx context.Context, bucket string) (*DeleteBuck
 
 // DeleteBucket delete a bucket.Deleting a non-empty bucket is not allowed.
 // A bucket is empty only if there is no exist object and uncanceled segmented tasks.
-func (cli *ClientV2) DeleteBucket(ctx context.Context, input *DeleteBucketInput) (*DeleteBucketOutput, error) {
-	return cli.Client.DeleteBucket(ctx, input.Bucket)
+func (cli *ClientV2) DeleteBucket(ctx context.Context, input *DeleteBucketInput, options ...Option) (*DeleteBucketOutput, error) {
+	return cli.Client.DeleteBucket(ctx, input.Bucket, options...)
 }
 
 // ListBuckets list the buckets that the AK can access
@@ -126,6 +142,7 @@ func (cli *ClientV2) DeleteBucket(ctx context.Context, input *DeleteBucketInput)
 // Deprecated: use ListBuckets of ClientV2 instead
 func (cli *Client) ListBuckets(ctx context.Context, _ *ListBucketsInput) (*ListBucketsOutput, error) {
 	res, err := cli.newBuilder("", "").
+		WithOperation(OperationListBuckets).
 		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
@@ -139,10 +156,14 @@ func (cli *Client) ListBuckets(ctx context.Context, _ *ListBucketsInput) (*ListB
 	return &output, nil
 }
 
-// ListBucketsV2 list the buckets that the AK can access
-func (cli *ClientV2) ListBucketsV2(ctx context.Context, _ *ListBucketsV2Input) (*ListBucketsV2Output, error) {
-	res, err := cli.newBuilder("", "").
-		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+// ListBucketsV2 list the buckets that the AK can access, input is nullable
+func (cli *ClientV2) ListBucketsV2(ctx context.Context, input *ListBucketsV2Input, options ...Option) (*ListBucketsV2Output, error) {
+	rb := cli.newBuilder("", "", options...).
+		WithOperation(OperationListBuckets)
+	if input != nil {
+		rb.WithParams(*input)
+	}
+	res, err := rb.Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
 	}
@@ -154,3 +175,109 @@ func (cli *ClientV2) ListBucketsV2(ctx context.Context, _ *ListBucketsV2Input) (
 	}
 	return &output, nil
 }
+
+// GetBucketLocationV2 get the region and endpoints of a bucket
+func (cli *ClientV2) GetBucketLocationV2(ctx context.Context, input *GetBucketLocationV2Input, options ...Option) (*GetBucketLocationV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketLocation).
+		WithQuery("location", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketLocationV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// GetBucketInfo get region, creation date, storage class, AZ redundancy and versioning status of a bucket.
+// It's composed of HeadBucket, GetBucketVersioning and ListBuckets. Only HeadBucket is required, the versioning
+// status, creation date and endpoints are left empty if the AK is denied GetBucketVersioning or ListBuckets, and
+// the creation date and endpoints are left empty as well if the bucket is not listed by the AK.
+func (cli *ClientV2) GetBucketInfo(ctx context.Context, input *GetBucketInfoInput, options ...Option) (*GetBucketInfoOutput, error) {
+	head, err := cli.HeadBucket(ctx, &HeadBucketInput{Bucket: input.Bucket}, options...)
+	if err != nil {
+		return nil, err
+	}
+	output := GetBucketInfoOutput{
+		RequestInfo:  head.RequestInfo,
+		Name:         input.Bucket,
+		Region:       head.Region,
+		StorageClass: head.StorageClass,
+		AzRedundancy: head.AzRedundancy,
+		BucketType:   head.BucketType,
+	}
+	versioning, err := cli.GetBucketVersioning(ctx, input.Bucket, options...)
+	if err == nil {
+		output.VersioningStatus = versioning.Status
+	} else if StatusCode(err) != http.StatusForbidden {
+		return nil, err
+	}
+	buckets, err := cli.ListBucketsV2(ctx, &ListBucketsV2Input{}, options...)
+	if err != nil {
+		if StatusCode(err) == http.StatusForbidden {
+			return &output, nil
+		}
+		return nil, err
+	}
+	for _, bucket := range buckets.Buckets {
+		if bucket.Name == input.Bucket {
+			output.CreationDate = bucket.CreationDate
+			output.ExtranetEndpoint = bucket.ExtranetEndpoint
+			output.IntranetEndpoint = bucket.IntranetEndpoint
+			break
+		}
+	}
+	return &output, nil
+}
+
+// PutBucketStorageClassV2 change the default storage class of a bucket, which applies to objects uploaded
+// afterwards without storage class, existing objects are not changed
+func (cli *ClientV2) PutBucketStorageClassV2(ctx context.Context, input *PutBucketStorageClassV2Input, options ...Option) (*PutBucketStorageClassV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := isValidStorageClass(input.StorageClass); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketStorageClass).
+		WithQuery("storageClass", "").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketStorageClassV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketStatV2 get object count and storage size of a bucket in total and by storage class. The statistics are
+// collected periodically by the server, see LastModifyTime of output, instead of listing objects.
+func (cli *ClientV2) GetBucketStatV2(ctx context.Context, input *GetBucketStatV2Input, options ...Option) (*GetBucketStatV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketStat).
+		WithQuery("stat", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketStatV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
@@ -0,0 +1,157 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestHeadBucketAzRedundancy(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderBucketRegion, "cn-beijing")
+	header.Set(HeaderStorageClass, string(enum.StorageClassIa))
+	header.Set(HeaderAzRedundancy, string(enum.AzRedundancyMultiAz))
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	output, err := client.HeadBucket(context.Background(), &HeadBucketInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, "cn-beijing", output.Region)
+	require.Equal(t, enum.StorageClassIa, output.StorageClass)
+	require.Equal(t, enum.AzRedundancyMultiAz, output.AzRedundancy)
+}
+
+func TestGetBucketLocationV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"Region":"cn-guangzhou",
+"ExtranetEndpoint":"tos-cn-guangzhou.volces.com","IntranetEndpoint":"tos-cn-guangzhou.ivolces.com"}`)
+
+	output, err := client.GetBucketLocationV2(context.Background(), &GetBucketLocationV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Contains(t, transport.requests[0].Query, "location")
+	require.Equal(t, "cn-guangzhou", output.Region)
+	require.Equal(t, "tos-cn-guangzhou.volces.com", output.ExtranetEndpoint)
+	require.Equal(t, "tos-cn-guangzhou.ivolces.com", output.IntranetEndpoint)
+}
+
+// bucketInfoTransport responds HeadBucket, GetBucketVersioning and ListBuckets, the latter two are denied if denied
+type bucketInfoTransport struct {
+	requests []*Request
+	denied   bool
+}
+
+func (bt *bucketInfoTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	bt.requests = append(bt.requests, req)
+	header := make(http.Header)
+	body := ""
+	switch {
+	case req.Method != http.MethodHead && bt.denied:
+		return &Response{StatusCode: http.StatusForbidden, Header: header,
+			Body: ioutil.NopCloser(strings.NewReader(`{"Code":"AccessDenied"}`))}, nil
+	case req.Method == http.MethodHead:
+		header.Set(HeaderBucketRegion, "cn-beijing")
+		header.Set(HeaderStorageClass, string(enum.StorageClassStandard))
+		header.Set(HeaderAzRedundancy, string(enum.AzRedundancySingleAz))
+	case len(req.Query["versioning"]) > 0:
+		body = `{"Status":"Enabled"}`
+	default:
+		body = `{"Buckets":[{"Name":"other","CreationDate":"2023-01-01T00:00:00.000Z"},
+{"Name":"bucket","CreationDate":"2024-01-01T00:00:00.000Z","Location":"cn-beijing",
+"ExtranetEndpoint":"tos-cn-beijing.volces.com","IntranetEndpoint":"tos-cn-beijing.ivolces.com"}]}`
+	}
+	return &Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestGetBucketInfo(t *testing.T) {
+	transport := &bucketInfoTransport{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+
+	output, err := client.GetBucketInfo(context.Background(), &GetBucketInfoInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Len(t, transport.requests, 3)
+	require.Equal(t, GetBucketInfoOutput{
+		RequestInfo:      output.RequestInfo,
+		Name:             "bucket",
+		Region:           "cn-beijing",
+		CreationDate:     "2024-01-01T00:00:00.000Z",
+		StorageClass:     enum.StorageClassStandard,
+		AzRedundancy:     enum.AzRedundancySingleAz,
+		VersioningStatus: BucketVersioningEnable,
+		ExtranetEndpoint: "tos-cn-beijing.volces.com",
+		IntranetEndpoint: "tos-cn-beijing.ivolces.com",
+	}, *output)
+
+	output, err = client.GetBucketInfo(context.Background(), &GetBucketInfoInput{Bucket: "shared"})
+	require.Nil(t, err)
+	require.Equal(t, "cn-beijing", output.Region)
+	require.Empty(t, output.CreationDate)
+
+	// versioning status and creation date are unknown if the AK is denied GetBucketVersioning and ListBuckets
+	transport.denied = true
+	output, err = client.GetBucketInfo(context.Background(), &GetBucketInfoInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, enum.StorageClassStandard, output.StorageClass)
+	require.Empty(t, output.VersioningStatus)
+	require.Empty(t, output.CreationDate)
+}
+
+func TestBucketStorageClass(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	_, err := client.CreateBucketV2(context.Background(), &CreateBucketV2Input{Bucket: "bucket",
+		StorageClass: enum.StorageClassIa, AzRedundancy: enum.AzRedundancyMultiAz})
+	require.Nil(t, err)
+	require.Equal(t, "IA", transport.requests[0].Header.Get(HeaderStorageClass))
+	require.Equal(t, "multi-az", transport.requests[0].Header.Get(HeaderAzRedundancy))
+
+	_, err = client.PutBucketStorageClassV2(context.Background(), &PutBucketStorageClassV2Input{Bucket: "bucket",
+		StorageClass: enum.StorageClassArchiveFr})
+	require.Nil(t, err)
+	req := transport.requests[1]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "storageClass")
+	require.Equal(t, "ARCHIVE_FR", req.Header.Get(HeaderStorageClass))
+
+	_, err = client.CreateBucketV2(context.Background(), &CreateBucketV2Input{Bucket: "bucket", StorageClass: "HOT"})
+	require.NotNil(t, err)
+	_, err = client.CreateBucketV2(context.Background(), &CreateBucketV2Input{Bucket: "bucket", AzRedundancy: "3-az"})
+	require.NotNil(t, err)
+	_, err = client.PutBucketStorageClassV2(context.Background(), &PutBucketStorageClassV2Input{Bucket: "bucket"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 2)
+}
+
+func TestListBucketsV2ProjectName(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"Buckets":[{"Name":"bucket","ProjectName":"tenant"}]}`)
+
+	output, err := client.ListBucketsV2(context.Background(), &ListBucketsV2Input{ProjectName: "tenant"})
+	require.Nil(t, err)
+	require.Equal(t, "tenant", transport.requests[0].Header.Get(HeaderProjectName))
+	require.Equal(t, "tenant", output.Buckets[0].ProjectName)
+
+	transport.respond(http.StatusOK, `{"Buckets":[]}`)
+	_, err = client.ListBucketsV2(context.Background(), nil)
+	require.Nil(t, err)
+	require.Empty(t, transport.requests[1].Header.Get(HeaderProjectName))
+}
+
+func TestGetBucketStatV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"StorageSize":3072,"ObjectCount":3,"LastModifyTime":1704067200,
+"StandardStorageSize":1024,"StandardObjectCount":1,"IaStorageSize":2048,"IaObjectCount":2}`)
+
+	output, err := client.GetBucketStatV2(context.Background(), &GetBucketStatV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodGet, transport.requests[0].Method)
+	require.Contains(t, transport.requests[0].Query, "stat")
+	require.Equal(t, int64(3072), output.StorageSize)
+	require.Equal(t, int64(3), output.ObjectCount)
+	require.Equal(t, int64(1704067200), output.LastModifyTime)
+	require.Equal(t, int64(1024), output.StandardStorageSize)
+	require.Equal(t, int64(2), output.IaObjectCount)
+	require.Equal(t, int64(0), output.ArchiveStorageSize)
+}
@@ -0,0 +1,38 @@
+package tos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// withCallback set X-Tos-Callback and X-Tos-Callback-Var of callback and vars, which are base64 encoded JSON
+func (rb *requestBuilder) withCallback(callback *Callback, vars map[string]string) error {
+	if callback == nil {
+		if len(vars) > 0 {
+			return newTosClientError("tos: CallbackVar is set without Callback", nil)
+		}
+		return nil
+	}
+	if len(callback.URL) == 0 || len(callback.Body) == 0 {
+		return newTosClientError("tos: URL and Body of Callback are required", nil)
+	}
+	data, err := json.Marshal(callback)
+	if err != nil {
+		return newTosClientError("tos: marshal Callback failed", err)
+	}
+	rb.WithHeader(HeaderCallback, base64.StdEncoding.EncodeToString(data))
+	if len(vars) == 0 {
+		return nil
+	}
+	for key := range vars {
+		if !strings.HasPrefix(key, "x:") {
+			return newTosClientError("tos: key of CallbackVar must start with \"x:\", got "+key, nil)
+		}
+	}
+	if data, err = json.Marshal(vars); err != nil {
+		return newTosClientError("tos: marshal CallbackVar failed", err)
+	}
+	rb.WithHeader(HeaderCallbackVar, base64.StdEncoding.EncodeToString(data))
+	return nil
+}
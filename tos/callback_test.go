@@ -0,0 +1,70 @@
+package tos
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeCallbackHeader(t *testing.T, value string) map[string]string {
+	data, err := base64.StdEncoding.DecodeString(value)
+	require.Nil(t, err)
+	var decoded map[string]string
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	return decoded
+}
+
+func TestPutObjectV2Callback(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderETag, `"etag"`)
+	client, transport := newRecordClient(t, http.StatusOK, `{"app":"ok"}`)
+	transport.res.Header = header
+
+	callback := &Callback{URL: "https://example.com/cb", Body: `{"key":"${object}","v":"${x:var}"}`,
+		BodyType: "application/json"}
+	output, err := client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", Callback: callback,
+			CallbackVar: map[string]string{"x:var": "value"}},
+		Content: strings.NewReader("a")})
+	require.Nil(t, err)
+	require.Equal(t, `{"app":"ok"}`, output.CallbackResult)
+	require.Equal(t, `"etag"`, output.ETag)
+	req := transport.requests[0]
+	require.Equal(t, map[string]string{"callbackUrl": "https://example.com/cb", "callbackBody": callback.Body,
+		"callbackBodyType": "application/json"}, decodeCallbackHeader(t, req.Header.Get(HeaderCallback)))
+	require.Equal(t, map[string]string{"x:var": "value"}, decodeCallbackHeader(t, req.Header.Get(HeaderCallbackVar)))
+
+	_, err = client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", Callback: callback,
+			CallbackVar: map[string]string{"var": "value"}},
+		Content: strings.NewReader("a")})
+	require.NotNil(t, err)
+	_, err = client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", Callback: &Callback{URL: "u"}},
+		Content:             strings.NewReader("a")})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 1)
+}
+
+func TestCompleteMultipartUploadV2Callback(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderETag, `"etag"`)
+	header.Set(HeaderVersionID, "v1")
+	client, transport := newRecordClient(t, http.StatusOK, "plain result")
+	transport.res.Header = header
+
+	output, err := client.CompleteMultipartUploadV2(context.Background(), &CompleteMultipartUploadV2Input{
+		Bucket: "bucket", Key: "key", UploadID: "upload", Parts: []UploadedPartV2{{PartNumber: 1, ETag: "etag"}},
+		Callback: &Callback{URL: "https://example.com/cb", Body: "bucket=${bucket}"}})
+	require.Nil(t, err)
+	require.Equal(t, "plain result", output.CallbackResult)
+	require.Equal(t, `"etag"`, output.ETag)
+	require.Equal(t, "v1", output.VersionID)
+	require.NotEmpty(t, transport.requests[0].Header.Get(HeaderCallback))
+	require.Empty(t, transport.requests[0].Header.Get(HeaderCallbackVar))
+}
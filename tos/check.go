@@ -1,6 +1,7 @@
 package tos
 
 import (
+	"strings"
 	"unicode/utf8"
 
 	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
@@ -76,3 +77,37 @@ func isValidACL(aclType enum.ACLType) error {
 
 	return newTosClientError("tos: invalid ACL", nil)
 }
+
+// isValidWebsiteRedirectLocation validate X-Tos-Website-Redirect-Location, which is either an object of the same
+// bucket starting with '/' or an absolute http(s) URL, return TosClientError if failed
+func isValidWebsiteRedirectLocation(location string) error {
+	if len(location) == 0 {
+		return nil
+	}
+	if len(location) > 2048 {
+		return newTosClientError("tos: invalid website redirect location, the length must be at most 2048", nil)
+	}
+	if !strings.HasPrefix(location, "/") && !strings.HasPrefix(location, "http://") &&
+		!strings.HasPrefix(location, "https://") {
+		return newTosClientError("tos: website redirect location must start with '/', 'http://' or 'https://'", nil)
+	}
+	return nil
+}
+
+// isValidStorageClass validate storageClass, return TosClientError if failed
+func isValidStorageClass(storageClass enum.StorageClassType) error {
+	switch storageClass {
+	case enum.StorageClassStandard, enum.StorageClassIa, enum.StorageClassArchiveFr, enum.StorageClassArchive,
+		enum.StorageClassColdArchive, enum.StorageClassIntelligentTiering:
+		return nil
+	}
+	return newTosClientError("tos: invalid storage class", nil)
+}
+
+// isValidAzRedundancy validate azRedundancy, return TosClientError if failed
+func isValidAzRedundancy(azRedundancy enum.AzRedundancyType) error {
+	if azRedundancy == enum.AzRedundancySingleAz || azRedundancy == enum.AzRedundancyMultiAz {
+		return nil
+	}
+	return newTosClientError("tos: invalid AZ redundancy", nil)
+}
@@ -0,0 +1,168 @@
+package tos
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// checkpointVersion is the schema version of checkpoint files written by this SDK.
+// Bump it and register a migration in checkpointMigrations whenever the checkpoint schema changes,
+// so that checkpoints written by older SDK versions can still be resumed.
+const checkpointVersion = 1
+
+// checkpointMigrations upgrade a raw checkpoint from version key to version key+1
+var checkpointMigrations = map[int]func(raw map[string]json.RawMessage) error{
+	// version 0 is the schema before Version field is introduced, it's compatible with version 1
+	0: func(raw map[string]json.RawMessage) error { return nil },
+}
+
+// migrateCheckpoint upgrade raw checkpoint to checkpointVersion.
+// Return TosClientError if the checkpoint is written by a newer SDK version.
+func migrateCheckpoint(name string, raw map[string]json.RawMessage) error {
+	version := 0
+	if v, ok := raw["Version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return newTosClientError("tos: invalid version of checkpoint file "+name, err)
+		}
+	}
+	if version > checkpointVersion {
+		return newTosClientError(fmt.Sprintf("tos: checkpoint file %s has version %d, which is not supported by "+
+			"this SDK (max version %d), please upgrade the SDK or remove the checkpoint file", name, version, checkpointVersion), nil)
+	}
+	for ; version < checkpointVersion; version++ {
+		migrate, ok := checkpointMigrations[version]
+		if !ok {
+			return newTosClientError(fmt.Sprintf("tos: no migration for version %d of checkpoint file %s", version, name), nil)
+		}
+		if err := migrate(raw); err != nil {
+			return newTosClientError("tos: migrate checkpoint file failed", err)
+		}
+	}
+	raw["Version"], _ = json.Marshal(checkpointVersion)
+	return nil
+}
+
+// loadCheckPoint load UploadFile checkpoint or DownloadFile checkpoint, checkpoint must be a pointer.
+// Return false if checkpoint file not exists or is corrupted, then the transfer should start over.
+// Return TosClientError if the checkpoint file can not be understood by this SDK version.
+func loadCheckPoint(path string, checkpoint interface{}) (bool, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, nil
+	}
+	return decodeCheckpoint(path, contents, checkpoint)
+}
+
+// decodeCheckpoint decode contents of checkpoint named name, see loadCheckPoint
+func decodeCheckpoint(name string, contents []byte, checkpoint interface{}) (bool, error) {
+	if len(contents) == 0 {
+		return false, nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(contents, &raw); err != nil {
+		return false, nil
+	}
+	if err := migrateCheckpoint(name, raw); err != nil {
+		return false, err
+	}
+	contents, err := json.Marshal(raw)
+	if err != nil {
+		return false, nil
+	}
+	if err = json.Unmarshal(contents, checkpoint); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// ExportUploadCheckpoint export the checkpoint of UploadFile in a portable format, which is not bound to the local file,
+// so that the upload can be continued on another host with ImportUploadCheckpoint.
+func (cli *ClientV2) ExportUploadCheckpoint(input *ExportUploadCheckpointInput) (*ExportUploadCheckpointOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	path := uploadCheckpointFile(input.FilePath, input.Bucket, input.Key, input.CheckpointFile, cli.config.CheckpointDir)
+	checkpoint := &uploadCheckpoint{}
+	ok, err := loadCheckPoint(path, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(checkpoint.UploadID) == 0 || checkpoint.Bucket != input.Bucket || checkpoint.Key != input.Key {
+		return nil, newTosClientError("tos: no valid upload checkpoint in file "+path, nil)
+	}
+	checkpoint.FilePath = ""
+	checkpoint.FileInfo.LastModified = 0
+	checkpoint.Version = checkpointVersion
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return nil, newTosClientError(err.Error(), err)
+	}
+	return &ExportUploadCheckpointOutput{UploadID: checkpoint.UploadID, Checkpoint: data}, nil
+}
+
+// ImportUploadCheckpoint write a checkpoint exported by ExportUploadCheckpoint as the checkpoint of local file,
+// then UploadFile with the same Bucket, Key, FilePath and CheckpointFile continues the upload.
+//
+// The local file must have the same size, and content of uploaded parts is verified with their crc64.
+func (cli *ClientV2) ImportUploadCheckpoint(input *ImportUploadCheckpointInput) (*ImportUploadCheckpointOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	checkpoint := &uploadCheckpoint{}
+	ok, err := decodeCheckpoint("imported checkpoint", input.Checkpoint, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(checkpoint.UploadID) == 0 {
+		return nil, newTosClientError("tos: invalid upload checkpoint", nil)
+	}
+	if checkpoint.Bucket != input.Bucket || checkpoint.Key != input.Key {
+		return nil, newTosClientError(fmt.Sprintf("tos: the checkpoint is for bucket %s and key %s",
+			checkpoint.Bucket, checkpoint.Key), nil)
+	}
+	stat, err := os.Stat(input.FilePath)
+	if err != nil {
+		return nil, newTosClientError("tos: stat file to upload failed", err)
+	}
+	if stat.Size() != checkpoint.FileInfo.Size {
+		return nil, newTosClientError(fmt.Sprintf("tos: size of file %s is %d, but %d in checkpoint",
+			input.FilePath, stat.Size(), checkpoint.FileInfo.Size), nil)
+	}
+	if err = verifyUploadedParts(input.FilePath, checkpoint.PartsInfo); err != nil {
+		return nil, err
+	}
+	checkpoint.FilePath = input.FilePath
+	checkpoint.FileInfo.LastModified = stat.ModTime().Unix()
+	checkpoint.checkpointPath = uploadCheckpointFile(input.FilePath, input.Bucket, input.Key,
+		input.CheckpointFile, cli.config.CheckpointDir)
+	if err = checkpoint.WriteToFile(); err != nil {
+		return nil, err
+	}
+	return &ImportUploadCheckpointOutput{UploadID: checkpoint.UploadID, CheckpointFile: checkpoint.checkpointPath}, nil
+}
+
+// verifyUploadedParts compare crc64 of completed parts with the local file
+func verifyUploadedParts(filePath string, parts []uploadPartInfo) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return newTosClientError("tos: open file to upload failed", err)
+	}
+	defer file.Close()
+	for _, part := range parts {
+		if !part.IsCompleted || part.HashCrc64ecma == 0 {
+			continue
+		}
+		checker := NewCRC(DefaultCrcTable(), 0)
+		if _, err = io.Copy(checker, io.NewSectionReader(file, int64(part.Offset), part.PartSize)); err != nil {
+			return newTosClientError("tos: read file to upload failed", err)
+		}
+		if checker.Sum64() != part.HashCrc64ecma {
+			return newTosClientError(fmt.Sprintf("tos: part %d of file %s differs from the uploaded one",
+				part.PartNumber, filePath), nil)
+		}
+	}
+	return nil
+}
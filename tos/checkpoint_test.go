@@ -0,0 +1,173 @@
+package tos
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeCheckpointFile(t *testing.T, contents string) string {
+	dir, err := ioutil.TempDir("", "tos-checkpoint")
+	require.Nil(t, err)
+	path := filepath.Join(dir, "checkpoint")
+	require.Nil(t, ioutil.WriteFile(path, []byte(contents), 0666))
+	return path
+}
+
+func TestLoadCheckpoint(t *testing.T) {
+	// checkpoint written before Version is introduced
+	path := writeCheckpointFile(t, `{"Bucket":"bucket","Key":"key","UploadID":"upload","PartSize":5242880}`)
+	defer os.RemoveAll(filepath.Dir(path))
+	checkpoint := &uploadCheckpoint{}
+	ok, err := loadCheckPoint(path, checkpoint)
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, checkpointVersion, checkpoint.Version)
+	require.Equal(t, "upload", checkpoint.UploadID)
+
+	// checkpoint written by a newer SDK
+	path = writeCheckpointFile(t, `{"Version":100,"Bucket":"bucket","Key":"key","UploadID":"upload"}`)
+	defer os.RemoveAll(filepath.Dir(path))
+	ok, err = loadCheckPoint(path, &uploadCheckpoint{})
+	require.False(t, ok)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "version 100")
+
+	// corrupted checkpoint, start over
+	path = writeCheckpointFile(t, `{"Bucket":`)
+	defer os.RemoveAll(filepath.Dir(path))
+	ok, err = loadCheckPoint(path, &uploadCheckpoint{})
+	require.False(t, ok)
+	require.Nil(t, err)
+
+	ok, err = loadCheckPoint(path+".not-exist", &uploadCheckpoint{})
+	require.False(t, ok)
+	require.Nil(t, err)
+}
+
+func TestGetUploadCheckpoint(t *testing.T) {
+	path := writeCheckpointFile(t, "")
+	defer os.RemoveAll(filepath.Dir(path))
+	created := &uploadCheckpoint{checkpointPath: path, Bucket: "bucket", Key: "key", UploadID: "upload"}
+	inits := 0
+	init := func() (*uploadCheckpoint, error) {
+		inits++
+		return created, nil
+	}
+	valid := func(checkpoint *uploadCheckpoint) bool { return checkpoint.UploadID == "upload" }
+
+	checkpoint, err := getUploadCheckpoint(true, path, valid, init)
+	require.Nil(t, err)
+	require.Equal(t, 1, inits)
+	require.Equal(t, created, checkpoint)
+
+	// reuse the persisted upload
+	checkpoint, err = getUploadCheckpoint(true, path, valid, init)
+	require.Nil(t, err)
+	require.Equal(t, 1, inits)
+	require.Equal(t, "upload", checkpoint.UploadID)
+	require.Equal(t, path, checkpoint.checkpointPath)
+
+	// invalid checkpoint, start over
+	_, err = getUploadCheckpoint(true, path, func(*uploadCheckpoint) bool { return false }, init)
+	require.Nil(t, err)
+	require.Equal(t, 2, inits)
+}
+
+func TestCheckpointDir(t *testing.T) {
+	require.Nil(t, os.Setenv(EnvCheckpointDir, "/env/checkpoint"))
+	require.Nil(t, os.Setenv(EnvTempFileDir, "/env/temp"))
+	defer os.Unsetenv(EnvCheckpointDir)
+	defer os.Unsetenv(EnvTempFileDir)
+
+	client, err := NewClientV2("tos-cn-beijing.volces.com")
+	require.Nil(t, err)
+	require.Equal(t, "/env/checkpoint", client.config.CheckpointDir)
+	require.Equal(t, "/env/temp", client.config.TempFileDir)
+	temp := client.tempFilePath("/data/file", "bucket", "key")
+	require.Equal(t, "/env/temp", filepath.Dir(temp))
+	require.NotEqual(t, temp, client.tempFilePath("/data/file", "bucket", "key2"))
+
+	client, err = NewClientV2("tos-cn-beijing.volces.com", WithCheckpointDir("/checkpoint"), WithTempFileDir(""))
+	require.Nil(t, err)
+	require.Equal(t, "/checkpoint", client.config.CheckpointDir)
+	require.Equal(t, "/data/file"+TempFileSuffix, client.tempFilePath("/data/file", "bucket", "key"))
+
+	path := writeCheckpointFile(t, "data")
+	defer os.RemoveAll(filepath.Dir(path))
+	input := &UploadFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:                     path,
+		EnableCheckpoint:             true,
+	}
+	require.Nil(t, validateUploadInput(input, "/checkpoint"))
+	require.Equal(t, "/checkpoint", filepath.Dir(input.CheckpointFile))
+
+	// CheckpointFile of input takes precedence
+	input.CheckpointFile = "/input/checkpoint"
+	require.Nil(t, validateUploadInput(input, "/checkpoint"))
+	require.Equal(t, "/input/checkpoint", input.CheckpointFile)
+}
+
+func TestExportImportUploadCheckpoint(t *testing.T) {
+	content := []byte(strings.Repeat("a", MinPartSize) + "tail")
+	hostA := writeCheckpointFile(t, "")
+	defer os.RemoveAll(filepath.Dir(hostA))
+	fileA := filepath.Join(filepath.Dir(hostA), "file")
+	require.Nil(t, ioutil.WriteFile(fileA, content, 0666))
+
+	// upload started on host A, the first part is uploaded
+	input := &UploadFileInput{CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+		FilePath: fileA, PartSize: MinPartSize, CheckpointFile: hostA}
+	checkpoint, err := initUploadCheckpoint(input, &CreateMultipartUploadV2Output{UploadID: "upload"})
+	require.Nil(t, err)
+	checker := NewCRC(DefaultCrcTable(), 0)
+	_, _ = checker.Write(content[:MinPartSize])
+	checkpoint.PartsInfo[0].IsCompleted = true
+	checkpoint.PartsInfo[0].ETag = "etag"
+	checkpoint.PartsInfo[0].HashCrc64ecma = checker.Sum64()
+	require.Nil(t, checkpoint.WriteToFile())
+
+	client, err := NewClientV2("tos-cn-beijing.volces.com")
+	require.Nil(t, err)
+	exported, err := client.ExportUploadCheckpoint(&ExportUploadCheckpointInput{Bucket: "bucket", Key: "key",
+		FilePath: fileA, CheckpointFile: hostA})
+	require.Nil(t, err)
+	require.Equal(t, "upload", exported.UploadID)
+	require.NotContains(t, string(exported.Checkpoint), fileA)
+
+	// continue on host B
+	dirB, err := ioutil.TempDir("", "tos-checkpoint")
+	require.Nil(t, err)
+	defer os.RemoveAll(dirB)
+	fileB := filepath.Join(dirB, "copy")
+	require.Nil(t, ioutil.WriteFile(fileB, content, 0666))
+	imported, err := client.ImportUploadCheckpoint(&ImportUploadCheckpointInput{Bucket: "bucket", Key: "key",
+		FilePath: fileB, Checkpoint: exported.Checkpoint})
+	require.Nil(t, err)
+	require.Equal(t, uploadCheckpointFile(fileB, "bucket", "key", "", ""), imported.CheckpointFile)
+
+	loaded := &uploadCheckpoint{}
+	ok, err := loadCheckPoint(imported.CheckpointFile, loaded)
+	require.Nil(t, err)
+	require.True(t, ok)
+	stat, err := os.Stat(fileB)
+	require.Nil(t, err)
+	require.True(t, loaded.Valid(stat, "bucket", "key", fileB))
+	require.Equal(t, []UploadedPartV2{{PartNumber: 1, ETag: "etag"}, {PartNumber: 2}}, loaded.GetParts())
+
+	// content of uploaded part differs
+	require.Nil(t, ioutil.WriteFile(fileB, []byte(strings.Repeat("b", MinPartSize)+"tail"), 0666))
+	_, err = client.ImportUploadCheckpoint(&ImportUploadCheckpointInput{Bucket: "bucket", Key: "key",
+		FilePath: fileB, Checkpoint: exported.Checkpoint})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "part 1")
+
+	_, err = client.ImportUploadCheckpoint(&ImportUploadCheckpointInput{Bucket: "bucket", Key: "other",
+		FilePath: fileB, Checkpoint: exported.Checkpoint})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,129 @@
+package tos
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitBreakerOpen is the cause of TosClientError returned when requests to a host are rejected by circuit breaker
+var ErrCircuitBreakerOpen = errors.New("tos: circuit breaker is open")
+
+const DefaultCircuitBreakerCooldown = 30 * time.Second
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold the number of consecutive connection failures or 5xx responses to a host to open the breaker,
+	// 0 means circuit breaker is disabled
+	FailureThreshold int
+
+	// Cooldown requests to the host fail fast during cooldown after the breaker opens,
+	// then a single probe request is allowed to decide whether to close the breaker. The default is 30s
+	Cooldown time.Duration
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker is the breaker of one host
+type circuitBreaker struct {
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// CircuitBreakerTransport wraps a Transport with per-host circuit breakers
+type CircuitBreakerTransport struct {
+	base     Transport
+	config   CircuitBreakerConfig
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+	now      func() time.Time
+}
+
+// NewCircuitBreakerTransport create a CircuitBreakerTransport, requests are sent by base
+func NewCircuitBreakerTransport(base Transport, config CircuitBreakerConfig) *CircuitBreakerTransport {
+	if config.Cooldown <= 0 {
+		config.Cooldown = DefaultCircuitBreakerCooldown
+	}
+	return &CircuitBreakerTransport{
+		base:     base,
+		config:   config,
+		breakers: make(map[string]*circuitBreaker),
+		now:      time.Now,
+	}
+}
+
+// allow report whether a request to host can be sent
+func (t *CircuitBreakerTransport) allow(host string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cb, ok := t.breakers[host]
+	if !ok {
+		return true
+	}
+	switch cb.state {
+	case circuitOpen:
+		if t.now().Sub(cb.openedAt) < t.config.Cooldown {
+			return false
+		}
+		// cooldown passed, let this request probe the host
+		cb.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// a probe is in flight
+		return false
+	}
+	return true
+}
+
+// record update breaker of host with the result of a request
+func (t *CircuitBreakerTransport) record(host string, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cb, ok := t.breakers[host]
+	if !failed {
+		if ok {
+			delete(t.breakers, host)
+		}
+		return
+	}
+	if !ok {
+		cb = &circuitBreaker{}
+		t.breakers[host] = cb
+	}
+	cb.failures++
+	if cb.state == circuitHalfOpen || cb.failures >= t.config.FailureThreshold {
+		cb.state = circuitOpen
+		cb.openedAt = t.now()
+	}
+}
+
+// abandon give up the probe of host if there is one, so that the next request probes it again
+func (t *CircuitBreakerTransport) abandon(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cb, ok := t.breakers[host]; ok && cb.state == circuitHalfOpen {
+		// cooldown has passed, keep openedAt
+		cb.state = circuitOpen
+	}
+}
+
+func (t *CircuitBreakerTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	if !t.allow(req.Host) {
+		return nil, newTosClientError("tos: circuit breaker is open for host "+req.Host, ErrCircuitBreakerOpen)
+	}
+	res, err := t.base.RoundTrip(ctx, req)
+	// request canceled by caller says nothing about the host
+	if err != nil && ctx.Err() != nil {
+		t.abandon(req.Host)
+		return res, err
+	}
+	t.record(req.Host, err != nil || res.StatusCode >= 500)
+	return res, err
+}
@@ -0,0 +1,77 @@
+package tos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockTransport struct {
+	calls int
+	res   *Response
+	err   error
+}
+
+func (m *mockTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	m.calls++
+	return m.res, m.err
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	base := &mockTransport{err: errors.New("connection refused")}
+	transport := NewCircuitBreakerTransport(base, CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute})
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	req := &Request{Host: "bucket.tos-cn-beijing.volces.com"}
+	ctx := context.Background()
+
+	// opens after 2 consecutive failures
+	for i := 0; i < 2; i++ {
+		_, err := transport.RoundTrip(ctx, req)
+		require.Equal(t, base.err, err)
+	}
+	_, err := transport.RoundTrip(ctx, req)
+	require.NotNil(t, err)
+	require.Equal(t, ErrCircuitBreakerOpen, err.(*TosClientError).Cause)
+	require.Equal(t, 2, base.calls)
+
+	// other hosts are not affected
+	_, err = transport.RoundTrip(ctx, &Request{Host: "other.tos-cn-beijing.volces.com"})
+	require.Equal(t, base.err, err)
+	require.Equal(t, 3, base.calls)
+
+	// half-open probe fails, opens again
+	now = now.Add(time.Minute)
+	base.err, base.res = nil, &Response{StatusCode: http.StatusServiceUnavailable}
+	_, err = transport.RoundTrip(ctx, req)
+	require.Nil(t, err)
+	require.Equal(t, 4, base.calls)
+	_, err = transport.RoundTrip(ctx, req)
+	require.Equal(t, ErrCircuitBreakerOpen, err.(*TosClientError).Cause)
+
+	// half-open probe succeeds, closes
+	now = now.Add(time.Minute)
+	base.res = &Response{StatusCode: http.StatusOK}
+	_, err = transport.RoundTrip(ctx, req)
+	require.Nil(t, err)
+	_, err = transport.RoundTrip(ctx, req)
+	require.Nil(t, err)
+	require.Equal(t, 6, base.calls)
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithCircuitBreaker(5, 0))
+	require.Nil(t, err)
+	transport, ok := client.transport.(*CircuitBreakerTransport)
+	require.True(t, ok)
+	require.Equal(t, DefaultCircuitBreakerCooldown, transport.config.Cooldown)
+
+	client, err = NewClientV2("tos-cn-beijing.volces.com")
+	require.Nil(t, err)
+	_, ok = client.transport.(*DefaultTransport)
+	require.True(t, ok)
+}
@@ -2,7 +2,10 @@ package tos
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/url"
@@ -37,9 +40,34 @@ type Client struct {
 	recognizer   ContentTypeRecognizer
 	config       Config
 	retry        *retryer
+	classifier   Classifier // nullable
 	dnsCacheTime time.Duration // milliseconds
 	enableCRC    bool
 	proxy        *Proxy
+
+	endpointResolver EndpointResolver // nullable
+	redirects        *redirectCache   // nullable, set by WithAutoRedirect
+	rateLimiter      RateLimiter      // nullable, shared by all transfers of the client
+	readEndpoint     string           // set by WithReadEndpoint
+	readYourWrites   time.Duration    // set by WithReadYourWrites
+	readRouter       *readRouter      // nullable, built from readEndpoint and readYourWrites
+	logger           Logger           // set by WithLogger, never nil after initClient
+	tracer           Tracer           // nullable, set by WithTracerProvider
+	limits           *operationLimits // nullable, set by WithOperationConcurrency
+	metrics          MetricsCollector // nullable, set by WithMetricsCollector
+	middlewares      []Middleware     // set by WithMiddleware
+	hooks            *Hooks           // nullable, set by WithHooks
+	idempotency      bool             // set by WithIdempotencyToken
+	debugDump        io.Writer        // nullable, set by WithDebugHTTPDump
+	debugDumpBody    int64            // set by WithDebugHTTPDumpBody
+	stats            *clientStats     // see Stats, never nil after initClient
+	retryBufferSize  int64            // set by WithRetryBufferSize
+	attemptTimeout   time.Duration    // set by WithAttemptTimeout
+	operationTimeout time.Duration    // set by WithOperationDeadline
+	customDomain     bool             // set by WithCustomDomain
+	accelerate       bool             // set by WithAccelerate
+
+	queryCanonicalization QueryCanonicalization
 }
 
 // ClientV2 TOS ClientV2
@@ -102,6 +130,29 @@ func WithMaxConnections(max int) ClientOption {
 
 }
 
+// WithMaxIdleConnsPerHost set maximum number of idle http connections kept for each host,
+// it should be no less than the concurrency of transfers to avoid reconnecting
+func WithMaxIdleConnsPerHost(max int) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.MaxIdleConnsPerHost = max
+	}
+}
+
+// WithMaxConnsPerHost set maximum number of http connections to each host, including active and idle ones,
+// 0 means no limit
+func WithMaxConnsPerHost(max int) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.MaxConnsPerHost = max
+	}
+}
+
+// WithTCPKeepAlive set interval of TCP keep-alive probes of http connections, negative value disables it
+func WithTCPKeepAlive(interval time.Duration) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.KeepAlive = interval
+	}
+}
+
 // WithIdleConnTimeout set max idle time of a http connection
 func WithIdleConnTimeout(timeout time.Duration) ClientOption {
 	return func(client *Client) {
@@ -141,14 +192,102 @@ func WithUserAgentSuffix(suffix string) ClientOption {
 // 	}
 // }
 
-// // WithMaxRetryCount set MaxRetryCount
-// func WithMaxRetryCount(retryCount int) ClientOption {
-// 	return func(client *Client) {
-// 		if client.retry != nil {
-// 			client.retry.SetBackoff(retryer.exponentialBackoff(retryCount, retryer.DefaultRetryBackoffBase))
-// 		}
-// 	}
-// }
+// WithMaxRetryCount set max number of retries of a request, only takes effect on ClientV2
+func WithMaxRetryCount(retryCount int) ClientOption {
+	return func(client *Client) {
+		client.config.RetryConfig.MaxRetryCount = retryCount
+	}
+}
+
+// WithRetryBackoff set back-off between retries, the back-off starts at base and doubles after each retry,
+// and it will never exceed max. max set to 0 means no limit.
+func WithRetryBackoff(base, max time.Duration) ClientOption {
+	return func(client *Client) {
+		client.config.RetryConfig.BackoffBase = base
+		client.config.RetryConfig.MaxBackoff = max
+	}
+}
+
+// WithRetryMode set retry mode, the default is RetryModeStandard.
+//
+// In RetryModeAdaptive, all requests on the client share a retry budget, which prevents retry storms
+// against a throttled or failing service.
+func WithRetryMode(mode RetryMode) ClientOption {
+	return func(client *Client) {
+		client.config.RetryConfig.Mode = mode
+	}
+}
+
+// WithRetryJitter set jitter of back-off, each back-off is adjusted by a random factor in range (-jitter, +jitter).
+// jitter must be in [0.0, 1.0], the default is 0.25
+func WithRetryJitter(jitter float64) ClientOption {
+	return func(client *Client) {
+		client.config.RetryConfig.Jitter = jitter
+	}
+}
+
+// WithRetryClassifier set Classifier to decide which errors are retryable, only takes effect on ClientV2.
+// It replaces the default classifiers of all requests, except those never retried such as AppendObjectV2, and those
+// with content which can't be sent again, e.g. an io.Reader which is not an io.Seeker.
+func WithRetryClassifier(classifier Classifier) ClientOption {
+	return func(client *Client) {
+		client.classifier = classifier
+	}
+}
+
+// WithThrottleCallback set a callback to observe requests throttled by server with 429 or 503.
+// When a throttled response carries Retry-After header, the server-specified interval is waited
+// instead of the back-off.
+func WithThrottleCallback(callback func(event *ThrottleEvent)) ClientOption {
+	return func(client *Client) {
+		client.config.RetryConfig.ThrottleCallback = callback
+	}
+}
+
+// WithCheckpointDir set the directory of checkpoint files, used when CheckpointFile of input is not set.
+// It overrides environment variable TOS_CHECKPOINT_DIR
+func WithCheckpointDir(dir string) ClientOption {
+	return func(client *Client) {
+		client.config.CheckpointDir = dir
+	}
+}
+
+// WithTempFileDir set the directory of temp files when downloading to file, it should be on the same filesystem as
+// the target file, or renaming temp file will fail. It overrides environment variable TOS_TEMP_FILE_DIR
+func WithTempFileDir(dir string) ClientOption {
+	return func(client *Client) {
+		client.config.TempFileDir = dir
+	}
+}
+
+// WithCircuitBreaker enable per-host circuit breaker. The breaker of a host opens after failureThreshold consecutive
+// connection failures or 5xx responses, then requests to the host fail fast with ErrCircuitBreakerOpen as cause
+// during cooldown. After cooldown, a single probe request decides whether to close the breaker.
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) ClientOption {
+	return func(client *Client) {
+		client.config.CircuitBreakerConfig = CircuitBreakerConfig{
+			FailureThreshold: failureThreshold,
+			Cooldown:         cooldown,
+		}
+	}
+}
+
+// WithQueryCanonicalization set how query string is canonicalized when signing requests,
+// use QueryCanonicalizationS3Compat to work with S3-compatible gateways.
+// It has no effect on the Signer set by WithSigner.
+func WithQueryCanonicalization(mode QueryCanonicalization) ClientOption {
+	return func(client *Client) {
+		client.queryCanonicalization = mode
+	}
+}
+
+// WithTransferRampUp set the duration within which part workers of UploadFile start,
+// to avoid connection and throttling storms when many transfers start at the same time
+func WithTransferRampUp(duration time.Duration) ClientOption {
+	return func(client *Client) {
+		client.config.RampUpPolicy = RampUpPolicy{Duration: duration}
+	}
+}
 
 // WithTransport set Transport
 func WithTransport(transport Transport) ClientOption {
@@ -157,6 +296,24 @@ func WithTransport(transport Transport) ClientOption {
 	}
 }
 
+// WithHTTPClient set the http.Client to send requests, e.g. a shared instrumented client.
+// Requests are still signed, retried and checked by the SDK. TransportConfig is ignored if it's set, nil is ignored.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) {
+		if httpClient != nil {
+			client.transport = NewDefaultTransportWithClient(*httpClient)
+		}
+	}
+}
+
+// WithHTTPRoundTripper set the http.RoundTripper to send requests.
+// Requests are still signed, retried and checked by the SDK. TransportConfig is ignored if it's set.
+func WithHTTPRoundTripper(roundTripper http.RoundTripper) ClientOption {
+	return func(client *Client) {
+		client.transport = NewDefaultTransportWithClient(http.Client{Transport: roundTripper})
+	}
+}
+
 // WithTransportConfig set TransportConfig
 func WithTransportConfig(config *TransportConfig) ClientOption {
 	return func(client *Client) {
@@ -165,6 +322,54 @@ func WithTransportConfig(config *TransportConfig) ClientOption {
 	}
 }
 
+// WithHTTP2Enabled set whether to try HTTP/2 on TLS connections, HTTP/2 is disabled by default.
+// It has no effect on the Transport set by WithTransport.
+func WithHTTP2Enabled(enabled bool) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.EnableHTTP2 = enabled
+	}
+}
+
+// WithTLSConfig set tls.Config of connections, it takes precedence over
+// WithClientCertificate, WithRootCAs and InsecureSkipVerify of TransportConfig.
+// It has no effect on the Transport set by WithTransport.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.TLSConfig = config
+	}
+}
+
+// WithClientCertificate add a client certificate presented to server for mutual TLS
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.ClientCertificates = append(client.config.TransportConfig.ClientCertificates, cert)
+	}
+}
+
+// WithRootCAs set CA certificates to verify server, e.g. a private CA bundle, the system pool is used by default
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.RootCAs = pool
+	}
+}
+
+// WithIPv4Only set whether to dial IPv4 addresses of the endpoint only, e.g. in networks where IPv6 is announced
+// but unreachable. By default, both IPv4 and IPv6 addresses of a dual-stack endpoint are tried.
+// The endpoint itself is not changed, pass an IPv6 literal like "[::1]:8080" to NewClientV2 to connect by IPv6.
+func WithIPv4Only(ipv4Only bool) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.IPv4Only = ipv4Only
+	}
+}
+
+// WithDialContext set the function to dial connections, e.g. dial a local proxy.
+// It has no effect on the Transport set by WithTransport.
+func WithDialContext(dialContext func(ctx context.Context, network, address string) (net.Conn, error)) ClientOption {
+	return func(client *Client) {
+		client.config.TransportConfig.DialContext = dialContext
+	}
+}
+
 // WithSocketTimeout set read-write timeout
 func WithSocketTimeout(readTimeout, writeTimeout time.Duration) ClientOption {
 	return func(client *Client) {
@@ -184,6 +389,156 @@ func WithRegion(region string) ClientOption {
 	}
 }
 
+// WithRateLimiter set a RateLimiter shared by all uploads and downloads of the client, including parts of UploadFile.
+// It works together with the RateLimiter of each input. Use NewDefaultRateLimiter to create a token bucket.
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(client *Client) {
+		client.rateLimiter = limiter
+	}
+}
+
+// WithEndpointResolver route requests of each bucket with resolver,
+// so buckets in different regions or behind different gateways can be accessed by one client.
+// The endpoint of client is used when resolver returns nil.
+func WithEndpointResolver(resolver EndpointResolver) ClientOption {
+	return func(client *Client) {
+		client.endpointResolver = resolver
+	}
+}
+
+// WithLogger set the Logger of client, which logs requests at debug level, retries at warn level,
+// and failures inside transfer helpers like UploadFile. Logs are dropped by default.
+// Use NewStdLogger or NewSlogLogger to adapt loggers of the standard library.
+func WithLogger(logger Logger) ClientOption {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
+
+// WithTracerProvider emit a span for each operation with the Tracer of provider, including each part of UploadFile,
+// spans of parts are children of the span of UploadFile. See SpanAttribute* constants for attributes of spans.
+func WithTracerProvider(provider TracerProvider) ClientOption {
+	return func(client *Client) {
+		client.tracer = nil
+		if provider != nil {
+			client.tracer = provider.Tracer(tracerName)
+		}
+	}
+}
+
+// WithHooks set callbacks called before each attempt, after each response and before each retry of requests
+func WithHooks(hooks Hooks) ClientOption {
+	return func(client *Client) {
+		client.hooks = &hooks
+	}
+}
+
+// WithAttemptTimeout set the default time limit of each attempt of calls, from sending the request to receiving
+// response headers, a timed out attempt is retried if the call is retryable. 0 means no limit.
+// WithResponseHeaderTimeout of calls overrides it.
+func WithAttemptTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		client.attemptTimeout = timeout
+	}
+}
+
+// WithOperationDeadline set the default total time limit of calls, including all attempts, sleeps between them,
+// and reading the response body. Retries are not attempted if the back-off exceeds the deadline. 0 means no limit.
+// WithOperationTimeout of calls overrides it, and an earlier deadline of ctx takes effect.
+func WithOperationDeadline(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		client.operationTimeout = timeout
+	}
+}
+
+// WithRetryBufferSize buffer content of PutObjectV2 and UploadPartV2 in memory if it's not seekable
+// and not larger than size, so that failed attempts can be retried. Uploads of content which is neither seekable
+// nor buffered are not retried unless ContentProvider of input is set. 0 means no buffering, which is the default.
+func WithRetryBufferSize(size int64) ClientOption {
+	return func(client *Client) {
+		client.retryBufferSize = size
+	}
+}
+
+// WithDebugHTTPDump write each request sent on the wire and its response to w, including retries and redirects,
+// to debug signature or proxy issues. Authorization, security tokens, SSE-C keys and signatures of pre-signed URLs
+// are redacted. Bodies are not dumped unless WithDebugHTTPDumpBody is set. Don't enable it in production.
+func WithDebugHTTPDump(w io.Writer) ClientOption {
+	return func(client *Client) {
+		client.debugDump = w
+	}
+}
+
+// WithDebugHTTPDumpBody dump up to maxBodySize bytes of request and response bodies with WithDebugHTTPDump
+func WithDebugHTTPDumpBody(maxBodySize int64) ClientOption {
+	return func(client *Client) {
+		client.debugDumpBody = maxBodySize
+	}
+}
+
+// WithIdempotencyToken generate a random token in header X-Tos-Idempotency-Token for each PUT, POST and DELETE request,
+// the same token is sent by all retries and redirects of the request, so that the server supporting it can
+// de-duplicate retried writes. Tokens set by WithHeader(HeaderIdempotencyToken, token) are kept as is.
+func WithIdempotencyToken(enable bool) ClientOption {
+	return func(client *Client) {
+		client.idempotency = enable
+	}
+}
+
+// WithMiddleware add middlewares wrapping the signing and sending of each request,
+// the first one is the outermost, and middlewares added earlier are outside of those added later.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(client *Client) {
+		client.middlewares = append(client.middlewares, middlewares...)
+	}
+}
+
+// WithMetricsCollector record metrics of each operation and each UploadFile with collector
+func WithMetricsCollector(collector MetricsCollector) ClientOption {
+	return func(client *Client) {
+		client.metrics = collector
+	}
+}
+
+// WithOperationConcurrency limit the number of concurrent requests of each operation class independently,
+// e.g. so that a burst of listings can't starve object reads. Requests wait for a slot until their context is done.
+func WithOperationConcurrency(concurrency OperationConcurrency) ClientOption {
+	return func(client *Client) {
+		client.limits = newOperationLimits(concurrency)
+	}
+}
+
+// WithReadEndpoint send GET and HEAD requests of buckets to endpoint, e.g. a read replica or a CDN,
+// other requests are still sent to the endpoint of client. Use WithReadYourWrites to avoid stale reads after writes.
+// Requests routed by EndpointResolver or redirects are not affected.
+func WithReadEndpoint(endpoint string) ClientOption {
+	return func(client *Client) {
+		client.readEndpoint = endpoint
+	}
+}
+
+// WithReadYourWrites keep reads of a bucket on the endpoint of client for window after a successful write
+// to the bucket via the client, so that the written data can be read back. It takes effect with WithReadEndpoint.
+func WithReadYourWrites(window time.Duration) ClientOption {
+	return func(client *Client) {
+		client.readYourWrites = window
+	}
+}
+
+// WithAutoRedirect set whether to follow 301 responses of buckets in other regions, the default is disabled.
+// If enabled, requests are re-signed and sent again to the endpoint of the region indicated by server,
+// and the endpoint is cached for later requests of the bucket.
+// Requests with a body can be sent again only if the body implements io.Seeker.
+func WithAutoRedirect(enable bool) ClientOption {
+	return func(client *Client) {
+		if enable {
+			client.redirects = newRedirectCache()
+		} else {
+			client.redirects = nil
+		}
+	}
+}
+
 // WithSigner for self-defined Signer
 func WithSigner(signer Signer) ClientOption {
 	return func(client *Client) {
@@ -191,6 +546,23 @@ func WithSigner(signer Signer) ClientOption {
 	}
 }
 
+// WithCustomDomain set whether the endpoint is a custom domain bound to a bucket by PutBucketCustomDomainV2,
+// the default is disabled. If enabled, requests are sent to http(s)://{endpoint}/{object} whatever the bucket is.
+func WithCustomDomain(enable bool) ClientOption {
+	return func(client *Client) {
+		client.customDomain = enable
+	}
+}
+
+// WithAccelerate set whether to send requests of objects to AccelerateEndpoint, the default is disabled.
+// Transfer acceleration of the bucket must be enabled by PutBucketAccelerateConfigurationV2, requests of buckets,
+// e.g. bucket configurations, are still sent to the endpoint of client.
+func WithAccelerate(enable bool) ClientOption {
+	return func(client *Client) {
+		client.accelerate = enable
+	}
+}
+
 // WithPathAccessMode url mode is path model or default mode
 //
 // Deprecated: This option is deprecated. Setting PathAccessMode will be ignored silently.
@@ -218,7 +590,18 @@ func WithContentTypeRecognizer(recognizer ContentTypeRecognizer) ClientOption {
 	}
 }
 
+const (
+	// unixSocketScheme endpoint like unix:///var/run/tos.sock sends all requests to the unix socket,
+	// which is usually a sidecar proxy
+	unixSocketScheme = "unix://"
+	unixSocketHost   = "localhost"
+)
+
 func schemeHost(endpoint string) (scheme string, host string, urlMode urlMode) {
+	if strings.HasPrefix(endpoint, unixSocketScheme) {
+		// all connections go to the socket, bucket must be in path
+		return "http", unixSocketHost, urlModePath
+	}
 	if strings.HasPrefix(endpoint, "https://") {
 		scheme = "https"
 		host = endpoint[len("https://"):]
@@ -230,8 +613,17 @@ func schemeHost(endpoint string) (scheme string, host string, urlMode urlMode) {
 		host = endpoint
 	}
 	urlMode = urlModeDefault
-	if net.ParseIP(host) != nil {
+	// IP literals can't be prefixed with bucket, e.g. 10.0.0.1:8080 and [::1]:8080
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	if ip := net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(hostname, "["), "]")); ip != nil {
 		urlMode = urlModePath
+		if ip.To4() == nil && hostname == host && !strings.HasPrefix(host, "[") {
+			// bare IPv6 literal without port
+			host = "[" + host + "]"
+		}
 	}
 	return scheme, host, urlMode
 }
@@ -240,21 +632,58 @@ func initClient(client *Client, endpoint string, options ...ClientOption) error
 	for _, option := range options {
 		option(client)
 	}
+	if client.logger == nil {
+		client.logger = nopLogger{}
+	}
+	client.stats = &clientStats{}
 	// if Region is set and supported, param "endpoint" will be ignored
 	if len(client.config.Endpoint) == 0 {
 		client.config.Endpoint = endpoint
 	}
 	client.scheme, client.host, client.urlMode = schemeHost(client.config.Endpoint)
+	if client.customDomain {
+		client.urlMode = urlModeCustomDomain
+	}
+	if len(client.readEndpoint) > 0 {
+		client.readRouter = newReadRouter(client.readEndpoint, client.host, client.config.Region, client.readYourWrites)
+	}
+	if socket := strings.TrimPrefix(client.config.Endpoint, unixSocketScheme); len(socket) < len(client.config.Endpoint) &&
+		client.config.TransportConfig.DialContext == nil {
+		client.config.TransportConfig.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+	}
 
 	if client.transport == nil {
 		client.transport = NewDefaultTransport(&client.config.TransportConfig)
 	}
+	if client.debugDump != nil {
+		client.transport = newDumpTransport(client.transport, client.debugDump, client.debugDumpBody)
+	}
+	if client.config.CircuitBreakerConfig.FailureThreshold > 0 {
+		client.transport = NewCircuitBreakerTransport(client.transport, client.config.CircuitBreakerConfig)
+	}
+
+	if client.retry != nil {
+		retry := client.config.RetryConfig
+		client.retry.SetBackoff(exponentialBackoff(retry.MaxRetryCount, retry.BackoffBase))
+		client.retry.SetMaxBackoff(retry.MaxBackoff)
+		client.retry.SetJitter(retry.Jitter)
+		client.retry.SetThrottleCallback(retry.ThrottleCallback)
+		client.retry.SetLogger(client.logger)
+		if retry.Mode == RetryModeAdaptive {
+			client.retry.SetBudget(newRetryBudget(DefaultRetryBudgetCapacity))
+		}
+	}
 
 	if cred := client.credentials; cred != nil && client.signer == nil {
 		if len(client.config.Region) == 0 {
 			return newTosClientError("tos: missing Region option", nil)
 		}
-		client.signer = NewSignV4(cred, client.config.Region)
+		signer := NewSignV4(cred, client.config.Region)
+		signer.WithQueryCanonicalization(client.queryCanonicalization)
+		client.signer = signer
 	}
 
 	return nil
@@ -288,6 +717,7 @@ func NewClient(endpoint string, options ...ClientOption) (*Client, error) {
 //     WithSocketTimeout set read-write timeout
 //     WithTransportConfig set TransportConfig
 //     WithTransport set self-defined Transport
+//     WithMaxRetryCount, WithRetryBackoff, WithRetryJitter and WithRetryMode set retry policy
 func NewClientV2(endpoint string, options ...ClientOption) (*ClientV2, error) {
 	client := ClientV2{
 		Client: Client{
@@ -299,7 +729,6 @@ func NewClientV2(endpoint string, options ...ClientOption) (*ClientV2, error) {
 			// enableCRC:  true,
 		},
 	}
-	client.retry.SetJitter(0.25)
 	err := initClient(&client.Client, endpoint, options...)
 	if err != nil {
 		return nil, err
@@ -320,6 +749,31 @@ func (cli *Client) newBuilder(bucket, object string, options ...Option) *request
 		OnRetry:    func(req *Request) {},
 		Classifier: StatusCodeClassifier{},
 	}
+	if cli.accelerate && len(object) > 0 && cli.urlMode == urlModeDefault {
+		rb.Host = AccelerateEndpoint
+	}
+	if cli.endpointResolver != nil {
+		cli.resolveEndpoint(rb)
+	}
+	if cli.redirects != nil {
+		if endpoint := cli.redirects.get(bucket); endpoint != nil {
+			route(rb, endpoint, cli.config.Region)
+		}
+		rb.OnRedirect = cli.onRedirect
+	}
+	rb.readRouter = cli.readRouter
+	rb.tracer = cli.tracer
+	rb.limits = cli.limits
+	rb.metrics = cli.metrics
+	rb.Middlewares = cli.middlewares
+	rb.hooks = cli.hooks
+	rb.idempotency = cli.idempotency
+	rb.stats = cli.stats
+	rb.ResponseHeaderTimeout = cli.attemptTimeout
+	rb.OperationTimeout = cli.operationTimeout
+	if _, ok := cli.logger.(nopLogger); !ok {
+		rb.logger = cli.logger
+	}
 	rb.Header.Set(HeaderUserAgent, cli.userAgent)
 	if typ := cli.recognizer.ContentType(object); len(typ) > 0 {
 		rb.Header.Set(HeaderContentType, typ)
@@ -328,6 +782,10 @@ func (cli *Client) newBuilder(bucket, object string, options ...Option) *request
 		option(rb)
 	}
 	rb.Retry = cli.retry
+	if cli.classifier != nil {
+		rb.Classifier = cli.classifier
+		rb.UserClassifier = cli.classifier
+	}
 	return rb
 }
 
@@ -370,6 +828,30 @@ func (cli *Client) PreSignedURL(httpMethod string, bucket, objectKey string, ttl
 
 // PreSignedURL return pre-signed url
 func (cli *ClientV2) PreSignedURL(input *PreSignedURLInput) (*PreSignedURLOutput, error) {
+	return cli.preSignedURL(input, nil)
+}
+
+// PreSignedURLs return pre-signed urls of inputs in order. It's much cheaper than calling PreSignedURL for each input,
+// since the signing time, credential and signing key are computed once and shared by all urls.
+// It fails if any input is invalid, and it's safe to call concurrently.
+func (cli *ClientV2) PreSignedURLs(inputs []PreSignedURLInput) ([]*PreSignedURLOutput, error) {
+	var snapshot *SignV4
+	if sv, ok := cli.signer.(*SignV4); ok {
+		snapshot = sv.snapshot()
+	}
+	outputs := make([]*PreSignedURLOutput, 0, len(inputs))
+	for i := range inputs {
+		output, err := cli.preSignedURL(&inputs[i], snapshot)
+		if err != nil {
+			return nil, newTosClientError(fmt.Sprintf("tos: pre-sign input %d failed, %s", i, err.Error()), err)
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// preSignedURL sign input with snapshot if it's not nil and the request is signed by the signer of client
+func (cli *ClientV2) preSignedURL(input *PreSignedURLInput, snapshot *SignV4) (*PreSignedURLOutput, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
@@ -380,10 +862,17 @@ func (cli *ClientV2) PreSignedURL(input *PreSignedURLInput) (*PreSignedURLOutput
 	for k, v := range input.Query {
 		rb.WithQuery(k, v)
 	}
-	if input.Expires == 0 {
-		input.Expires = 3600
+	if sv, ok := rb.Signer.(*SignV4); ok && snapshot != nil {
+		// the request may be routed to another region, keep its region
+		signer := *snapshot
+		signer.region = sv.region
+		rb.Signer = &signer
+	}
+	expires := input.Expires
+	if expires == 0 {
+		expires = 3600
 	}
-	signedURL, err := rb.PreSignedURL(string(input.HTTPMethod), time.Second*time.Duration(3600))
+	signedURL, err := rb.PreSignedURL(string(input.HTTPMethod), time.Second*time.Duration(expires))
 	if err != nil {
 		return nil, err
 	}
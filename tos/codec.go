@@ -0,0 +1,113 @@
+package tos
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+)
+
+const (
+	CodecGzip = "gzip"
+)
+
+// Codec compress and decompress object content.
+//
+// The SDK only provides gzip, register other codecs like zstd or snappy with RegisterCodec.
+// Set PutObjectV2Input.Codec to compress content when uploading, the name of codec is recorded in object meta,
+// and set GetObjectV2Input.DecodeContent to decompress content with it when downloading.
+type Codec interface {
+	// Name of codec, recorded in object meta
+	Name() string
+	// NewWriter return a writer compressing data into w, closing it must flush all data but not close w
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	// NewReader return a reader decompressing data read from r, closing it must not close r
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+var codecs = struct {
+	sync.RWMutex
+	m map[string]Codec
+}{m: map[string]Codec{CodecGzip: gzipCodec{}}}
+
+// RegisterCodec register codec by its name, replace the one with the same name if any
+func RegisterCodec(codec Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	codecs.m[codec.Name()] = codec
+}
+
+// LookupCodec return codec registered with name
+func LookupCodec(name string) (Codec, bool) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	codec, ok := codecs.m[name]
+	return codec, ok
+}
+
+func lookupCodec(name string) (Codec, error) {
+	codec, ok := LookupCodec(name)
+	if !ok {
+		return nil, newTosClientError("tos: codec "+name+" is not registered", nil)
+	}
+	return codec, nil
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string { return CodecGzip }
+
+func (gzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil }
+
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
+
+// encodeReader return a reader of r compressed by codec.
+// Close the returned reader to stop compressing if it's not read to EOF.
+func encodeReader(codec Codec, r io.Reader) *io.PipeReader {
+	pr, pw := io.Pipe()
+	go func() {
+		w, err := codec.NewWriter(pw)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err = io.Copy(w, r); err != nil {
+			w.Close()
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(w.Close())
+	}()
+	return pr
+}
+
+// readCloserWithDecoder decompress base with codec, the decoder is created on first read
+type readCloserWithDecoder struct {
+	codec   Codec
+	base    io.ReadCloser
+	decoder io.ReadCloser
+	err     error
+}
+
+func (r *readCloserWithDecoder) Read(p []byte) (int, error) {
+	if r.decoder == nil && r.err == nil {
+		r.decoder, r.err = r.codec.NewReader(r.base)
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.decoder.Read(p)
+}
+
+func (r *readCloserWithDecoder) Close() error {
+	if r.decoder != nil {
+		r.decoder.Close()
+	}
+	return r.base.Close()
+}
+
+// decodeStage decompress content with codec
+func decodeStage(codec Codec) ReaderStage {
+	return func(rc io.ReadCloser) io.ReadCloser {
+		return &readCloserWithDecoder{codec: codec, base: rc}
+	}
+}
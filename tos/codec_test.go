@@ -0,0 +1,121 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// storeTransport keeps the last object put, and returns it on get
+type storeTransport struct {
+	header http.Header
+	body   []byte
+	length *int64
+}
+
+func (rt *storeTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	if req.Method == http.MethodPut {
+		body, err := ioutil.ReadAll(req.Content)
+		if err != nil {
+			return nil, err
+		}
+		rt.header, rt.body, rt.length = req.Header.Clone(), body, req.ContentLength
+		return &Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	}
+	header := make(http.Header)
+	for key, values := range rt.header {
+		if strings.HasPrefix(key, HeaderMetaPrefix) {
+			header[key] = values
+		}
+	}
+	return &Response{StatusCode: http.StatusOK, Header: header, ContentLength: int64(len(rt.body)),
+		Body: ioutil.NopCloser(bytes.NewReader(rt.body))}, nil
+}
+
+type reverseCodec struct{}
+
+func (reverseCodec) Name() string { return "reverse" }
+
+func (reverseCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return &reverseWriter{w: w}, nil
+}
+
+func (reverseCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(reverse(data))), nil
+}
+
+type reverseWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (w *reverseWriter) Write(p []byte) (int, error) { return w.buf.Write(p) }
+
+func (w *reverseWriter) Close() error {
+	_, err := w.w.Write(reverse(w.buf.Bytes()))
+	return err
+}
+
+func reverse(data []byte) []byte {
+	reversed := make([]byte, len(data))
+	for i, b := range data {
+		reversed[len(data)-1-i] = b
+	}
+	return reversed
+}
+
+func TestCodec(t *testing.T) {
+	RegisterCodec(reverseCodec{})
+	transport := &storeTransport{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+	ctx := context.Background()
+	content := strings.Repeat("hello tos ", 100)
+
+	for _, name := range []string{CodecGzip, "reverse"} {
+		_, err = client.PutObjectV2(ctx, &PutObjectV2Input{
+			PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", ContentLength: int64(len(content))},
+			Content:             strings.NewReader(content),
+			Codec:               name,
+		})
+		require.Nil(t, err)
+		require.Equal(t, name, transport.header.Get(HeaderMetaCodec))
+		require.Equal(t, "", transport.header.Get(HeaderContentLength))
+		require.Nil(t, transport.length)
+		require.NotEqual(t, content, string(transport.body))
+
+		get, err := client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key", DecodeContent: true})
+		require.Nil(t, err)
+		require.Equal(t, name, get.Meta["Content-Codec"])
+		data, err := ioutil.ReadAll(get.Content)
+		require.Nil(t, err)
+		require.Equal(t, content, string(data))
+		require.Nil(t, get.Content.Close())
+
+		// raw content without DecodeContent
+		get, err = client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+		require.Nil(t, err)
+		data, err = ioutil.ReadAll(get.Content)
+		require.Nil(t, err)
+		require.Equal(t, transport.body, data)
+	}
+
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"},
+		Content:             strings.NewReader(content),
+		Codec:               "unknown",
+	})
+	require.NotNil(t, err)
+	_, ok := LookupCodec("unknown")
+	require.False(t, ok)
+}
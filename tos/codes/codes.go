@@ -76,6 +76,7 @@ const (
 	NotAppendable                     = "NotAppendable"
 	OffsetNotMatched                  = "OffsetNotMatched"
 	NoSuchWebsiteConfiguration        = "NoSuchWebsiteConfiguration"
+	NoSuchTagSet                      = "NoSuchTagSet"
 	InvalidRedirectLocation           = "InvalidRedirectLocation"
 	NoSuchMirrorConfiguration         = "NoSuchMirrorConfiguration"
 	TryAgain                          = "TryAgain"
@@ -112,4 +113,6 @@ const (
 	ExceedClusterRateLimit            = "ExceedClusterRateLimit"
 	InvalidPartNumber                 = "InvalidPartNumber"
 	NoSuchUpload                      = "NoSuchUpload"
+	ObjectAlreadyExists               = "ObjectAlreadyExists"
+	CallbackFailed                    = "CallbackFailed"
 )
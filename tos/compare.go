@@ -0,0 +1,340 @@
+package tos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+type CompareObjectsInput struct {
+	SrcBucket   string
+	SrcKey      string
+	DstClient   *ClientV2 // nullable, the client to access destination, the current client is used if not set
+	DstBucket   string
+	DstKey      string // the default is SrcKey
+	CompareMeta bool   // compare system meta such as Content-Type and user meta
+}
+
+// ObjectDiff a difference between source and destination object
+type ObjectDiff struct {
+	SrcKey string
+	DstKey string
+	Type   enum.ObjectDiffType
+	Detail string
+}
+
+type CompareObjectsOutput struct {
+	Equal bool
+	Diff  *ObjectDiff // nil if Equal
+}
+
+type ComparePrefixInput struct {
+	SrcBucket   string
+	SrcPrefix   string
+	DstClient   *ClientV2 // nullable, the client to access destination, the current client is used if not set
+	DstBucket   string
+	DstPrefix   string // the default is SrcPrefix
+	CompareMeta bool   // compare system meta such as Content-Type and user meta, which needs HEAD of each object
+	TaskNum     int    // number of concurrent HEAD requests, the default is 1
+}
+
+type ComparePrefixOutput struct {
+	Compared int          // number of keys compared
+	Matched  int          // number of keys without difference
+	Diffs    []ObjectDiff // sorted by SrcKey, then DstKey
+}
+
+// comparedObject is what we know about an object, from listing or HEAD
+type comparedObject struct {
+	size          int64
+	etag          string
+	hashCrc64ecma uint64
+}
+
+func diffObject(src, dst comparedObject) (enum.ObjectDiffType, string) {
+	if src.size != dst.size {
+		return enum.ObjectDiffSize, fmt.Sprintf("size %d != %d", src.size, dst.size)
+	}
+	if src.hashCrc64ecma != 0 && dst.hashCrc64ecma != 0 {
+		if src.hashCrc64ecma != dst.hashCrc64ecma {
+			return enum.ObjectDiffChecksum, fmt.Sprintf("crc64 %d != %d", src.hashCrc64ecma, dst.hashCrc64ecma)
+		}
+		return "", ""
+	}
+	if strings.Trim(src.etag, `"`) != strings.Trim(dst.etag, `"`) {
+		return enum.ObjectDiffChecksum, fmt.Sprintf("etag %s != %s", src.etag, dst.etag)
+	}
+	return "", ""
+}
+
+func diffMeta(src, dst *HeadObjectV2Output) string {
+	pairs := [][3]string{
+		{"Content-Type", src.ContentType, dst.ContentType},
+		{"Cache-Control", src.CacheControl, dst.CacheControl},
+		{"Content-Disposition", src.ContentDisposition, dst.ContentDisposition},
+		{"Content-Encoding", src.ContentEncoding, dst.ContentEncoding},
+		{"Content-Language", src.ContentLanguage, dst.ContentLanguage},
+	}
+	for _, p := range pairs {
+		if p[1] != p[2] {
+			return fmt.Sprintf("%s %q != %q", p[0], p[1], p[2])
+		}
+	}
+	if !src.Expires.Equal(dst.Expires) {
+		return fmt.Sprintf("Expires %s != %s", src.Expires, dst.Expires)
+	}
+	if len(src.Meta) != len(dst.Meta) {
+		return fmt.Sprintf("meta count %d != %d", len(src.Meta), len(dst.Meta))
+	}
+	for k, v := range src.Meta {
+		if dv, ok := dst.Meta[k]; !ok || dv != v {
+			return fmt.Sprintf("meta %s %q != %q", k, v, dv)
+		}
+	}
+	return ""
+}
+
+func headObjectForCompare(ctx context.Context, cli *ClientV2, bucket, key string) (*HeadObjectV2Output, error) {
+	head, err := cli.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: bucket, Key: key})
+	if err != nil && StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	return head, err
+}
+
+// compareMeta HEAD source and destination, return nil if there's no difference of meta
+func compareMeta(ctx context.Context, src, dst *ClientV2, srcBucket, srcKey, dstBucket, dstKey string) (*ObjectDiff, error) {
+	srcHead, err := headObjectForCompare(ctx, src, srcBucket, srcKey)
+	if err != nil {
+		return nil, err
+	}
+	dstHead, err := headObjectForCompare(ctx, dst, dstBucket, dstKey)
+	if err != nil {
+		return nil, err
+	}
+	diff := &ObjectDiff{SrcKey: srcKey, DstKey: dstKey}
+	switch {
+	case srcHead == nil && dstHead == nil:
+		return nil, nil
+	case srcHead == nil:
+		diff.Type = enum.ObjectDiffMissingInSource
+	case dstHead == nil:
+		diff.Type = enum.ObjectDiffMissingInDestination
+	default:
+		diff.Type, diff.Detail = diffObject(
+			comparedObject{size: srcHead.ContentLength, etag: srcHead.ETag, hashCrc64ecma: srcHead.HashCrc64ecma},
+			comparedObject{size: dstHead.ContentLength, etag: dstHead.ETag, hashCrc64ecma: dstHead.HashCrc64ecma})
+		if len(diff.Type) == 0 {
+			if diff.Detail = diffMeta(srcHead, dstHead); len(diff.Detail) == 0 {
+				return nil, nil
+			}
+			diff.Type = enum.ObjectDiffMeta
+		}
+	}
+	return diff, nil
+}
+
+// CompareObjects compare size, CRC64 (or ETag if CRC64 is absent) and optionally meta of two objects,
+// which may be in different buckets, regions or accounts.
+func (cli *ClientV2) CompareObjects(ctx context.Context, input *CompareObjectsInput) (*CompareObjectsOutput, error) {
+	dstKey := input.DstKey
+	if len(dstKey) == 0 {
+		dstKey = input.SrcKey
+	}
+	if err := isValidNames(input.SrcBucket, input.SrcKey); err != nil {
+		return nil, err
+	}
+	if err := isValidNames(input.DstBucket, dstKey); err != nil {
+		return nil, err
+	}
+	dst := input.DstClient
+	if dst == nil {
+		dst = cli
+	}
+	diff, err := compareMeta(ctx, cli, dst, input.SrcBucket, input.SrcKey, input.DstBucket, dstKey)
+	if err != nil {
+		return nil, err
+	}
+	if diff != nil && diff.Type == enum.ObjectDiffMeta && !input.CompareMeta {
+		diff = nil
+	}
+	return &CompareObjectsOutput{Equal: diff == nil, Diff: diff}, nil
+}
+
+// objectLister iterates objects under prefix page by page
+type objectLister struct {
+	cli          *ClientV2
+	bucket       string
+	prefix       string
+	requestPayer string
+	options      []Option
+	marker       string
+	page         []ListedObject
+	done         bool
+}
+
+// next return the next object, or nil if there's no more
+func (l *objectLister) next(ctx context.Context) (*ListedObject, error) {
+	for len(l.page) == 0 {
+		if l.done {
+			return nil, nil
+		}
+		out, err := l.cli.ListObjectsV2(ctx, &ListObjectsV2Input{
+			Bucket:           l.bucket,
+			RequestPayer:     l.requestPayer,
+			ListObjectsInput: ListObjectsInput{Prefix: l.prefix, Marker: l.marker, MaxKeys: 1000},
+		}, l.options...)
+		if err != nil {
+			return nil, err
+		}
+		l.page = out.Contents
+		l.done = !out.IsTruncated
+		l.marker = out.NextMarker
+		if len(l.marker) == 0 && len(out.Contents) > 0 {
+			l.marker = out.Contents[len(out.Contents)-1].Key
+		}
+	}
+	obj := l.page[0]
+	l.page = l.page[1:]
+	return &obj, nil
+}
+
+type comparePair struct {
+	srcKey string
+	dstKey string
+}
+
+// ComparePrefix compare all objects under SrcPrefix with objects under DstPrefix, objects are matched by the key
+// relative to the prefix. Size and CRC64 (or ETag if CRC64 is absent) are compared from listing,
+// and meta is compared by HEAD of each object if CompareMeta is set.
+func (cli *ClientV2) ComparePrefix(ctx context.Context, input *ComparePrefixInput) (*ComparePrefixOutput, error) {
+	if err := IsValidBucketName(input.SrcBucket); err != nil {
+		return nil, err
+	}
+	if err := IsValidBucketName(input.DstBucket); err != nil {
+		return nil, err
+	}
+	dstPrefix := input.DstPrefix
+	if len(dstPrefix) == 0 {
+		dstPrefix = input.SrcPrefix
+	}
+	dst := input.DstClient
+	if dst == nil {
+		dst = cli
+	}
+	taskNum := input.TaskNum
+	if taskNum < 1 {
+		taskNum = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		output   ComparePrefixOutput
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+		pairs    = make(chan comparePair)
+	)
+	addDiff := func(diff *ObjectDiff) {
+		mu.Lock()
+		defer mu.Unlock()
+		output.Diffs = append(output.Diffs, *diff)
+	}
+	for i := 0; i < taskNum && input.CompareMeta; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pair := range pairs {
+				diff, err := compareMeta(ctx, cli, dst, input.SrcBucket, pair.srcKey, input.DstBucket, pair.dstKey)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				if diff != nil {
+					addDiff(diff)
+				}
+			}
+		}()
+	}
+
+	// objects of both sides are listed in lexicographical order, so merge them
+	err := func() error {
+		defer close(pairs)
+		srcLister := &objectLister{cli: cli, bucket: input.SrcBucket, prefix: input.SrcPrefix}
+		dstLister := &objectLister{cli: dst, bucket: input.DstBucket, prefix: dstPrefix}
+		src, err := srcLister.next(ctx)
+		if err != nil {
+			return err
+		}
+		dstObj, err := dstLister.next(ctx)
+		if err != nil {
+			return err
+		}
+		for src != nil || dstObj != nil {
+			var srcRel, dstRel string
+			if src != nil {
+				srcRel = strings.TrimPrefix(src.Key, input.SrcPrefix)
+			}
+			if dstObj != nil {
+				dstRel = strings.TrimPrefix(dstObj.Key, dstPrefix)
+			}
+			output.Compared++
+			switch {
+			case dstObj == nil || (src != nil && srcRel < dstRel):
+				addDiff(&ObjectDiff{SrcKey: src.Key, DstKey: dstPrefix + srcRel, Type: enum.ObjectDiffMissingInDestination})
+				src, err = srcLister.next(ctx)
+			case src == nil || dstRel < srcRel:
+				addDiff(&ObjectDiff{SrcKey: input.SrcPrefix + dstRel, DstKey: dstObj.Key, Type: enum.ObjectDiffMissingInSource})
+				dstObj, err = dstLister.next(ctx)
+			default:
+				typ, detail := diffObject(
+					comparedObject{size: src.Size, etag: src.ETag, hashCrc64ecma: src.HashCrc64ecma},
+					comparedObject{size: dstObj.Size, etag: dstObj.ETag, hashCrc64ecma: dstObj.HashCrc64ecma})
+				if len(typ) > 0 {
+					addDiff(&ObjectDiff{SrcKey: src.Key, DstKey: dstObj.Key, Type: typ, Detail: detail})
+				} else if input.CompareMeta {
+					select {
+					case pairs <- comparePair{srcKey: src.Key, dstKey: dstObj.Key}:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				if src, err = srcLister.next(ctx); err != nil {
+					return err
+				}
+				dstObj, err = dstLister.next(ctx)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(output.Diffs, func(i, j int) bool {
+		if output.Diffs[i].SrcKey != output.Diffs[j].SrcKey {
+			return output.Diffs[i].SrcKey < output.Diffs[j].SrcKey
+		}
+		return output.Diffs[i].DstKey < output.Diffs[j].DstKey
+	})
+	output.Matched = output.Compared - len(output.Diffs)
+	return &output, nil
+}
@@ -0,0 +1,83 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// listTransport serves ListObjects from in-memory buckets, one object per page
+type listTransport map[string][]ListedObject
+
+func (lt listTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	bucket := strings.Split(req.Host, ".")[0]
+	output := ListObjectsOutput{Name: bucket}
+	for _, obj := range lt[bucket] {
+		if strings.HasPrefix(obj.Key, req.Query.Get("prefix")) && obj.Key > req.Query.Get("marker") {
+			if len(output.Contents) > 0 {
+				output.IsTruncated = true
+				break
+			}
+			output.Contents = append(output.Contents, obj)
+		}
+	}
+	data, _ := json.Marshal(output)
+	return &Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewReader(data)),
+	}, nil
+}
+
+func TestDiffObject(t *testing.T) {
+	typ, _ := diffObject(comparedObject{size: 1, hashCrc64ecma: 1}, comparedObject{size: 2, hashCrc64ecma: 1})
+	require.Equal(t, enum.ObjectDiffSize, typ)
+	typ, _ = diffObject(comparedObject{size: 1, hashCrc64ecma: 1, etag: "a"}, comparedObject{size: 1, hashCrc64ecma: 2, etag: "a"})
+	require.Equal(t, enum.ObjectDiffChecksum, typ)
+	// fall back to ETag if CRC64 is absent
+	typ, _ = diffObject(comparedObject{size: 1, etag: `"a"`}, comparedObject{size: 1, hashCrc64ecma: 2, etag: "a"})
+	require.Equal(t, enum.ObjectDiffType(""), typ)
+	typ, _ = diffObject(comparedObject{size: 1, etag: "a"}, comparedObject{size: 1, etag: "b"})
+	require.Equal(t, enum.ObjectDiffChecksum, typ)
+}
+
+func TestComparePrefix(t *testing.T) {
+	transport := listTransport{
+		"src": {
+			{Key: "data/a", Size: 1, HashCrc64ecma: 1},
+			{Key: "data/b", Size: 1, HashCrc64ecma: 1},
+			{Key: "data/c", Size: 1, HashCrc64ecma: 1},
+			{Key: "data/e", Size: 1, HashCrc64ecma: 1},
+		},
+		"dst": {
+			{Key: "backup/a", Size: 1, HashCrc64ecma: 1},
+			{Key: "backup/b", Size: 2, HashCrc64ecma: 1},
+			{Key: "backup/d", Size: 1, HashCrc64ecma: 1},
+			{Key: "backup/e", Size: 1, HashCrc64ecma: 2},
+		},
+	}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+	output, err := client.ComparePrefix(context.Background(), &ComparePrefixInput{
+		SrcBucket: "src",
+		SrcPrefix: "data/",
+		DstBucket: "dst",
+		DstPrefix: "backup/",
+	})
+	require.Nil(t, err)
+	require.Equal(t, 5, output.Compared)
+	require.Equal(t, 1, output.Matched)
+	require.Equal(t, []ObjectDiff{
+		{SrcKey: "data/b", DstKey: "backup/b", Type: enum.ObjectDiffSize, Detail: "size 1 != 2"},
+		{SrcKey: "data/c", DstKey: "backup/c", Type: enum.ObjectDiffMissingInDestination},
+		{SrcKey: "data/d", DstKey: "backup/d", Type: enum.ObjectDiffMissingInSource},
+		{SrcKey: "data/e", DstKey: "backup/e", Type: enum.ObjectDiffChecksum, Detail: "crc64 1 != 2"},
+	}, output.Diffs)
+}
@@ -0,0 +1,82 @@
+package tos
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// OperationConcurrency limits the number of concurrent requests of each operation class of a client,
+// so that a burst of one class can't starve the others. 0 means no limit.
+type OperationConcurrency struct {
+	// List ListObjects, ListObjectVersions, ListBuckets, ListMultipartUploads and ListParts
+	List int
+	// Put PutObject, AppendObject and UploadPart, including parts of UploadFile
+	Put int
+	// Get GetObject, a slot is held until Content of output is closed
+	Get int
+}
+
+// operationLimits semaphores of OperationConcurrency, nil semaphores mean no limit
+type operationLimits struct {
+	list semaphore
+	put  semaphore
+	get  semaphore
+}
+
+func newOperationLimits(concurrency OperationConcurrency) *operationLimits {
+	return &operationLimits{
+		list: newSemaphore(concurrency.List),
+		put:  newSemaphore(concurrency.Put),
+		get:  newSemaphore(concurrency.Get),
+	}
+}
+
+// semaphore return the semaphore limiting operation, nil if it's not limited
+func (l *operationLimits) semaphore(operation string) semaphore {
+	switch operation {
+	case OperationListObjects, OperationListObjectVersions, OperationListBuckets,
+		OperationListMultipartUploads, OperationListParts:
+		return l.list
+	case OperationPutObject, OperationAppendObject, OperationUploadPart:
+		return l.put
+	case OperationGetObject:
+		return l.get
+	}
+	return nil
+}
+
+type semaphore chan struct{}
+
+func newSemaphore(size int) semaphore {
+	if size <= 0 {
+		return nil
+	}
+	return make(semaphore, size)
+}
+
+// acquire a slot, return error if ctx is done before that
+func (s semaphore) acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return newTosClientError("tos: wait for operation concurrency limit failed, "+ctx.Err().Error(), ctx.Err())
+	}
+}
+
+func (s semaphore) release() {
+	<-s
+}
+
+// releaseOnClose release the slot once the response body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	r.once.Do(r.release)
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,38 @@
+package tos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOperationConcurrency(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&readingTransport{body: "{}"}),
+		WithOperationConcurrency(OperationConcurrency{List: 1, Get: 1}))
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	get, err := client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+
+	// the slot of GetObject is held until Content is closed
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = client.GetObjectV2(timeout, &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.NotNil(t, err)
+	require.Equal(t, OperationGetObject, OperationName(err))
+
+	// other classes are not affected, and slots of them are released after response
+	for i := 0; i < 2; i++ {
+		_, err = client.ListObjectsV2(ctx, &ListObjectsV2Input{Bucket: "bucket"})
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, get.Content.Close())
+	require.Nil(t, get.Content.Close())
+	get, err = client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Nil(t, get.Content.Close())
+}
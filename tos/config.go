@@ -1,16 +1,107 @@
 package tos
 
-import "time"
+import (
+	"math/rand"
+	"os"
+	"time"
+)
 
 type Config struct {
 	Endpoint        string
 	Region          string
 	TransportConfig TransportConfig
+	RetryConfig     RetryConfig
+
+	// RampUpPolicy staggers start of part workers of UploadFile, disabled by default
+	RampUpPolicy RampUpPolicy
+
+	// CircuitBreakerConfig per-host circuit breaker, disabled by default
+	CircuitBreakerConfig CircuitBreakerConfig
+
+	// CheckpointDir the directory of checkpoint files, used when CheckpointFile of input is not set.
+	// The default is read from environment variable TOS_CHECKPOINT_DIR
+	CheckpointDir string
+
+	// TempFileDir the directory of temp files when downloading, used instead of the directory of the target file.
+	// It should be on the same filesystem as the target file. The default is read from environment variable TOS_TEMP_FILE_DIR
+	TempFileDir string
+}
+
+type RetryMode int
+
+const (
+	// RetryModeStandard retry each request independently
+	RetryModeStandard RetryMode = iota
+
+	// RetryModeAdaptive retries of all requests on a client share a retry budget,
+	// retries consume the budget and successes replenish it
+	RetryModeAdaptive
+)
+
+type RetryConfig struct {
+	// Mode RetryModeStandard or RetryModeAdaptive, the default is RetryModeStandard
+	Mode RetryMode
+
+	// MaxRetryCount the max number of retries of a request, 0 means no retry
+	MaxRetryCount int
+
+	// BackoffBase the back-off before the first retry, doubled at each following retry
+	BackoffBase time.Duration
+
+	// MaxBackoff the upper bound of a single back-off, 0 means no limit
+	MaxBackoff time.Duration
+
+	// Jitter each back-off is adjusted by a random factor in range (-Jitter, +Jitter), must be in [0.0, 1.0]
+	Jitter float64
+
+	// ThrottleCallback nullable, called before retrying a request throttled by server with 429 or 503
+	ThrottleCallback func(event *ThrottleEvent)
+}
+
+// ThrottleEvent describes a request throttled by server
+type ThrottleEvent struct {
+	StatusCode int
+	RequestID  string
+	Attempt    int           // the retry about to be made, starting at 1
+	RetryAfter time.Duration // interval from Retry-After header, 0 if absent
+	Backoff    time.Duration // actual wait before next retry
+}
+
+// RampUpPolicy staggers start of part workers of transfers, e.g. UploadFile,
+// to avoid connection and throttling storms when many transfers start at the same time.
+type RampUpPolicy struct {
+	// Duration workers of a transfer start evenly within Duration, each with a random offset,
+	// so workers of concurrent transfers don't align. 0 means all workers start at once
+	Duration time.Duration
+}
+
+// delay return the delay before starting the i-th of n workers
+func (p RampUpPolicy) delay(i, n int) time.Duration {
+	if p.Duration <= 0 || n <= 0 {
+		return 0
+	}
+	slot := p.Duration / time.Duration(n)
+	delay := slot * time.Duration(i)
+	if slot > 0 {
+		delay += time.Duration(rand.Int63n(int64(slot)))
+	}
+	return delay
 }
 
 func defaultConfig() Config {
 	return Config{
 		TransportConfig: DefaultTransportConfig(),
+		RetryConfig:     DefaultRetryConfig(),
+		CheckpointDir:   os.Getenv(EnvCheckpointDir),
+		TempFileDir:     os.Getenv(EnvTempFileDir),
+	}
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		BackoffBase: DefaultRetryBackoffBase,
+		MaxBackoff:  DefaultRetryBackoffMax,
+		Jitter:      DefaultRetryJitter,
 	}
 }
 
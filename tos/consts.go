@@ -10,6 +10,13 @@ const (
 )
 
 const TempFileSuffix = ".temp"
+
+const (
+	// EnvCheckpointDir environment variable of the default checkpoint directory, see WithCheckpointDir
+	EnvCheckpointDir = "TOS_CHECKPOINT_DIR"
+	// EnvTempFileDir environment variable of the default temp file directory, see WithTempFileDir
+	EnvTempFileDir = "TOS_TEMP_FILE_DIR"
+)
 const DefaultFilePerm = 0644
 
 var DefaultCrcTable = func() *crc64.Table {
@@ -18,6 +25,13 @@ var DefaultCrcTable = func() *crc64.Table {
 
 const DefaultTaskBufferSize = 100
 
+// QueryProcess query parameter of image and video processing, see Process of GetObjectV2Input and ProcessObject
+const QueryProcess = "x-tos-process"
+
+// AccelerateEndpoint the endpoint of transfer acceleration, which requests of objects are sent to if WithAccelerate
+// is enabled
+const AccelerateEndpoint = "tos-accelerate.volces.com"
+
 func SupportedRegion() map[string]string {
 	return map[string]string{
 		"cn-beijing":   "https://tos-cn-beijing.volces.com",
@@ -28,6 +42,8 @@ func SupportedRegion() map[string]string {
 const (
 	MaxPartSize = 5 * 1024 * 1024 * 1024
 	MinPartSize = 5 * 1024 * 1024
+	// MaxDeleteObjects max count of objects deleted by a DeleteMultiObjects request
+	MaxDeleteObjects = 1000
 )
 
 const (
@@ -82,20 +98,28 @@ const (
 	HeaderLastModified                = "Last-Modified"
 	HeaderCacheControl                = "Cache-Control"
 	HeaderExpires                     = "Expires"
+	HeaderRetryAfter                  = "Retry-After"
 	HeaderETag                        = "ETag"
 	HeaderVersionID                   = "X-Tos-Version-Id"
 	HeaderDeleteMarker                = "X-Tos-Delete-Marker"
 	HeaderStorageClass                = "X-Tos-Storage-Class"
 	HeaderAzRedundancy                = "X-Tos-Az-Redundancy"
+	HeaderBucketType                  = "X-Tos-Bucket-Type"
+	HeaderProjectName                 = "X-Tos-Project-Name"
 	HeaderRestore                     = "X-Tos-Restore"
+	HeaderExpiration                  = "X-Tos-Expiration"
+	HeaderAccessTier                  = "X-Tos-Intelligent-Tiering-Access-Tier"
+	HeaderRequestPayer                = "X-Tos-Request-Payer"
 	HeaderTag                         = "X-Tos-Tag"
+	HeaderTagging                     = "X-Tos-Tagging"
+	HeaderTaggingDirective            = "X-Tos-Tagging-Directive"
 	HeaderSSECustomerAlgorithm        = "X-Tos-Server-Side-Encryption-Customer-Algorithm"
 	HeaderSSECustomerKeyMD5           = "X-Tos-Server-Side-Encryption-Customer-Key-MD5"
 	HeaderSSECustomerKey              = "X-Tos-Server-Side-Encryption-Customer-Key"
 	HeaderServerSideEncryption        = "X-Tos-Server-Side-Encryption"
-	HeaderCopySourceSSECAlgorithm     = "X-Tos-Server-Side-Encryption-Customer-Algorithm"
-	HeaderCopySourceSSECKeyMD5        = "X-Tos-Server-Side-Encryption-Customer-Key-MD5"
-	HeaderCopySourceSSECKey           = "X-Tos-Server-Side-Encryption-Customer-Key"
+	HeaderCopySourceSSECAlgorithm     = "X-Tos-Copy-Source-Server-Side-Encryption-Customer-Algorithm"
+	HeaderCopySourceSSECKeyMD5        = "X-Tos-Copy-Source-Server-Side-Encryption-Customer-Key-MD5"
+	HeaderCopySourceSSECKey           = "X-Tos-Copy-Source-Server-Side-Encryption-Customer-Key"
 	HeaderIfModifiedSince             = "If-Modified-Since"
 	HeaderIfUnmodifiedSince           = "If-Unmodified-Since"
 	HeaderIfMatch                     = "If-Match"
@@ -104,6 +128,7 @@ const (
 	HeaderContentRange                = "Content-Range"
 	HeaderRequestID                   = "X-Tos-Request-Id"
 	HeaderID2                         = "X-Tos-Id-2"
+	HeaderEC                          = "X-Tos-Ec"
 	HeaderBucketRegion                = "X-Tos-Bucket-Region"
 	HeaderLocation                    = "Location"
 	HeaderACL                         = "X-Tos-Acl"
@@ -124,6 +149,19 @@ const (
 	HeaderCopySourceRange             = "X-Tos-Copy-Source-Range"
 	HeaderCopySourceVersionID         = "X-Tos-Copy-Source-Version-Id"
 	HeaderWebsiteRedirectLocation     = "X-Tos-Website-Redirect-Location"
+	HeaderForbidOverwrite             = "X-Tos-Forbid-Overwrite"
+	HeaderSymlinkTarget               = "X-Tos-Symlink-Target"
+	HeaderSymlinkBucket               = "X-Tos-Symlink-Bucket"
+	HeaderSymlinkTargetSize           = "X-Tos-Symlink-Target-Size"
+	HeaderObjectLockMode              = "X-Tos-Object-Lock-Mode"
+	HeaderObjectLockRetainUntilDate   = "X-Tos-Object-Lock-Retain-Until-Date"
+	HeaderObjectLockLegalHold         = "X-Tos-Object-Lock-Legal-Hold"
+	HeaderBypassGovernanceRetention   = "X-Tos-Bypass-Governance-Retention"
+	HeaderCallback                    = "X-Tos-Callback"
+	HeaderCallbackVar                 = "X-Tos-Callback-Var"
+	HeaderTrafficLimit                = "X-Tos-Traffic-Limit"
 	HeaderCSType                      = "X-Tos-Cs-Type"
 	HeaderMetaPrefix                  = "X-Tos-Meta-"
+	HeaderMetaCodec                   = "X-Tos-Meta-Content-Codec" // name of Codec compressing object content
+	HeaderIdempotencyToken            = "X-Tos-Idempotency-Token"  // de-duplicate retried writes, set by WithIdempotencyToken
 )
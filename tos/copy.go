@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
 )
 
 // CopyObject copy an object
@@ -88,23 +89,30 @@ func (bkt *Bucket) CopyObjectFrom(ctx context.Context, srcBucket, srcObjectKey,
 
 func (cli *Client) copyObject(ctx context.Context, dstBucket, dstObject string, srcBucket, srcObject string, options ...Option) (*CopyObjectOutput, error) {
 	res, err := cli.newBuilder(dstBucket, dstObject, options...).
+		WithOperation(OperationCopyObject).
 		WithCopySource(srcBucket, srcObject).
 		Request(ctx, http.MethodPut, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
 	}
 	defer res.Close()
-	out := CopyObjectOutput{RequestInfo: res.RequestInfo()}
-	if err = marshalOutput(out.RequestID, res.Body, &out); err != nil {
+	result, err := readCopyResult(res, OperationCopyObject)
+	if err != nil {
 		return nil, err
 	}
-	out.VersionID = res.Header.Get(HeaderVersionID)
-	out.SourceVersionID = res.Header.Get(HeaderCopySourceVersionID)
-	return &out, nil
+	return &CopyObjectOutput{
+		RequestInfo:     res.RequestInfo(),
+		VersionID:       res.Header.Get(HeaderVersionID),
+		SourceVersionID: res.Header.Get(HeaderCopySourceVersionID),
+		ETag:            result.ETag,
+		LastModified:    result.LastModified,
+	}, nil
 }
 
-// CopyObject copy an object
-func (cli *ClientV2) CopyObject(ctx context.Context, input *CopyObjectInput) (*CopyObjectOutput, error) {
+// CopyObject copy an object, metadata and tags of source object are copied unless MetadataDirective or
+// TaggingDirective is REPLACE. Copying may fail after status code 200 is received, in which case
+// TosServerError with status code 200 and the Code in body is returned.
+func (cli *ClientV2) CopyObject(ctx context.Context, input *CopyObjectInput, options ...Option) (*CopyObjectOutput, error) {
 	if err := IsValidBucketName(input.SrcBucket); err != nil {
 		return nil, err
 	}
@@ -114,27 +122,72 @@ func (cli *ClientV2) CopyObject(ctx context.Context, input *CopyObjectInput) (*C
 	if err := isValidKey(input.Key, input.SrcKey); err != nil {
 		return nil, err
 	}
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	if err := isValidWebsiteRedirectLocation(input.WebsiteRedirectLocation); err != nil {
+		return nil, err
+	}
+	rb := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationCopyObject).
 		WithParams(*input).
-		WithCopySource(input.SrcBucket, input.SrcKey).
-		WithRetry(nil, ServerErrorClassifier{}).
+		WithCopySource(input.SrcBucket, input.SrcKey)
+	if err := rb.withTagSet(input.Tagging, input.TagSet); err != nil {
+		return nil, err
+	}
+	if input.TagSet != nil && len(input.TaggingDirective) == 0 {
+		rb.WithHeader(HeaderTaggingDirective, string(enum.TaggingDirectiveReplace))
+	}
+	res, err := rb.WithRetry(nil, ServerErrorClassifier{}).
 		Request(ctx, http.MethodPut, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
 	}
 	defer res.Close()
-	out := CopyObjectOutput{RequestInfo: res.RequestInfo()}
-	if err = marshalOutput(out.RequestID, res.Body, &out); err != nil {
+	result, err := readCopyResult(res, OperationCopyObject)
+	if err != nil {
 		return nil, err
 	}
-	out.VersionID = res.Header.Get(HeaderVersionID)
-	out.SourceVersionID = res.Header.Get(HeaderCopySourceVersionID)
-	return &out, nil
+	return &CopyObjectOutput{
+		RequestInfo:     res.RequestInfo(),
+		VersionID:       res.Header.Get(HeaderVersionID),
+		SourceVersionID: res.Header.Get(HeaderCopySourceVersionID),
+		ETag:            result.ETag,
+		LastModified:    result.LastModified,
+	}, nil
 }
 
-type uploadPartCopyOutput struct {
+// copyResult body of CopyObject and UploadPartCopy. The server sends status code 200 before copying finishes,
+// so copying may still fail with an error in body, which has Code set.
+type copyResult struct {
 	ETag         string `json:"ETag,omitempty"`
 	LastModified string `json:"LastModified,omitempty"`
+	Code         string `json:"Code,omitempty"`
+	Message      string `json:"Message,omitempty"`
+	HostID       string `json:"HostId,omitempty"`
+	Resource     string `json:"Resource,omitempty"`
+	EC           string `json:"EC,omitempty"`
+}
+
+// readCopyResult read body of a copy response with status code 200, return TosServerError of operation
+// if copying failed
+func readCopyResult(res *Response, operation string) (*copyResult, error) {
+	var out copyResult
+	if err := marshalOutput(res.RequestInfo().RequestID, res.Body, &out); err != nil {
+		return nil, err
+	}
+	if len(out.Code) > 0 {
+		info := res.RequestInfo()
+		if len(out.EC) > 0 {
+			info.EC = out.EC
+		}
+		return nil, &TosServerError{
+			TosError:      TosError{out.Message},
+			RequestInfo:   info,
+			Code:          out.Code,
+			HostID:        out.HostID,
+			Resource:      out.Resource,
+			OperationName: operation,
+		}
+	}
+	return &out, nil
 }
 
 func copyRange(startOffset, partSize *int64) string {
@@ -151,11 +204,14 @@ func copyRange(startOffset, partSize *int64) string {
 	return cr
 }
 
+// copySource return value of X-Tos-Copy-Source, object is percent-encoded including '/',
+// space is encoded to "%20" instead of "+", which may be taken as a literal '+'
 func copySource(bucket, object, versionID string) string {
+	escaped := string(URIEncode(object, true))
 	if len(versionID) == 0 {
-		return "/" + bucket + "/" + url.QueryEscape(object)
+		return "/" + bucket + "/" + escaped
 	}
-	return "/" + bucket + "/" + url.QueryEscape(object) + "?versionId=" + versionID
+	return "/" + bucket + "/" + escaped + "?versionId=" + versionID
 }
 
 func (up *UploadPartCopyOutput) uploadedPart() uploadedPart {
@@ -173,6 +229,7 @@ func (bkt *Bucket) UploadPartCopy(ctx context.Context, input *UploadPartCopyInpu
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, input.DestinationKey, options...).
+		WithOperation(OperationUploadPartCopy).
 		WithQuery("partNumber", strconv.Itoa(input.PartNumber)).
 		WithQuery("uploadId", input.UploadID).
 		WithQuery("versionId", input.SourceVersionID).
@@ -183,8 +240,8 @@ func (bkt *Bucket) UploadPartCopy(ctx context.Context, input *UploadPartCopyInpu
 		return nil, err
 	}
 	defer res.Close()
-	var out uploadPartCopyOutput
-	if err = marshalOutput(res.RequestInfo().RequestID, res.Body, &out); err != nil {
+	out, err := readCopyResult(res, OperationUploadPartCopy)
+	if err != nil {
 		return nil, err
 	}
 
@@ -213,7 +270,7 @@ func copyRangeV2(start, end int64) string {
 // UploadPartCopyV2 copy a part of object as a part of a multipart upload operation
 func (cli *ClientV2) UploadPartCopyV2(
 	ctx context.Context,
-	input *UploadPartCopyV2Input) (*UploadPartCopyV2Output, error) {
+	input *UploadPartCopyV2Input, options ...Option) (*UploadPartCopyV2Output, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
@@ -224,7 +281,8 @@ func (cli *ClientV2) UploadPartCopyV2(
 		return nil, err
 	}
 
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationUploadPartCopy).
 		WithParams(*input).
 		WithHeader(HeaderCopySourceRange, copyRangeV2(input.CopySourceRangeStart, input.CopySourceRangeEnd)).
 		WithCopySource(input.SrcBucket, input.SrcKey).
@@ -234,8 +292,8 @@ func (cli *ClientV2) UploadPartCopyV2(
 		return nil, err
 	}
 	defer res.Close()
-	var out uploadPartCopyOutput
-	if err = marshalOutput(res.RequestInfo().RequestID, res.Body, &out); err != nil {
+	out, err := readCopyResult(res, OperationUploadPartCopy)
+	if err != nil {
 		return nil, err
 	}
 
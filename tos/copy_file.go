@@ -0,0 +1,315 @@
+package tos
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// initCopyPartsInfo split an object of size into parts of partSize, return TosClientError if there are too many parts
+func initCopyPartsInfo(size, partSize int64) ([]copyPartInfo, error) {
+	if size == 0 {
+		// RangeEnd < RangeStart copies the whole empty object
+		return []copyPartInfo{{PartNumber: 1, RangeStart: 0, RangeEnd: -1}}, nil
+	}
+	partCount := (size + partSize - 1) / partSize
+	if partCount > 10000 {
+		return nil, newTosClientError("tos: part count too many", nil)
+	}
+	parts := make([]copyPartInfo, 0, partCount)
+	for i := int64(0); i < partCount; i++ {
+		end := (i+1)*partSize - 1
+		if end >= size {
+			end = size - 1
+		}
+		parts = append(parts, copyPartInfo{
+			PartNumber: int(i + 1),
+			RangeStart: i * partSize,
+			RangeEnd:   end,
+		})
+	}
+	return parts, nil
+}
+
+// initCopyCheckpoint initialize checkpoint of copying the source object described by head
+func initCopyCheckpoint(input *CopyFileInput, head *HeadObjectV2Output, uploadID string) (*copyCheckpoint, error) {
+	parts, err := initCopyPartsInfo(head.ContentLength, input.PartSize)
+	if err != nil {
+		return nil, err
+	}
+	return &copyCheckpoint{
+		checkpointPath: input.CheckpointFile,
+		Bucket:         input.Bucket,
+		Key:            input.Key,
+		UploadID:       uploadID,
+		PartSize:       input.PartSize,
+		SrcBucket:      input.SrcBucket,
+		SrcKey:         input.SrcKey,
+		SrcVersionID:   input.SrcVersionID,
+		SourceInfo: copyObjectInfo{
+			VersionID:     head.VersionID,
+			Etag:          head.ETag,
+			HashCrc64ecma: head.HashCrc64ecma,
+			LastModified:  head.LastModified,
+			ObjectSize:    head.ContentLength,
+		},
+		PartsInfo: parts,
+	}, nil
+}
+
+// copyCheckpointFile return the correct checkpoint path of CopyFile
+func copyCheckpointFile(input *CopyFileInput, checkpointDir string) string {
+	sum := md5.Sum([]byte(strings.Join([]string{input.SrcBucket, input.SrcKey, input.SrcVersionID,
+		input.Bucket, input.Key}, "\n")))
+	name := hex.EncodeToString(sum[:]) + ".copy"
+	checkpointFile := input.CheckpointFile
+	if len(checkpointFile) == 0 {
+		if len(checkpointDir) == 0 {
+			checkpointDir = os.TempDir()
+		}
+		return filepath.Join(checkpointDir, name)
+	}
+	mustFile(&checkpointFile, name)
+	return checkpointFile
+}
+
+// validateCopyInput validate copy input, return TosClientError failed
+func validateCopyInput(input *CopyFileInput, checkpointDir string) error {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return err
+	}
+	if err := isValidNames(input.SrcBucket, input.SrcKey); err != nil {
+		return err
+	}
+	if input.PartSize == 0 {
+		input.PartSize = MinPartSize
+	}
+	if input.PartSize < MinPartSize || input.PartSize > MaxPartSize {
+		return newTosClientError("tos: the input part size is invalid, please set it range from 5MB to 5GB.", nil)
+	}
+	if input.EnableCheckpoint {
+		input.CheckpointFile = copyCheckpointFile(input, checkpointDir)
+	}
+	if input.TaskNum < 1 {
+		input.TaskNum = 1
+	}
+	if input.TaskNum > 1000 {
+		input.TaskNum = 1000
+	}
+	return nil
+}
+
+// getCopyCheckpoint get checkpoint from checkpoint file if it's valid, or initialize from scratch with function init
+func getCopyCheckpoint(enabled bool, checkpointPath string, valid func(checkpoint *copyCheckpoint) bool,
+	init func() (*copyCheckpoint, error)) (*copyCheckpoint, error) {
+	if enabled {
+		loaded := &copyCheckpoint{checkpointPath: checkpointPath}
+		ok, err := loadCheckPoint(checkpointPath, loaded)
+		if err != nil {
+			return nil, err
+		}
+		if ok && valid(loaded) {
+			return loaded, nil
+		}
+	}
+	checkpoint, err := init()
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		if err = checkpoint.WriteToFile(); err != nil {
+			return nil, err
+		}
+	}
+	return checkpoint, nil
+}
+
+// CopyFile copy an object of any size by UploadPartCopy, parts are copied concurrently by TaskNum goroutines.
+// If EnableCheckpoint is set, copied parts are recorded in the checkpoint file, and CopyFile called again with the same
+// input after a failure or crash resumes the copy, unless the source object is changed.
+//
+// Parts are pinned to the ETag of source object when the copy starts, so overwriting the source object during the copy
+// fails the copy, the multipart upload is aborted and the checkpoint file is removed in this case.
+func (cli *ClientV2) CopyFile(ctx context.Context, input *CopyFileInput) (*CopyFileOutput, error) {
+	// avoid modifying on origin input
+	copied := *input
+	input = &copied
+	if err := validateCopyInput(input, cli.config.CheckpointDir); err != nil {
+		return nil, err
+	}
+	head, err := cli.HeadObjectV2(ctx, &HeadObjectV2Input{
+		Bucket:        input.SrcBucket,
+		Key:           input.SrcKey,
+		VersionID:     input.SrcVersionID,
+		SSECAlgorithm: input.CopySourceSSECAlgorithm,
+		SSECKey:       input.CopySourceSSECKey,
+		SSECKeyMD5:    input.CopySourceSSECKeyMD5,
+		RequestPayer:  input.RequestPayer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	valid := func(checkpoint *copyCheckpoint) bool {
+		return checkpoint.Valid(input, head)
+	}
+	init := func() (*copyCheckpoint, error) {
+		created, err := cli.CreateMultipartUploadV2(ctx, &input.CreateMultipartUploadV2Input)
+		if err != nil {
+			return nil, err
+		}
+		return initCopyCheckpoint(input, head, created.UploadID)
+	}
+	checkpoint, err := getCopyCheckpoint(input.EnableCheckpoint, input.CheckpointFile, valid, init)
+	if err != nil {
+		return nil, err
+	}
+	bindCancelHookWithCleaner(input.CancelHook, func() {
+		_ = os.Remove(input.CheckpointFile)
+	})
+	return cli.copyParts(ctx, checkpoint, input)
+}
+
+// copyParts copy parts not completed in checkpoint and complete the multipart upload
+func (cli *ClientV2) copyParts(ctx context.Context, checkpoint *copyCheckpoint, input *CopyFileInput) (*CopyFileOutput, error) {
+	aborter := func() error {
+		_, err := cli.AbortMultipartUpload(ctx, &AbortMultipartUploadInput{
+			Bucket:       input.Bucket,
+			Key:          input.Key,
+			UploadID:     checkpoint.UploadID,
+			RequestPayer: input.RequestPayer,
+		})
+		return err
+	}
+	bindCancelHookWithAborter(input.CancelHook, aborter)
+
+	// taskCtx is canceled once a part fails or CancelHook is called
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	tasks := make([]task, 0, len(checkpoint.PartsInfo))
+	for _, part := range checkpoint.PartsInfo {
+		if !part.IsCompleted {
+			tasks = append(tasks, &copyTask{cli: cli, ctx: taskCtx, input: input, checkpoint: checkpoint, part: part})
+		}
+	}
+	cancelHandle := getCancelHandle(input.CancelHook)
+	go func() {
+		select {
+		case <-cancelHandle:
+			cancel()
+		case <-taskCtx.Done():
+		}
+	}()
+
+	type taskResult struct {
+		part copyPartInfo
+		err  error
+	}
+	tasksCh := make(chan task)
+	resultsCh := make(chan taskResult)
+	var wg sync.WaitGroup
+	for i := 0; i < min(input.TaskNum, len(tasks)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasksCh {
+				if taskCtx.Err() != nil {
+					// a part failed or the copy is canceled, drain the remaining tasks
+					continue
+				}
+				result, err := t.do()
+				if err != nil {
+					// stop picking up tasks before the result is handled
+					cancel()
+					resultsCh <- taskResult{err: err}
+					continue
+				}
+				resultsCh <- taskResult{part: result.(copyPartInfo)}
+			}
+		}()
+	}
+	go func() {
+		defer close(tasksCh)
+		for _, t := range tasks {
+			select {
+			case tasksCh <- t:
+			case <-taskCtx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	var taskErr error
+	for result := range resultsCh {
+		if result.err != nil {
+			if taskErr == nil {
+				taskErr = result.err
+				cli.logger.Warn("tos: copy part failed", "bucket", input.Bucket, "key", input.Key,
+					"uploadID", checkpoint.UploadID, "error", taskErr)
+				cancel()
+			}
+			continue
+		}
+		checkpoint.UpdatePartsInfo(result.part)
+		if input.EnableCheckpoint {
+			if err := checkpoint.WriteToFile(); err != nil {
+				cli.logger.Warn("tos: write checkpoint file failed", "checkpointFile", input.CheckpointFile, "error", err)
+			}
+		}
+	}
+	select {
+	case <-cancelHandle:
+		return nil, newTosClientError("tos: copy is canceled", taskErr)
+	default:
+	}
+	if taskErr != nil {
+		switch StatusCode(taskErr) {
+		case http.StatusForbidden, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusPreconditionFailed:
+			// the copy can't be resumed
+			_ = os.Remove(input.CheckpointFile)
+			if err := aborter(); err != nil {
+				cli.logger.Error("tos: abort multipart upload failed", "bucket", input.Bucket, "key", input.Key,
+					"uploadID", checkpoint.UploadID, "error", err)
+			}
+		}
+		return nil, taskErr
+	}
+
+	complete, err := cli.CompleteMultipartUploadV2(ctx, &CompleteMultipartUploadV2Input{
+		Bucket:       input.Bucket,
+		Key:          input.Key,
+		UploadID:     checkpoint.UploadID,
+		RequestPayer: input.RequestPayer,
+		Parts:        checkpoint.GetParts(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if source := checkpoint.SourceInfo.HashCrc64ecma; source != 0 && complete.HashCrc64ecma != 0 &&
+		source != complete.HashCrc64ecma {
+		return nil, &TosServerError{
+			TosError:    TosError{"tos: crc of copied object mismatch."},
+			RequestInfo: complete.RequestInfo,
+		}
+	}
+	_ = os.Remove(input.CheckpointFile)
+	return &CopyFileOutput{
+		RequestInfo:     complete.RequestInfo,
+		Bucket:          complete.Bucket,
+		Key:             complete.Key,
+		UploadID:        checkpoint.UploadID,
+		ETag:            complete.ETag,
+		Location:        complete.Location,
+		VersionID:       complete.VersionID,
+		SourceVersionID: checkpoint.SourceInfo.VersionID,
+		HashCrc64ecma:   complete.HashCrc64ecma,
+	}, nil
+}
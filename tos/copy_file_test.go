@@ -0,0 +1,155 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// copyTransport serves CopyFile requests for a source object of size, UploadPartCopy of failPart fails with failCode,
+// and UploadPartCopy of holdPart isn't answered until the copy is canceled
+type copyTransport struct {
+	mu       sync.Mutex
+	size     int64
+	failPart string
+	failCode int
+	holdPart string
+	creates  int
+	aborts   int
+	ranges   map[string]string // part number -> X-Tos-Copy-Source-Range
+	ifMatch  []string
+	complete int
+}
+
+func (rt *copyTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	if part := req.Query.Get("partNumber"); len(part) > 0 && part == rt.holdPart {
+		<-ctx.Done()
+	}
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	header := make(http.Header)
+	header.Set(HeaderHashCrc64ecma, "123")
+	respond := func(code int, body string) (*Response, error) {
+		return &Response{StatusCode: code, Header: header, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+	}
+	switch {
+	case req.Method == http.MethodHead:
+		header.Set(HeaderETag, `"source"`)
+		header.Set(HeaderContentLength, strconv.FormatInt(rt.size, 10))
+		header.Set(HeaderVersionID, "v1")
+		return respond(http.StatusOK, "")
+	case req.Method == http.MethodPost && req.Query.Get("uploadId") == "":
+		rt.creates++
+		return respond(http.StatusOK, `{"Bucket":"bucket","Key":"dst","UploadId":"upload"}`)
+	case req.Method == http.MethodDelete:
+		rt.aborts++
+		return respond(http.StatusNoContent, "")
+	case req.Method == http.MethodPost:
+		rt.complete++
+		return respond(http.StatusOK, `{"Bucket":"bucket","Key":"dst","ETag":"etag"}`)
+	}
+	part := req.Query.Get("partNumber")
+	if part == rt.failPart {
+		return respond(rt.failCode, `{"Code":"Failed"}`)
+	}
+	rt.ranges[part] = req.Header.Get(HeaderCopySourceRange)
+	rt.ifMatch = append(rt.ifMatch, req.Header.Get(HeaderCopySourceIfMatch))
+	return respond(http.StatusOK, `{"ETag":"etag`+part+`"}`)
+}
+
+func TestCopyFileResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-copy")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	transport := &copyTransport{size: 2*MinPartSize + 1, failPart: "2", failCode: 500, ranges: make(map[string]string)}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(0))
+	require.Nil(t, err)
+	input := &CopyFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "dst"},
+		SrcBucket:                    "src-bucket",
+		SrcKey:                       "src",
+		EnableCheckpoint:             true,
+		CheckpointFile:               dir,
+		// parts are copied one by one, so part 3 is never sent once part 2 fails
+		TaskNum: 1,
+	}
+
+	_, err = client.CopyFile(context.Background(), input)
+	require.Equal(t, http.StatusInternalServerError, StatusCode(err))
+	require.Equal(t, "bytes=0-5242879", transport.ranges["1"])
+	require.Equal(t, []string{`"source"`}, transport.ifMatch)
+	files, err := ioutil.ReadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, files, 1)
+	checkpoint := filepath.Join(dir, files[0].Name())
+
+	// resume from part 2
+	transport.failPart = ""
+	transport.ranges = make(map[string]string)
+	output, err := client.CopyFile(context.Background(), input)
+	require.Nil(t, err)
+	require.Equal(t, 1, transport.creates)
+	require.Equal(t, 1, transport.complete)
+	require.Equal(t, map[string]string{"2": "bytes=5242880-10485759", "3": "bytes=10485760-10485760"}, transport.ranges)
+	require.Equal(t, "upload", output.UploadID)
+	require.Equal(t, "v1", output.SourceVersionID)
+	require.Equal(t, uint64(123), output.HashCrc64ecma)
+	_, err = os.Stat(checkpoint)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestCopyFileStopOnFailedPart(t *testing.T) {
+	// part 1 is held until part 2 fails, so the other worker is free only after the failure
+	transport := &copyTransport{size: 3*MinPartSize + 1, failPart: "2", failCode: 500, holdPart: "1",
+		ranges: make(map[string]string)}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(0))
+	require.Nil(t, err)
+	_, err = client.CopyFile(context.Background(), &CopyFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "dst"},
+		SrcBucket:                    "src-bucket",
+		SrcKey:                       "src",
+		TaskNum:                      2,
+	})
+	require.Equal(t, http.StatusInternalServerError, StatusCode(err))
+	// parts 3 and 4 are never sent
+	require.NotContains(t, transport.ranges, "3")
+	require.NotContains(t, transport.ranges, "4")
+}
+
+func TestCopyFileSourceChanged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-copy")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	transport := &copyTransport{size: 10, failPart: "1", failCode: http.StatusPreconditionFailed,
+		ranges: make(map[string]string)}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+	_, err = client.CopyFile(context.Background(), &CopyFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "dst"},
+		SrcBucket:                    "src-bucket",
+		SrcKey:                       "src",
+		EnableCheckpoint:             true,
+		CheckpointFile:               dir,
+	})
+	require.True(t, IsPreconditionFailed(err))
+	require.Equal(t, 1, transport.aborts)
+	files, err := ioutil.ReadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, files, 0)
+}
+
+func TestInitCopyPartsInfo(t *testing.T) {
+	parts, err := initCopyPartsInfo(0, MinPartSize)
+	require.Nil(t, err)
+	require.Equal(t, []copyPartInfo{{PartNumber: 1, RangeStart: 0, RangeEnd: -1}}, parts)
+	_, err = initCopyPartsInfo(10001*MinPartSize, MinPartSize)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,71 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestCopyObjectHeaders(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderVersionID, "v2")
+	header.Set(HeaderCopySourceVersionID, "v1")
+	client, transport := newRecordClient(t, http.StatusOK, `{"ETag":"\"abc\"","LastModified":"2022-01-01T00:00:00Z"}`)
+	transport.res.Header = header
+
+	modified := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	output, err := client.CopyObject(context.Background(), &CopyObjectInput{
+		Bucket:                    "bucket",
+		Key:                       "dst",
+		SrcBucket:                 "src-bucket",
+		SrcKey:                    "src key",
+		SrcVersionID:              "v1",
+		CopySourceIfMatch:         `"abc"`,
+		CopySourceIfModifiedSince: modified,
+		CopySourceSSECAlgorithm:   "AES256",
+		CopySourceSSECKey:         "c3JjLWtleQ==",
+		SSECAlgorithm:             "AES256",
+		SSECKey:                   "ZHN0LWtleQ==",
+		StorageClass:              enum.StorageClassIa,
+		MetadataDirective:         enum.MetadataDirectiveReplace,
+		ContentType:               "text/plain",
+		TaggingDirective:          enum.TaggingDirectiveReplace,
+		Tagging:                   "k1=v1",
+	})
+	require.Nil(t, err)
+	require.Equal(t, "v2", output.VersionID)
+	require.Equal(t, "v1", output.SourceVersionID)
+	require.Equal(t, `"abc"`, output.ETag)
+
+	req := transport.requests[0]
+	require.Equal(t, "/src-bucket/src%20key?versionId=v1", req.Header.Get(HeaderCopySource))
+	require.Equal(t, "", req.Query.Get("versionId"))
+	require.Equal(t, `"abc"`, req.Header.Get(HeaderCopySourceIfMatch))
+	require.Equal(t, modified.Format(http.TimeFormat), req.Header.Get(HeaderCopySourceIfModifiedSince))
+	require.Equal(t, "c3JjLWtleQ==", req.Header.Get(HeaderCopySourceSSECKey))
+	require.Equal(t, "ZHN0LWtleQ==", req.Header.Get(HeaderSSECustomerKey))
+	require.Equal(t, "IA", req.Header.Get(HeaderStorageClass))
+	require.Equal(t, "REPLACE", req.Header.Get(HeaderMetadataDirective))
+	require.Equal(t, "text/plain", req.Header.Get(HeaderContentType))
+	require.Equal(t, "REPLACE", req.Header.Get(HeaderTaggingDirective))
+	require.Equal(t, "k1=v1", req.Header.Get(HeaderTagging))
+}
+
+func TestCopyObjectErrorInBody(t *testing.T) {
+	client, _ := newRecordClient(t, http.StatusOK, `{"Code":"InternalError","Message":"copy failed","EC":"0001-00000001"}`)
+
+	_, err := client.CopyObject(context.Background(), &CopyObjectInput{Bucket: "bucket", Key: "dst",
+		SrcBucket: "bucket", SrcKey: "src"})
+	require.NotNil(t, err)
+	se, ok := err.(*TosServerError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusOK, se.StatusCode)
+	require.Equal(t, "InternalError", se.Code)
+	require.Equal(t, "copy failed", se.Message)
+	require.Equal(t, "0001-00000001", se.EC)
+	require.Equal(t, OperationCopyObject, se.OperationName)
+}
@@ -0,0 +1,106 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const maxCORSRules = 10
+
+// PutBucketCORSV2 replace CORS rules of a bucket, a cross-origin request is allowed if any rule matches
+func (cli *ClientV2) PutBucketCORSV2(ctx context.Context, input *PutBucketCORSV2Input, options ...Option) (*PutBucketCORSV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := validateCORSRules(input.CORSRules); err != nil {
+		return nil, err
+	}
+	in, contentMD5, err := marshalInput("PutBucketCORSV2Input", struct {
+		CORSRules []CORSRule `json:"CORSRules"`
+	}{input.CORSRules})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketCORS).
+		WithQuery("cors", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketCORSV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketCORSV2 get CORS rules of a bucket, it fails with code NoSuchCORSConfiguration if there's none
+func (cli *ClientV2) GetBucketCORSV2(ctx context.Context, input *GetBucketCORSV2Input, options ...Option) (*GetBucketCORSV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketCORS).
+		WithQuery("cors", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketCORSV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketCORSV2 delete all CORS rules of a bucket
+func (cli *ClientV2) DeleteBucketCORSV2(ctx context.Context, input *DeleteBucketCORSV2Input, options ...Option) (*DeleteBucketCORSV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketCORS).
+		WithQuery("cors", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketCORSV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+func validateCORSRules(rules []CORSRule) error {
+	if len(rules) == 0 || len(rules) > maxCORSRules {
+		return newTosClientError(fmt.Sprintf("tos: number of CORS rules must be [1, %d]", maxCORSRules), nil)
+	}
+	for i, rule := range rules {
+		invalid := func(reason string) error {
+			return newTosClientError(fmt.Sprintf("tos: invalid CORS rule %d, %s", i, reason), nil)
+		}
+		if len(rule.AllowedOrigins) == 0 || len(rule.AllowedMethods) == 0 {
+			return invalid("AllowedOrigins and AllowedMethods are required")
+		}
+		for _, origin := range rule.AllowedOrigins {
+			if strings.Count(origin, "*") > 1 {
+				return invalid("origin " + origin + " contains more than one wildcard")
+			}
+		}
+		for _, method := range rule.AllowedMethods {
+			switch method {
+			case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodHead:
+			default:
+				return invalid("method " + method + " is not allowed")
+			}
+		}
+		if rule.MaxAgeSeconds < 0 {
+			return invalid("negative MaxAgeSeconds")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketCORSV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	rule := CORSRule{
+		AllowedOrigins: []string{"https://*.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPut},
+		AllowedHeaders: []string{"*"},
+		ExposeHeaders:  []string{"ETag"},
+		MaxAgeSeconds:  600,
+	}
+	_, err := client.PutBucketCORSV2(context.Background(), &PutBucketCORSV2Input{Bucket: "bucket",
+		CORSRules: []CORSRule{rule}})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "cors")
+	require.NotEmpty(t, req.Header.Get(HeaderContentMD5))
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"CORSRules":[{"AllowedOrigins":["https://*.example.com"],"AllowedMethods":["GET","PUT"],
+"AllowedHeaders":["*"],"ExposeHeaders":["ETag"],"MaxAgeSeconds":600}]}`, string(data))
+
+	// optional fields are omitted by server
+	transport.respond(http.StatusOK, `{"CORSRules":[{"AllowedOrigins":["*"],"AllowedMethods":["GET","HEAD"]}]}`)
+	out, err := client.GetBucketCORSV2(context.Background(), &GetBucketCORSV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, []CORSRule{{AllowedOrigins: []string{"*"}, AllowedMethods: []string{http.MethodGet, http.MethodHead}}},
+		out.CORSRules)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "cors")
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketCORSV2(context.Background(), &DeleteBucketCORSV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+
+	for _, rules := range [][]CORSRule{
+		nil,
+		{{AllowedOrigins: []string{"*"}}},
+		{{AllowedOrigins: []string{"*.*.com"}, AllowedMethods: []string{http.MethodGet}}},
+		{{AllowedOrigins: []string{"*"}, AllowedMethods: []string{http.MethodPatch}}},
+	} {
+		_, err = client.PutBucketCORSV2(context.Background(), &PutBucketCORSV2Input{Bucket: "bucket", CORSRules: rules})
+		require.NotNil(t, err)
+	}
+	require.Len(t, transport.requests, 3)
+}
@@ -0,0 +1,84 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// PutBucketCustomDomainV2 bind a domain to a bucket, the domain must have a CNAME record to the Cname returned by
+// ListBucketCustomDomainV2. Use WithCustomDomain to send requests to the domain.
+func (cli *ClientV2) PutBucketCustomDomainV2(ctx context.Context, input *PutBucketCustomDomainV2Input, options ...Option) (*PutBucketCustomDomainV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	rule := input.Rule
+	if len(rule.Domain) == 0 {
+		return nil, newTosClientError("tos: Domain of custom domain rule is required", nil)
+	}
+	switch rule.Protocol {
+	case "", enum.CustomDomainProtocolTos, enum.CustomDomainProtocolS3:
+	default:
+		return nil, newTosClientError("tos: invalid Protocol of custom domain rule", nil)
+	}
+	in, contentMD5, err := marshalInput("PutBucketCustomDomainV2Input", struct {
+		CustomDomainRule CustomDomainRule `json:"CustomDomainRule"`
+	}{CustomDomainRule{Domain: rule.Domain, CertID: rule.CertID, Protocol: rule.Protocol}})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketCustomDomain).
+		WithQuery("customdomain", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketCustomDomainV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// ListBucketCustomDomainV2 list domains bound to a bucket
+func (cli *ClientV2) ListBucketCustomDomainV2(ctx context.Context, input *ListBucketCustomDomainV2Input, options ...Option) (*ListBucketCustomDomainV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationListBucketCustomDomain).
+		WithQuery("customdomain", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := ListBucketCustomDomainV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketCustomDomainV2 unbind a domain from a bucket
+func (cli *ClientV2) DeleteBucketCustomDomainV2(ctx context.Context, input *DeleteBucketCustomDomainV2Input, options ...Option) (*DeleteBucketCustomDomainV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if len(input.Domain) == 0 {
+		return nil, newTosClientError("tos: Domain is required", nil)
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketCustomDomain).
+		WithQuery("customdomain", input.Domain).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketCustomDomainV2Output{RequestInfo: res.RequestInfo()}, nil
+}
@@ -0,0 +1,64 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketCustomDomainV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	_, err := client.PutBucketCustomDomainV2(context.Background(), &PutBucketCustomDomainV2Input{Bucket: "bucket",
+		Rule: CustomDomainRule{Domain: "static.example.com", CertID: "cert-1", Protocol: enum.CustomDomainProtocolTos,
+			Cname: "ignored"}})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "customdomain")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"CustomDomainRule":{"Domain":"static.example.com","CertId":"cert-1","Protocol":"tos"}}`,
+		string(data))
+
+	transport.respond(http.StatusOK, `{"CustomDomainRules":[{"Domain":"static.example.com",
+"Cname":"bucket.tos-cn-beijing.volces.com","Forbidden":false,"CertId":"cert-1","CertStatus":"CertBound"}]}`)
+	out, err := client.ListBucketCustomDomainV2(context.Background(), &ListBucketCustomDomainV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, []CustomDomainRule{{Domain: "static.example.com", CertID: "cert-1",
+		Cname: "bucket.tos-cn-beijing.volces.com", CertStatus: "CertBound"}}, out.Rules)
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketCustomDomainV2(context.Background(), &DeleteBucketCustomDomainV2Input{Bucket: "bucket",
+		Domain: "static.example.com"})
+	require.Nil(t, err)
+	req = transport.requests[2]
+	require.Equal(t, http.MethodDelete, req.Method)
+	require.Equal(t, "static.example.com", req.Query.Get("customdomain"))
+
+	_, err = client.PutBucketCustomDomainV2(context.Background(), &PutBucketCustomDomainV2Input{Bucket: "bucket"})
+	require.NotNil(t, err)
+	_, err = client.PutBucketCustomDomainV2(context.Background(), &PutBucketCustomDomainV2Input{Bucket: "bucket",
+		Rule: CustomDomainRule{Domain: "static.example.com", Protocol: "ftp"}})
+	require.NotNil(t, err)
+	_, err = client.DeleteBucketCustomDomainV2(context.Background(), &DeleteBucketCustomDomainV2Input{Bucket: "bucket"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 3)
+}
+
+func TestWithCustomDomain(t *testing.T) {
+	transport := &recordTransport{res: newRecordResponse(http.StatusOK, "")}
+	client, err := NewClientV2("https://static.example.com", WithTransport(transport), WithCustomDomain(true))
+	require.Nil(t, err)
+
+	_, err = client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "images/a.jpg"})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, "https", req.Scheme)
+	require.Equal(t, "static.example.com", req.Host)
+	require.Equal(t, "/images/a.jpg", req.Path)
+}
@@ -0,0 +1,193 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const redacted = "[REDACTED]"
+
+// redactedHeaders headers carrying credentials, their values are never dumped
+var redactedHeaders = []string{authorization, v4SecurityToken, HeaderSSECustomerKey, HeaderCopySourceSSECKey}
+
+// redactedQueries queries of pre-signed URLs carrying credentials
+var redactedQueries = []string{v4Signature, v4SecurityToken}
+
+// dumpTransport writes requests sent on the wire and their responses to w, set by WithDebugHTTPDump.
+//
+// Each request is written once its response header is received, together with the status and headers of response,
+// in a single Write, so that dumps of concurrent requests don't interleave. Bodies are dumped up to maxBody bytes,
+// the body of response is written once it's read to the end or closed.
+type dumpTransport struct {
+	transport Transport
+	w         io.Writer
+	mu        *sync.Mutex
+	maxBody   int64
+}
+
+func newDumpTransport(transport Transport, w io.Writer, maxBody int64) *dumpTransport {
+	return &dumpTransport{transport: transport, w: w, mu: &sync.Mutex{}, maxBody: maxBody}
+}
+
+func (dt *dumpTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "> %s %s\n", req.Method, redactURL(req))
+	writeDumpHeader(&buf, ">", req.Header)
+	var content *captureReader
+	if dt.maxBody > 0 && req.Content != nil {
+		// keep the original request unchanged, it's reused by retries
+		dumped := *req
+		if dumped.ContentLength == nil {
+			if l, ok := req.Content.(interface{ Len() int }); ok {
+				length := int64(l.Len())
+				dumped.ContentLength = &length
+			}
+		}
+		content = &captureReader{Reader: req.Content, max: dt.maxBody}
+		dumped.Content = content
+		req = &dumped
+	}
+	res, err := dt.transport.RoundTrip(ctx, req)
+	if content != nil {
+		content.writeTo(&buf, ">")
+	}
+	if err != nil {
+		fmt.Fprintf(&buf, "< error: %s\n", err.Error())
+		dt.write(buf.Bytes())
+		return res, err
+	}
+	fmt.Fprintf(&buf, "< %d %s\n", res.StatusCode, http.StatusText(res.StatusCode))
+	writeDumpHeader(&buf, "<", res.Header)
+	dt.write(buf.Bytes())
+	if dt.maxBody > 0 && res.Body != nil {
+		res.Body = &dumpBody{
+			ReadCloser: res.Body,
+			capture:    captureReader{max: dt.maxBody},
+			title:      fmt.Sprintf("< body of %s %s\n", req.Method, redactURL(req)),
+			dt:         dt,
+		}
+	}
+	return res, nil
+}
+
+func (dt *dumpTransport) write(p []byte) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	_, _ = dt.w.Write(p)
+}
+
+// redactURL return URL of req with credentials in query redacted
+func redactURL(req *Request) string {
+	redact := false
+	for _, key := range redactedQueries {
+		if _, ok := req.Query[key]; ok {
+			redact = true
+		}
+	}
+	if !redact {
+		return req.URL()
+	}
+	query := make(url.Values, len(req.Query))
+	for key, values := range req.Query {
+		query[key] = values
+	}
+	for _, key := range redactedQueries {
+		if _, ok := query[key]; ok {
+			query.Set(key, redacted)
+		}
+	}
+	dumped := *req
+	dumped.Query = query
+	return dumped.URL()
+}
+
+// writeDumpHeader write headers sorted by key, followed by an empty line
+func writeDumpHeader(buf *bytes.Buffer, prefix string, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := strings.Join(header[key], ", ")
+		for _, secret := range redactedHeaders {
+			if strings.EqualFold(key, secret) {
+				value = redacted
+			}
+		}
+		fmt.Fprintf(buf, "%s %s: %s\n", prefix, key, value)
+	}
+	fmt.Fprintf(buf, "%s\n", prefix)
+}
+
+// captureReader keep the first max bytes read from Reader
+type captureReader struct {
+	io.Reader
+	max       int64
+	data      bytes.Buffer
+	truncated bool
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.capture(p[:n])
+	return n, err
+}
+
+func (r *captureReader) capture(p []byte) {
+	if left := r.max - int64(r.data.Len()); int64(len(p)) > left {
+		p = p[:left]
+		r.truncated = true
+	}
+	r.data.Write(p)
+}
+
+func (r *captureReader) writeTo(buf *bytes.Buffer, prefix string) {
+	if r.data.Len() == 0 {
+		return
+	}
+	buf.Write(r.data.Bytes())
+	if r.truncated {
+		fmt.Fprintf(buf, "\n%s ... (truncated at %d bytes)", prefix, r.max)
+	}
+	buf.WriteString("\n")
+}
+
+// dumpBody dump the response body once it's read to the end or closed
+type dumpBody struct {
+	io.ReadCloser
+	capture captureReader
+	title   string
+	dt      *dumpTransport
+	once    sync.Once
+}
+
+func (b *dumpBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.capture.capture(p[:n])
+	if err == io.EOF {
+		b.dump()
+	}
+	return n, err
+}
+
+func (b *dumpBody) Close() error {
+	b.dump()
+	return b.ReadCloser.Close()
+}
+
+func (b *dumpBody) dump() {
+	b.once.Do(func() {
+		var buf bytes.Buffer
+		buf.WriteString(b.title)
+		b.capture.writeTo(&buf, "<")
+		b.dt.write(buf.Bytes())
+	})
+}
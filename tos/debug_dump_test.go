@@ -0,0 +1,52 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDebugHTTPDump(t *testing.T) {
+	var dump bytes.Buffer
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&readingTransport{body: "world"}),
+		WithCredentials(NewStaticCredentials("ak", "sk")), WithRegion("cn-beijing"),
+		WithDebugHTTPDump(&dump), WithDebugHTTPDumpBody(4))
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key",
+			SSECAlgorithm: "AES256", SSECKey: "c2VjcmV0", SSECKeyMD5: "md5"},
+		Content: strings.NewReader("hello world"),
+	})
+	require.Nil(t, err)
+	out := dump.String()
+	require.Contains(t, out, "> PUT https://bucket.tos-cn-beijing.volces.com/key\n")
+	require.Contains(t, out, "> Authorization: [REDACTED]\n")
+	require.Contains(t, out, "> X-Tos-Server-Side-Encryption-Customer-Key: [REDACTED]\n")
+	require.NotContains(t, out, "c2VjcmV0")
+	require.Contains(t, out, ">\nhell\n> ... (truncated at 4 bytes)\n< 200 OK\n<\n")
+
+	// the response body is dumped once it's read
+	dump.Reset()
+	get, err := client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.NotContains(t, dump.String(), "< body of")
+	data, err := ioutil.ReadAll(get.Content)
+	require.Nil(t, err)
+	require.Equal(t, "world", string(data))
+	require.Nil(t, get.Content.Close())
+	require.Contains(t, dump.String(), "< body of GET https://bucket.tos-cn-beijing.volces.com/key")
+	require.True(t, strings.HasSuffix(dump.String(), "\nworl\n< ... (truncated at 4 bytes)\n"))
+
+	// signatures of pre-signed URLs are redacted
+	req := &Request{Scheme: "https", Method: http.MethodGet, Host: "host", Path: "/key", Query: map[string][]string{
+		v4Signature: {"signature"}, v4Expires: {"3600"}}}
+	require.Equal(t, "https://host/key?X-Tos-Expires=3600&X-Tos-Signature=%5BREDACTED%5D", redactURL(req))
+	require.Equal(t, "signature", req.Query.Get(v4Signature))
+}
@@ -0,0 +1,50 @@
+package tos
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetObjectV2DecompressGzip(t *testing.T) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, err := w.Write([]byte("hello world"))
+	require.Nil(t, err)
+	require.Nil(t, w.Close())
+	newResponse := func() *Response {
+		header := make(http.Header)
+		header.Set(HeaderContentEncoding, "gzip")
+		// CRC of the uncompressed object, which doesn't match the compressed content
+		header.Set(HeaderHashCrc64ecma, "1234")
+		return &Response{StatusCode: http.StatusOK, Header: header, ContentLength: int64(compressed.Len()),
+			Body: ioutil.NopCloser(bytes.NewReader(compressed.Bytes()))}
+	}
+	transport := &recordTransport{res: newResponse()}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+	client.enableCRC = true
+
+	out, err := client.GetObjectV2(context.Background(), &GetObjectV2Input{Bucket: "bucket", Key: "key",
+		DecompressGzip: true})
+	require.Nil(t, err)
+	data, err := ioutil.ReadAll(out.Content)
+	require.Nil(t, err)
+	require.Nil(t, out.Content.Close())
+	require.Equal(t, "hello world", string(data))
+	require.Equal(t, "gzip", out.ContentEncoding)
+
+	// the raw encoded content is returned by default
+	transport.res = newResponse()
+	client.enableCRC = false
+	out, err = client.GetObjectV2(context.Background(), &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	data, err = ioutil.ReadAll(out.Content)
+	require.Nil(t, err)
+	require.Equal(t, compressed.Bytes(), data)
+}
@@ -0,0 +1,70 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// deleteTransport respond DeleteMultiObjects, objects with key "bad" or ending with "/bad" fail
+type deleteTransport struct {
+	batches []deleteMultiObjectsInput
+}
+
+func (rt *deleteTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	var in deleteMultiObjectsInput
+	if err := json.NewDecoder(req.Content).Decode(&in); err != nil {
+		return nil, err
+	}
+	rt.batches = append(rt.batches, in)
+	var out DeleteMultiObjectsOutput
+	for _, object := range in.Objects {
+		if object.Key == "bad" || strings.HasSuffix(object.Key, "/bad") {
+			out.Error = append(out.Error, DeleteError{Code: "AccessDenied", Key: object.Key, VersionID: object.VersionID})
+		} else if !in.Quiet {
+			out.Deleted = append(out.Deleted, Deleted{Key: object.Key, VersionID: object.VersionID})
+		}
+	}
+	body, _ := json.Marshal(&out)
+	return &Response{StatusCode: http.StatusOK, Header: make(http.Header),
+		Body: ioutil.NopCloser(bytes.NewReader(body))}, nil
+}
+
+func TestDeleteMultiObjectsChunked(t *testing.T) {
+	transport := &deleteTransport{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+
+	objects := make([]ObjectTobeDeleted, 0, 2500)
+	for i := 0; i < 2499; i++ {
+		objects = append(objects, ObjectTobeDeleted{Key: "key-" + strconv.Itoa(i), VersionID: "v" + strconv.Itoa(i)})
+	}
+	objects = append(objects, ObjectTobeDeleted{Key: "bad", VersionID: "v"})
+	output, err := client.DeleteMultiObjects(context.Background(), &DeleteMultiObjectsInput{Bucket: "bucket",
+		Objects: objects})
+	require.Nil(t, err)
+	require.Len(t, transport.batches, 3)
+	require.Len(t, transport.batches[0].Objects, MaxDeleteObjects)
+	require.Len(t, transport.batches[2].Objects, 500)
+	require.Len(t, output.Deleted, 2499)
+	require.Equal(t, Deleted{Key: "key-1000", VersionID: "v1000"}, output.Deleted[1000])
+	require.Equal(t, []DeleteError{{Code: "AccessDenied", Key: "bad", VersionID: "v"}}, output.Error)
+
+	output, err = client.DeleteMultiObjects(context.Background(), &DeleteMultiObjectsInput{Bucket: "bucket",
+		Objects: objects[2490:], Quiet: true})
+	require.Nil(t, err)
+	require.True(t, transport.batches[3].Quiet)
+	require.Len(t, output.Deleted, 0)
+	require.Len(t, output.Error, 1)
+
+	_, err = client.DeleteMultiObjects(context.Background(), &DeleteMultiObjectsInput{Bucket: "bucket"})
+	require.NotNil(t, err)
+	require.Len(t, transport.batches, 4)
+}
@@ -0,0 +1,184 @@
+package tos
+
+import (
+	"context"
+	"sync"
+)
+
+type DeletePrefixInput struct {
+	Bucket string
+	Prefix string // required, deleting a whole bucket by an empty prefix is not allowed
+	// AllVersions delete all versions and delete markers under Prefix, otherwise only the latest objects are deleted,
+	// which adds delete markers in versioned buckets
+	AllVersions bool
+	TaskNum     int // number of concurrent DeleteMultiObjects requests, the default is 1
+	// Listener optional, notified after each DeleteMultiObjects request, it may be called concurrently if TaskNum > 1,
+	// and DeletePrefix fails with TosClientError if it panics
+	Listener DeletePrefixListener
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string
+	// KeySharder optional, Prefix is a logical prefix of keys written by KeySharder.ShardedKey, and objects under all
+	// prefixes returned by KeySharder.ShardPrefixes are deleted
+	KeySharder *KeySharder
+}
+
+type DeletePrefixOutput struct {
+	Deleted int64         // number of objects or versions deleted
+	Errors  []DeleteError // objects or versions failed to delete
+}
+
+type DeletePrefixProgress struct {
+	Deleted int64 // number of objects or versions deleted so far
+	Failed  int64 // number of objects or versions failed to delete so far
+}
+
+type DeletePrefixListener interface {
+	DeletePrefixProgressChange(progress *DeletePrefixProgress)
+}
+
+// DeletePrefix delete all objects under Prefix, objects are listed page by page and deleted by DeleteMultiObjects
+// concurrently. Objects failed to delete, e.g. for AccessDenied, are returned in Errors of output, while a failed
+// request stops deleting and its error is returned. It's safe to call DeletePrefix again to continue.
+func (cli *ClientV2) DeletePrefix(ctx context.Context, input *DeletePrefixInput) (*DeletePrefixOutput, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if len(input.Prefix) == 0 {
+		return nil, newTosClientError("tos: empty prefix of DeletePrefix", nil)
+	}
+	taskNum := input.TaskNum
+	if taskNum < 1 {
+		taskNum = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		output   DeletePrefixOutput
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+		batches  = make(chan []ObjectTobeDeleted)
+	)
+	setErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	for i := 0; i < taskNum; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				out, err := cli.deleteMultiObjects(ctx, &DeleteMultiObjectsInput{Bucket: input.Bucket, Objects: batch,
+					Quiet: true, RequestPayer: input.RequestPayer})
+				if err != nil {
+					setErr(err)
+					continue
+				}
+				mu.Lock()
+				output.Deleted += int64(len(batch) - len(out.Error))
+				output.Errors = append(output.Errors, out.Error...)
+				progress := DeletePrefixProgress{Deleted: output.Deleted, Failed: int64(len(output.Errors))}
+				mu.Unlock()
+				// the listener is called without holding mu, so a slow listener doesn't block other workers
+				if err = postDeletePrefixProgress(input.Listener, &progress); err != nil {
+					setErr(err)
+				}
+			}
+		}()
+	}
+
+	err := func() error {
+		defer close(batches)
+		next := cli.prefixObjectsLister(input)
+		batch := make([]ObjectTobeDeleted, 0, MaxDeleteObjects)
+		for {
+			object, err := next(ctx)
+			if err != nil {
+				return err
+			}
+			if object != nil {
+				batch = append(batch, *object)
+			}
+			if len(batch) == MaxDeleteObjects || (object == nil && len(batch) > 0) {
+				select {
+				case batches <- batch:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				batch = make([]ObjectTobeDeleted, 0, MaxDeleteObjects)
+			}
+			if object == nil {
+				return nil
+			}
+		}
+	}()
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// postDeletePrefixProgress return TosClientError if listener panics
+func postDeletePrefixProgress(listener DeletePrefixListener, progress *DeletePrefixProgress) (err error) {
+	if listener != nil {
+		defer recoverPanic("DeletePrefixListener", &err)
+		listener.DeletePrefixProgressChange(progress)
+	}
+	return nil
+}
+
+// prefixObjectsLister return a function returning the next object or version to delete, or nil if there's no more,
+// shard prefixes are listed one by one if KeySharder is set
+func (cli *ClientV2) prefixObjectsLister(input *DeletePrefixInput) func(ctx context.Context) (*ObjectTobeDeleted, error) {
+	if input.KeySharder == nil {
+		return cli.singlePrefixObjectsLister(input, input.Prefix)
+	}
+	prefixes := input.KeySharder.ShardPrefixes(input.Prefix)
+	next := cli.singlePrefixObjectsLister(input, prefixes[0])
+	prefixes = prefixes[1:]
+	return func(ctx context.Context) (*ObjectTobeDeleted, error) {
+		for {
+			object, err := next(ctx)
+			if err != nil || object != nil || len(prefixes) == 0 {
+				return object, err
+			}
+			next = cli.singlePrefixObjectsLister(input, prefixes[0])
+			prefixes = prefixes[1:]
+		}
+	}
+}
+
+func (cli *ClientV2) singlePrefixObjectsLister(input *DeletePrefixInput,
+	prefix string) func(ctx context.Context) (*ObjectTobeDeleted, error) {
+	if input.AllVersions {
+		it := cli.NewObjectVersionsIterator(&ListObjectVersionsV2Input{
+			Bucket:                  input.Bucket,
+			RequestPayer:            input.RequestPayer,
+			ListObjectVersionsInput: ListObjectVersionsInput{Prefix: prefix, MaxKeys: MaxDeleteObjects},
+		})
+		return func(ctx context.Context) (*ObjectTobeDeleted, error) {
+			entry, err := it.Next(ctx)
+			if err != nil || entry == nil {
+				return nil, err
+			}
+			return &ObjectTobeDeleted{Key: entry.Key, VersionID: entry.VersionID}, nil
+		}
+	}
+	lister := &objectLister{cli: cli, bucket: input.Bucket, prefix: prefix, requestPayer: input.RequestPayer}
+	return func(ctx context.Context) (*ObjectTobeDeleted, error) {
+		object, err := lister.next(ctx)
+		if err != nil || object == nil {
+			return nil, err
+		}
+		return &ObjectTobeDeleted{Key: object.Key}, nil
+	}
+}
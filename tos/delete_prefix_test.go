@@ -0,0 +1,103 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// prefixTransport list 1500 objects under "dir/" in 2 pages, and delete them, "dir/bad" can't be deleted
+type prefixTransport struct {
+	mu      sync.Mutex
+	deletes *deleteTransport
+	failing bool
+	payers  []string // X-Tos-Request-Payer of requests
+}
+
+func (rt *prefixTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.payers = append(rt.payers, req.Header.Get(HeaderRequestPayer))
+	if req.Method == http.MethodPost {
+		if rt.failing {
+			return &Response{StatusCode: http.StatusForbidden, Header: make(http.Header),
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"Code":"AccessDenied"}`)))}, nil
+		}
+		return rt.deletes.RoundTrip(ctx, req)
+	}
+	var out ListObjectsOutput
+	if req.Query.Get("marker") == "" {
+		for i := 0; i < 1000; i++ {
+			out.Contents = append(out.Contents, ListedObject{Key: fmt.Sprintf("dir/%04d", i)})
+		}
+		out.IsTruncated = true
+		out.NextMarker = "dir/0999"
+	} else {
+		for i := 1000; i < 1499; i++ {
+			out.Contents = append(out.Contents, ListedObject{Key: fmt.Sprintf("dir/%04d", i)})
+		}
+		out.Contents = append(out.Contents, ListedObject{Key: "dir/bad"})
+	}
+	body, _ := json.Marshal(&out)
+	return &Response{StatusCode: http.StatusOK, Header: make(http.Header),
+		Body: ioutil.NopCloser(bytes.NewReader(body))}, nil
+}
+
+// recordDeleteListener record progresses, it panics if panicking is set
+type recordDeleteListener struct {
+	mu         sync.Mutex
+	progresses []DeletePrefixProgress
+	panicking  bool
+}
+
+func (l *recordDeleteListener) DeletePrefixProgressChange(progress *DeletePrefixProgress) {
+	if l.panicking {
+		panic("listener panics")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.progresses = append(l.progresses, *progress)
+}
+
+func TestDeletePrefix(t *testing.T) {
+	transport := &prefixTransport{deletes: &deleteTransport{}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(0))
+	require.Nil(t, err)
+
+	listener := &recordDeleteListener{}
+	output, err := client.DeletePrefix(context.Background(), &DeletePrefixInput{Bucket: "bucket", Prefix: "dir/",
+		TaskNum: 2, Listener: listener})
+	require.Nil(t, err)
+	require.Equal(t, int64(1499), output.Deleted)
+	require.Equal(t, []DeleteError{{Code: "AccessDenied", Key: "dir/bad"}}, output.Errors)
+	require.Len(t, transport.deletes.batches, 2)
+	for _, batch := range transport.deletes.batches {
+		require.True(t, batch.Quiet)
+	}
+	require.Len(t, listener.progresses, 2)
+	// the listener is called concurrently, so progresses may be reported out of order
+	require.Contains(t, listener.progresses, DeletePrefixProgress{Deleted: 1499, Failed: 1})
+
+	// a panicking listener fails DeletePrefix rather than leaving other workers blocked
+	_, err = client.DeletePrefix(context.Background(), &DeletePrefixInput{Bucket: "bucket", Prefix: "dir/",
+		TaskNum: 2, Listener: &recordDeleteListener{panicking: true}})
+	require.NotNil(t, err)
+	var panicErr *PanicError
+	require.True(t, errors.As(err, &panicErr))
+	require.Equal(t, "DeletePrefixListener", panicErr.Callback)
+
+	transport.failing = true
+	_, err = client.DeletePrefix(context.Background(), &DeletePrefixInput{Bucket: "bucket", Prefix: "dir/"})
+	require.Equal(t, http.StatusForbidden, StatusCode(err))
+
+	_, err = client.DeletePrefix(context.Background(), &DeletePrefixInput{Bucket: "bucket"})
+	require.NotNil(t, err)
+}
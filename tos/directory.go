@@ -0,0 +1,86 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// dirKey return key of the directory path, which ends with '/'
+func dirKey(path string) string {
+	if strings.HasSuffix(path, "/") {
+		return path
+	}
+	return path + "/"
+}
+
+// CreateDirectory create a directory, parent directories are created implicitly in buckets with hierarchical
+// namespace, while an empty object ending with '/' is created in other buckets
+func (cli *ClientV2) CreateDirectory(ctx context.Context, input *CreateDirectoryInput, options ...Option) (*CreateDirectoryOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, dirKey(input.Key), options...).
+		WithOperation(OperationCreateDirectory).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &CreateDirectoryOutput{RequestInfo: res.RequestInfo()}, nil
+}
+
+// DeleteDirectory delete a directory of a bucket with hierarchical namespace, deleting with Recursive is atomic.
+// Use DeletePrefix for other buckets.
+//
+// Deleting recursively is not retried, since a retry of a deletion which succeeded fails as the directory doesn't
+// exist any more.
+func (cli *ClientV2) DeleteDirectory(ctx context.Context, input *DeleteDirectoryInput, options ...Option) (*DeleteDirectoryOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	var classifier Classifier = StatusCodeClassifier{}
+	if input.Recursive {
+		classifier = NoRetryClassifier{}
+	}
+	rb := cli.newBuilder(input.Bucket, dirKey(input.Key), options...).
+		WithOperation(OperationDeleteDirectory).
+		WithRetry(nil, classifier)
+	if input.Recursive {
+		rb.WithQuery("recursive", "true")
+	}
+	res, err := rb.Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteDirectoryOutput{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetFileStatus get status of an object or a directory of a bucket with hierarchical namespace, it fails with 404
+// if neither exists
+func (cli *ClientV2) GetFileStatus(ctx context.Context, input *GetFileStatusInput, options ...Option) (*GetFileStatusOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationGetFileStatus).
+		WithQuery("stat", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetFileStatusOutput{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// IsDirectory return whether the status is of a directory
+func (output *GetFileStatusOutput) IsDirectory() bool {
+	return strings.HasSuffix(output.Key, "/")
+}
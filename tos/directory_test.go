@@ -0,0 +1,78 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestDirectory(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "", WithMaxRetryCount(1))
+	ctx := context.Background()
+
+	_, err := client.CreateDirectory(ctx, &CreateDirectoryInput{Bucket: "bucket", Key: "a/b"})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Equal(t, "/a/b/", req.Path)
+	require.Nil(t, req.Content)
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteDirectory(ctx, &DeleteDirectoryInput{Bucket: "bucket", Key: "a/b/", Recursive: true})
+	require.Nil(t, err)
+	req = transport.requests[1]
+	require.Equal(t, http.MethodDelete, req.Method)
+	require.Equal(t, "/a/b/", req.Path)
+	require.Equal(t, "true", req.Query.Get("recursive"))
+
+	// deleting recursively is not retried
+	transport.respond(http.StatusServiceUnavailable, "")
+	_, err = client.DeleteDirectory(ctx, &DeleteDirectoryInput{Bucket: "bucket", Key: "a", Recursive: true})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 3)
+	transport.respond(http.StatusServiceUnavailable, "")
+	_, err = client.DeleteDirectory(ctx, &DeleteDirectoryInput{Bucket: "bucket", Key: "a"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 5)
+	require.NotContains(t, transport.requests[4].Query, "recursive")
+}
+
+func TestGetFileStatus(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"Key":"a/b/","Size":0,
+"LastModified":"2024-01-01T00:00:00.000Z"}`)
+
+	output, err := client.GetFileStatus(context.Background(), &GetFileStatusInput{Bucket: "bucket", Key: "a/b"})
+	require.Nil(t, err)
+	require.Contains(t, transport.requests[0].Query, "stat")
+	require.Equal(t, "/a/b", transport.requests[0].Path)
+	require.True(t, output.IsDirectory())
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), output.LastModified.UTC())
+
+	transport.respond(http.StatusOK, `{"Key":"a/b/c","Size":1024,
+"LastModified":"2024-01-01T00:00:00.000Z","CRC64":"123"}`)
+	output, err = client.GetFileStatus(context.Background(), &GetFileStatusInput{Bucket: "bucket", Key: "a/b/c"})
+	require.Nil(t, err)
+	require.False(t, output.IsDirectory())
+	require.Equal(t, int64(1024), output.Size)
+	require.Equal(t, "123", output.CRC64)
+}
+
+func TestHNSBucket(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderBucketType, string(enum.BucketTypeHNS))
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	_, err := client.CreateBucketV2(context.Background(), &CreateBucketV2Input{Bucket: "bucket",
+		BucketType: enum.BucketTypeHNS})
+	require.Nil(t, err)
+	require.Equal(t, "hns", transport.requests[0].Header.Get(HeaderBucketType))
+
+	output, err := client.HeadBucket(context.Background(), &HeadBucketInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, enum.BucketTypeHNS, output.BucketType)
+}
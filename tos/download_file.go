@@ -1,75 +1,115 @@
 package tos
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
 )
 
-// func getDownloadCheckpoint(enabled bool, checkpointPath string, init func() (*downloadCheckpoint, error)) (checkpoint *downloadCheckpoint, err error) {
-// 	if enabled {
-// 		_, err = os.Stat(checkpointPath)
-// 		// if err is not empty, assume checkpoint not exists
-// 		if err == nil {
-// 			loadCheckPoint(checkpointPath, checkpoint)
-// 			if checkpoint != nil {
-// 				return
-// 			}
-// 		}
-// 		_, err = os.Create(checkpointPath)
-// 		if err != nil {
-// 			return nil, newTosClientError(err.Error(), err)
-// 		}
-// 	}
-// 	checkpoint, err = init()
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	if enabled {
-// 		err = checkpoint.WriteToFile()
-// 		if err != nil {
-// 			return nil, err
-// 		}
-// 	}
-// 	return
-// }
+// getDownloadCheckpoint get checkpoint from checkpoint file if it's valid, or initialize from scratch with function init
+func getDownloadCheckpoint(enabled bool, checkpointPath string, valid func(checkpoint *downloadCheckpoint) bool,
+	init func() (*downloadCheckpoint, error)) (*downloadCheckpoint, error) {
+	if enabled {
+		loaded := &downloadCheckpoint{checkpointPath: checkpointPath}
+		ok, err := loadCheckPoint(checkpointPath, loaded)
+		if err != nil {
+			return nil, err
+		}
+		if ok && valid(loaded) {
+			return loaded, nil
+		}
+	}
+	checkpoint, err := init()
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		if err = checkpoint.WriteToFile(); err != nil {
+			return nil, err
+		}
+	}
+	return checkpoint, nil
+}
 
-// func (cli *ClientV2) DownloadFile(ctx context.Context, input *DownloadFileInput) (*DownloadFileOutput, error) {
-// 	err := validateDownloadInput(input)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	headOutput, err := cli.HeadObjectV2(ctx, &input.HeadObjectV2Input)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	init := func() (*downloadCheckpoint, error) {
-// 		err := createTempFile(input.tempFile, input.Bucket, input.Key,
-// 			input.VersionID, input.FilePath, input.DownloadEventListener)
-// 		if err != nil {
-// 			return nil, err
-// 		}
-// 		return initDownloadCheckpoint(input, headOutput)
-// 	}
-// 	checkpoint, err := getDownloadCheckpoint(input.EnableCheckpoint, input.CheckpointFile, init)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return cli.downloadFile(ctx, headOutput, checkpoint, input)
-// }
+// DownloadFile download an object to FilePath by ranged GETs, parts are downloaded concurrently by TaskNum goroutines
+// to a temp file, which is renamed to FilePath once all parts are downloaded. If EnableCheckpoint is set, downloaded
+// parts are recorded in the checkpoint file, and DownloadFile called again with the same input after a failure or
+// crash resumes the download, unless the object is changed.
+//
+// Ranged GETs of parts are pinned to the ETag of the object when the download starts, the ETag is from HeadObjectV2,
+// or from ObjectInfo to skip HeadObjectV2, so parts of an object overwritten during the download fail with 412.
+func (cli *ClientV2) DownloadFile(ctx context.Context, input *DownloadFileInput) (*DownloadFileOutput, error) {
+	// avoid modifying on origin input
+	copied := *input
+	input = &copied
+	if err := cli.validateDownloadInput(input); err != nil {
+		return nil, err
+	}
+	// skip HEAD if object info is known, e.g. from a manifest, it's validated lazily by If-Match of ranged GETs
+	headOutput := input.ObjectInfo.headOutput()
+	if headOutput == nil {
+		var err error
+		headOutput, err = cli.HeadObjectV2(ctx, &input.HeadObjectV2Input)
+		if err != nil {
+			return nil, err
+		}
+	}
+	valid := func(checkpoint *downloadCheckpoint) bool {
+		// parts downloaded are in temp file, so the temp file must be kept as well
+		stat, err := os.Stat(input.tempFile)
+		return err == nil && stat.Size() == headOutput.ContentLength && checkpoint.Valid(input, headOutput)
+	}
+	init := func() (*downloadCheckpoint, error) {
+		err := createTempFile(input.tempFile, headOutput.ContentLength, input)
+		if err != nil {
+			return nil, err
+		}
+		return initDownloadCheckpoint(input, headOutput)
+	}
+	checkpoint, err := getDownloadCheckpoint(input.EnableCheckpoint, input.CheckpointFile, valid, init)
+	if err != nil {
+		return nil, err
+	}
+	bindCancelHookWithCleaner(input.CancelHook, func() {
+		_ = os.Remove(input.CheckpointFile)
+		_ = os.Remove(input.tempFile)
+	})
+	return cli.downloadParts(ctx, headOutput, checkpoint, input)
+}
 
-// loadCheckPoint load UploadFile checkpoint or DownloadFile checkpoint.
-// checkpoint must be a pointer
-func loadCheckPoint(path string, checkpoint interface{}) {
-	contents, err := ioutil.ReadFile(path)
-	if err != nil && !os.IsNotExist(err) {
-		return
+// downloadCheckpointFile return the correct checkpoint path of DownloadFile
+func downloadCheckpointFile(input *DownloadFileInput, checkpointDir string) string {
+	checkpointFile := input.CheckpointFile
+	if len(checkpointFile) == 0 {
+		if len(checkpointDir) == 0 {
+			checkpointDir = filepath.Dir(input.FilePath)
+		}
+		return fileNameInDir(checkpointDir, input.FilePath, input.Bucket, input.Key, ".download")
 	}
-	if len(contents) == 0 {
-		return
+	mustFile(&checkpointFile, fileNameInDir("", input.FilePath, input.Bucket, input.Key, ".download"))
+	return checkpointFile
+}
+
+// fileNameInDir return a file name in dir for the transfer between file and object,
+// the name is unique for each combination of file path, bucket and key.
+func fileNameInDir(dir, filePath, bucket, key, suffix string) string {
+	sum := md5.Sum([]byte(strings.Join([]string{filePath, bucket, key}, "\n")))
+	return filepath.Join(dir, filepath.Base(filePath)+"."+hex.EncodeToString(sum[:])+suffix)
+}
+
+// tempFilePath return the temp file path for downloading to filePath
+func (cli *ClientV2) tempFilePath(filePath, bucket, key string) string {
+	if len(cli.config.TempFileDir) == 0 {
+		return filePath + TempFileSuffix
 	}
-	json.Unmarshal(contents, &checkpoint)
+	return fileNameInDir(cli.config.TempFileDir, filePath, bucket, key, TempFileSuffix)
 }
 
 // if file is a directory, append suffix to it to make a file name
@@ -80,238 +120,328 @@ func mustFile(file *string, suffix string) {
 	}
 }
 
-// func validateDownloadInput(input *DownloadFileInput) error {
-// 	if err := isValidNames(input.Bucket, input.Key); err != nil {
-// 		return err
-// 	}
-// 	if input.PartSize == 0 {
-// 		input.PartSize = MinPartSize
-// 	}
-// 	if input.PartSize < MinPartSize || input.PartSize > MaxPartSize {
-// 		return newTosClientError("The input part size is invalid, please set it range from 5MB to 5GB", nil)
-// 	}
-// 	// if directory, append object key at end
-// 	mustFile(&input.FilePath, input.Key)
-// 	input.tempFile = input.FilePath + TempFileSuffix
-// 	if input.EnableCheckpoint {
-// 		// get correct checkpoint path
-// 		if len(input.CheckpointFile) == 0 {
-// 			dirName, _ := filepath.Split(input.FilePath)
-// 			fileName := strings.Join([]string{input.FilePath, input.Bucket, input.Key, "download"}, ".")
-// 			input.CheckpointFile = filepath.Join(dirName, fileName)
-// 		} else {
-// 			mustFile(&input.CheckpointFile, strings.Join([]string{input.FilePath, input.Bucket, input.Key, "download"}, "."))
-// 		}
-// 	}
-// 	if input.TaskNum < 1 {
-// 		input.TaskNum = 1
-// 	}
-// 	if input.TaskNum > 1000 {
-// 		input.TaskNum = 1000
-// 	}
-// 	return nil
-// }
+// validateDownloadInput validate download input, return TosClientError failed
+func (cli *ClientV2) validateDownloadInput(input *DownloadFileInput) error {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return err
+	}
+	if len(input.FilePath) == 0 {
+		return newTosClientError("tos: empty file path of DownloadFile", nil)
+	}
+	if input.PartSize == 0 {
+		input.PartSize = MinPartSize
+	}
+	if input.PartSize < MinPartSize || input.PartSize > MaxPartSize {
+		return newTosClientError("tos: the input part size is invalid, please set it range from 5MB to 5GB.", nil)
+	}
+	if info := input.ObjectInfo; info != nil {
+		if info.ContentLength < 0 {
+			return newTosClientError("tos: invalid content length of object info", nil)
+		}
+		if len(info.ETag) == 0 {
+			return newTosClientError("tos: empty ETag of object info", nil)
+		}
+	}
+	// if directory, append object key at end
+	mustFile(&input.FilePath, input.Key)
+	input.tempFile = cli.tempFilePath(input.FilePath, input.Bucket, input.Key)
+	if input.EnableCheckpoint {
+		input.CheckpointFile = downloadCheckpointFile(input, cli.config.CheckpointDir)
+	}
+	if input.TaskNum < 1 {
+		input.TaskNum = 1
+	}
+	if input.TaskNum > 1000 {
+		input.TaskNum = 1000
+	}
+	return nil
+}
 
-// func initDownloadCheckpoint(input *DownloadFileInput, headOutput *HeadObjectV2Output) (*downloadCheckpoint, error) {
-// 	partsNum := headOutput.ContentLength / input.PartSize
-// 	remainder := headOutput.ContentLength % input.PartSize
-// 	if remainder != 0 {
-// 		partsNum++
-// 	}
-// 	parts := make([]downloadPartInfo, partsNum)
-// 	for i := int64(0); i < partsNum; i++ {
-// 		parts[i] = downloadPartInfo{
-// 			PartNumber: int(i + 1),
-// 			RangeStart: i * input.PartSize,
-// 			RangeEnd:   (i+1)*input.PartSize - 1,
-// 		}
-// 	}
-// 	if remainder != 0 {
-// 		parts[partsNum-1].RangeEnd = (partsNum-1)*input.PartSize + remainder - 1
-// 	}
-// 	if len(parts) > 10000 {
-// 		return nil, newTosClientError("tos: part count too many", nil)
-// 	}
-// 	return &downloadCheckpoint{
-// 		checkpointPath:    input.CheckpointFile,
-// 		Bucket:            input.Bucket,
-// 		Key:               input.Key,
-// 		VersionID:         input.VersionID,
-// 		PartSize:          input.PartSize,
-// 		IfMatch:           input.IfMatch,
-// 		IfModifiedSince:   input.IfModifiedSince,
-// 		IfNoneMatch:       input.IfNoneMatch,
-// 		IfUnmodifiedSince: input.IfUnmodifiedSince,
-// 		SSECAlgorithm:     input.SSECAlgorithm,
-// 		SSECKeyMD5:        input.SSECKey,
-// 		ObjectInfo: downloadObjectInfo{
-// 			Etag:          headOutput.ETag,
-// 			HashCrc64ecma: headOutput.HashCrc64ecma,
-// 			LastModified:  headOutput.LastModified,
-// 			ObjectSize:    headOutput.ContentLength,
-// 		},
-// 		FileInfo: downloadFileInfo{
-// 			FilePath:     input.FilePath,
-// 			TempFilePath: input.tempFile,
-// 		},
-// 		PartsInfo: parts,
-// 	}, nil
-// }
-//
-// func createTempFile(tempFilePath string, bucket, key, versionID string, filePath string, listener DownloadEventListener) error {
-// 	_, err := os.Create(tempFilePath)
-// 	if err != nil {
-// 		postDownloadEvent(listener, &DownloadEvent{
-// 			Type:      enum.DownloadEventCreateTempFileFailed,
-// 			Bucket:    bucket,
-// 			Key:       key,
-// 			VersionID: versionID,
-// 			FilePath:  filePath,
-// 		})
-// 		return newTosClientError("tos: create temp file failed.", err)
-// 	}
-// 	postDownloadEvent(listener, &DownloadEvent{
-// 		Type:         enum.DownloadEventCreateTempFileSucceed,
-// 		Bucket:       bucket,
-// 		Key:          key,
-// 		VersionID:    versionID,
-// 		FilePath:     filePath,
-// 		TempFilePath: &tempFilePath,
-// 	})
-// 	return nil
-// }
+// initDownloadCheckpoint initialize checkpoint of downloading the object described by headOutput
+func initDownloadCheckpoint(input *DownloadFileInput, headOutput *HeadObjectV2Output) (*downloadCheckpoint, error) {
+	partsNum := (headOutput.ContentLength + input.PartSize - 1) / input.PartSize
+	if partsNum > 10000 {
+		return nil, newTosClientError("tos: part count too many", nil)
+	}
+	parts := make([]downloadPartInfo, partsNum)
+	for i := int64(0); i < partsNum; i++ {
+		end := (i+1)*input.PartSize - 1
+		if end >= headOutput.ContentLength {
+			end = headOutput.ContentLength - 1
+		}
+		parts[i] = downloadPartInfo{
+			PartNumber: int(i + 1),
+			RangeStart: i * input.PartSize,
+			RangeEnd:   end,
+		}
+	}
+	return &downloadCheckpoint{
+		checkpointPath:    input.CheckpointFile,
+		Bucket:            input.Bucket,
+		Key:               input.Key,
+		VersionID:         input.VersionID,
+		PartSize:          input.PartSize,
+		IfMatch:           input.IfMatch,
+		IfModifiedSince:   input.IfModifiedSince,
+		IfNoneMatch:       input.IfNoneMatch,
+		IfUnmodifiedSince: input.IfUnmodifiedSince,
+		SSECAlgorithm:     input.SSECAlgorithm,
+		SSECKeyMD5:        input.SSECKeyMD5,
+		ObjectInfo: downloadObjectInfo{
+			Etag:          headOutput.ETag,
+			HashCrc64ecma: headOutput.HashCrc64ecma,
+			LastModified:  headOutput.LastModified,
+			ObjectSize:    headOutput.ContentLength,
+		},
+		FileInfo: downloadFileInfo{
+			FilePath:     input.FilePath,
+			TempFilePath: input.tempFile,
+		},
+		PartsInfo: parts,
+	}, nil
+}
 
-// func getDownloadTasks(cli *ClientV2, ctx context.Context, headOutput *HeadObjectV2Output,
-// 	checkpoint *downloadCheckpoint, input *DownloadFileInput) []task {
-// 	tasks := make([]task, 0)
-// 	consumed := int64(0)
-// 	mutex := sync.Mutex{}
-// 	for _, part := range checkpoint.PartsInfo {
-// 		if !part.IsCompleted {
-// 			tasks = append(tasks, &downloadTask{
-// 				cli:        cli,
-// 				ctx:        ctx,
-// 				input:      input,
-// 				PartNumber: part.PartNumber,
-// 				RangeStart: part.RangeStart,
-// 				RangeEnd:   part.RangeEnd,
-// 				consumed:   &consumed,
-// 				total:      headOutput.ContentLength,
-// 				mutex:      &mutex,
-// 			})
-// 		}
-// 	}
-// 	return tasks
-// }
-//
-// func newDownloadEvent(input *DownloadFileInput) *DownloadEvent {
-// 	return &DownloadEvent{
-// 		Bucket:         input.Bucket,
-// 		Key:            input.Key,
-// 		VersionID:      input.VersionID,
-// 		FilePath:       input.FilePath,
-// 		CheckpointFile: &input.CheckpointFile,
-// 		TempFilePath:   &input.tempFile,
-// 	}
-// }
-//
-// func newDownloadPartSucceedEvent(part downloadPartInfo, input *DownloadFileInput) *DownloadEvent {
-// 	event := newSucceedEvent(enum.DownloadEventDownloadPartSucceed, input)
-// 	event.DowloadPartInfo = &DownloadPartInfo{
-// 		PartNumber: part.PartNumber,
-// 		RangeStart: part.RangeStart,
-// 		RangeEnd:   part.RangeEnd,
-// 	}
-// 	return event
-// }
-//
-// func newSucceedEvent(eventType enum.DownloadEventType, input *DownloadFileInput) *DownloadEvent {
-// 	event := newDownloadEvent(input)
-// 	event.Type = eventType
-// 	return event
-// }
-//
-// func newFailedEvent(err error, eventType enum.DownloadEventType, input *DownloadFileInput) *DownloadEvent {
-// 	event := newDownloadEvent(input)
-// 	event.Type = eventType
-// 	event.Err = err
-// 	return event
-// }
-//
-// func postDownloadEvent(listener DownloadEventListener, event *DownloadEvent) {
-// 	if listener != nil {
-// 		listener.EventChange(event)
-// 	}
-// }
-//
-// func (cli *ClientV2) downloadFile(ctx context.Context,
-// 	headOutput *HeadObjectV2Output, checkpoint *downloadCheckpoint, input *DownloadFileInput) (*DownloadFileOutput, error) {
-// 	// prepare tasks
-// 	tasks := getDownloadTasks(cli, ctx, headOutput, checkpoint, input)
-// 	if len(tasks) < input.TaskNum {
-// 		input.TaskNum = len(tasks)
-// 	}
-// 	manager := newTaskManager(input.TaskNum)
-// 	manager.addTask(tasks...)
-// 	input.withCancelHook(canceler{
-// 		called: false,
-// 		cancelHandle: manager.cancelHandle,
-// 		files:  []string{input.tempFile, input.CheckpointFile},
-// 	})
-// 	postDataTransferStatus(input.DataTransferListener, &DataTransferStatus{
-// 		TotalBytes: checkpoint.ObjectInfo.ObjectSize,
-// 		Type:       enum.DataTransferStarted,
-// 	})
-// 	manager.run()
-// 	success := 0
-// 	fail := make([]error, 0, len(tasks))
-// Loop:
-// 	for success+len(fail) < len(tasks) {
-// 		select {
-// 		case <-manager.cancelHandle:
-// 			break Loop
-// 		case result := <-manager.results:
-// 			if part, ok := result.(downloadPartInfo); ok {
-// 				success++
-// 				checkpoint.UpdatePartsInfo(part)
-// 				if input.EnableCheckpoint {
-// 					err := checkpoint.WriteToFile()
-// 					if err != nil {
-// 						return nil, err
-// 					}
-// 				}
-// 				postDownloadEvent(input.DownloadEventListener, newDownloadPartSucceedEvent(part, input))
-// 				if success == len(tasks) {
-// 					break Loop
-// 				}
-// 			}
-// 		case err := <-manager.failed:
-// 			if StatusCode(err) == 403 || StatusCode(err) == 404 || StatusCode(err) == 405 {
-// 				close(manager.cancelHandle)
-// 				postDownloadEvent(input.DownloadEventListener, newFailedEvent(err, enum.DownloadEventDownloadPartAborted, input))
-// 				_ = os.Remove(input.CheckpointFile)
-// 				_ = os.Remove(input.tempFile)
-// 				break Loop
-// 			} else {
-// 				postDownloadEvent(input.DownloadEventListener, newFailedEvent(err, enum.DownloadEventDownloadPartFailed, input))
-// 				fail = append(fail, err)
-// 			}
-// 		}
-// 	}
-//
-// 	if success < len(tasks) {
-// 		return nil, newTosClientError("tos: some download task failed.", nil)
-// 	}
-// 	err := checkFileCrc64(input.tempFile, headOutput.HashCrc64ecma)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	err = os.Rename(input.tempFile, input.FilePath)
-// 	if err != nil {
-// 		postDownloadEvent(input.DownloadEventListener, newFailedEvent(err, enum.DownloadEventRenameTempFileFailed, input))
-// 		return nil, err
-// 	}
-// 	postDownloadEvent(input.DownloadEventListener, newSucceedEvent(enum.DownloadEventRenameTempFileSucceed, input))
-// 	_ = os.Remove(checkpoint.checkpointPath)
-// 	return &DownloadFileOutput{*headOutput}, nil
-// }
+// createTempFile create the temp file of size to write parts to, parent directories are created if they don't exist
+func createTempFile(tempFilePath string, size int64, input *DownloadFileInput) error {
+	err := os.MkdirAll(filepath.Dir(tempFilePath), 0755)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(input.FilePath), 0755)
+	}
+	var fd *os.File
+	if err == nil {
+		fd, err = os.OpenFile(tempFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, DefaultFilePerm)
+	}
+	if err == nil {
+		err = fd.Truncate(size)
+		if cerr := fd.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		_ = postDownloadEvent(input.DownloadEventListener, newFailedEvent(err, enum.DownloadEventCreateTempFileFailed, input))
+		return newTosClientError("tos: create temp file failed.", err)
+	}
+	return postDownloadEvent(input.DownloadEventListener, newSucceedEvent(enum.DownloadEventCreateTempFileSucceed, input))
+}
+
+func newDownloadEvent(input *DownloadFileInput) *DownloadEvent {
+	return &DownloadEvent{
+		Bucket:         input.Bucket,
+		Key:            input.Key,
+		VersionID:      input.VersionID,
+		FilePath:       input.FilePath,
+		CheckpointFile: &input.CheckpointFile,
+		TempFilePath:   &input.tempFile,
+	}
+}
+
+func newDownloadPartSucceedEvent(part downloadPartInfo, input *DownloadFileInput) *DownloadEvent {
+	event := newSucceedEvent(enum.DownloadEventDownloadPartSucceed, input)
+	event.DowloadPartInfo = &DownloadPartInfo{
+		PartNumber: part.PartNumber,
+		RangeStart: part.RangeStart,
+		RangeEnd:   part.RangeEnd,
+	}
+	return event
+}
+
+func newSucceedEvent(eventType enum.DownloadEventType, input *DownloadFileInput) *DownloadEvent {
+	event := newDownloadEvent(input)
+	event.Type = eventType
+	return event
+}
+
+func newFailedEvent(err error, eventType enum.DownloadEventType, input *DownloadFileInput) *DownloadEvent {
+	event := newDownloadEvent(input)
+	event.Type = eventType
+	event.Err = err
+	return event
+}
+
+// postDownloadEvent return TosClientError if listener panics
+func postDownloadEvent(listener DownloadEventListener, event *DownloadEvent) (err error) {
+	if listener != nil {
+		defer recoverPanic("DownloadEventListener", &err)
+		listener.EventChange(event)
+	}
+	return nil
+}
+
+// isDownloadAborted report whether the download should be aborted for err of a part, parts downloaded so far are
+// useless if the object is not accessible, or is changed during downloading with strict consistency
+func isDownloadAborted(err error, strictConsistency bool) bool {
+	switch StatusCode(err) {
+	case http.StatusForbidden, http.StatusNotFound, http.StatusMethodNotAllowed:
+		return true
+	case http.StatusPreconditionFailed:
+		return strictConsistency
+	}
+	return false
+}
+
+// downloadParts download parts not completed in checkpoint to temp file, and rename temp file to FilePath
+func (cli *ClientV2) downloadParts(ctx context.Context, headOutput *HeadObjectV2Output, checkpoint *downloadCheckpoint,
+	input *DownloadFileInput) (*DownloadFileOutput, error) {
+	// taskCtx is canceled once a part fails or CancelHook is called
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	consumed := int64(0)
+	for _, part := range checkpoint.PartsInfo {
+		if part.IsCompleted {
+			consumed += part.RangeEnd - part.RangeStart + 1
+		}
+	}
+	subtotal := int64(0)
+	rate := newTransferRate()
+	tasks := make([]task, 0, len(checkpoint.PartsInfo))
+	for _, part := range checkpoint.PartsInfo {
+		if !part.IsCompleted {
+			tasks = append(tasks, &downloadTask{
+				cli:        cli,
+				ctx:        taskCtx,
+				input:      input,
+				consumed:   &consumed,
+				subtotal:   &subtotal,
+				rate:       rate,
+				total:      headOutput.ContentLength,
+				PartNumber: part.PartNumber,
+				RangeStart: part.RangeStart,
+				RangeEnd:   part.RangeEnd,
+				etag:       headOutput.ETag,
+			})
+		}
+	}
+	cancelHandle := getCancelHandle(input.CancelHook)
+	go func() {
+		select {
+		case <-cancelHandle:
+			cancel()
+		case <-taskCtx.Done():
+		}
+	}()
+	if err := postDataTransferStatus(input.DataTransferListener, &DataTransferStatus{
+		TotalBytes: headOutput.ContentLength,
+		Type:       enum.DataTransferStarted,
+	}); err != nil {
+		return nil, err
+	}
+
+	type taskResult struct {
+		part downloadPartInfo
+		err  error
+	}
+	tasksCh := make(chan task)
+	resultsCh := make(chan taskResult)
+	var wg sync.WaitGroup
+	for i := 0; i < min(input.TaskNum, len(tasks)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasksCh {
+				if taskCtx.Err() != nil {
+					// the download is aborted or canceled, drain the remaining tasks
+					continue
+				}
+				result, err := t.do()
+				if err != nil {
+					if isDownloadAborted(err, input.StrictConsistency) {
+						// stop picking up tasks before the result is handled
+						cancel()
+					}
+					resultsCh <- taskResult{err: err}
+					continue
+				}
+				resultsCh <- taskResult{part: result.(downloadPartInfo)}
+			}
+		}()
+	}
+	go func() {
+		defer close(tasksCh)
+		for _, t := range tasks {
+			select {
+			case tasksCh <- t:
+			case <-taskCtx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	var (
+		taskErr   error
+		aborted   bool
+		completed int
+	)
+	for result := range resultsCh {
+		if result.err != nil {
+			if taskErr == nil {
+				taskErr = result.err
+			}
+			if !isDownloadAborted(result.err, input.StrictConsistency) {
+				// other parts go on, so that less is left when the download is resumed
+				cli.logger.Warn("tos: download part failed", "bucket", input.Bucket, "key", input.Key, "error", result.err)
+				_ = postDownloadEvent(input.DownloadEventListener, newFailedEvent(result.err, enum.DownloadEventDownloadPartFailed, input))
+			} else if !aborted {
+				aborted = true
+				taskErr = result.err
+				cancel()
+				_ = postDownloadEvent(input.DownloadEventListener, newFailedEvent(result.err, enum.DownloadEventDownloadPartAborted, input))
+			}
+			continue
+		}
+		completed++
+		checkpoint.UpdatePartsInfo(result.part)
+		if input.EnableCheckpoint {
+			if err := checkpoint.WriteToFile(); err != nil {
+				cli.logger.Warn("tos: write checkpoint file failed", "checkpointFile", input.CheckpointFile, "error", err)
+			}
+		}
+		if err := postDownloadEvent(input.DownloadEventListener, newDownloadPartSucceedEvent(result.part, input)); err != nil && taskErr == nil {
+			taskErr = err
+			cancel()
+		}
+	}
+	select {
+	case <-cancelHandle:
+		return nil, newTosClientError("tos: download is canceled", taskErr)
+	default:
+	}
+	if aborted {
+		// the download can't be resumed
+		_ = os.Remove(input.CheckpointFile)
+		_ = os.Remove(input.tempFile)
+		if StatusCode(taskErr) == http.StatusPreconditionFailed {
+			return nil, newTosClientError("tos: object is changed during downloading", taskErr)
+		}
+		return nil, taskErr
+	}
+	if taskErr == nil && completed < len(tasks) {
+		taskErr = newTosClientError("tos: download is canceled", ctx.Err())
+	}
+	if taskErr != nil {
+		if !input.EnableCheckpoint {
+			_ = os.Remove(input.tempFile)
+		}
+		return nil, taskErr
+	}
+
+	if cli.enableCRC && headOutput.HashCrc64ecma != 0 {
+		if err := checkFileCrc64(input.tempFile, headOutput.HashCrc64ecma); err != nil {
+			_ = os.Remove(input.CheckpointFile)
+			_ = os.Remove(input.tempFile)
+			return nil, err
+		}
+	}
+	if err := os.Rename(input.tempFile, input.FilePath); err != nil {
+		_ = postDownloadEvent(input.DownloadEventListener, newFailedEvent(err, enum.DownloadEventRenameTempFileFailed, input))
+		return nil, newTosClientError("tos: rename temp file failed", err)
+	}
+	if err := postDownloadEvent(input.DownloadEventListener, newSucceedEvent(enum.DownloadEventRenameTempFileSucceed, input)); err != nil {
+		return nil, err
+	}
+	_ = os.Remove(input.CheckpointFile)
+	return &DownloadFileOutput{*headOutput}, nil
+}
@@ -0,0 +1,189 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/crc64"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// downloadTransport serves DownloadFile requests for data with ETag etag, ranged GETs starting at failStart fail
+// with failCode
+type downloadTransport struct {
+	mu        sync.Mutex
+	data      []byte
+	etag      string
+	failStart int64
+	failCode  int
+	heads     int
+	ranges    []string
+	ifMatch   []string
+}
+
+func (rt *downloadTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	header := make(http.Header)
+	header.Set(HeaderETag, rt.etag)
+	header.Set(HeaderHashCrc64ecma, strconv.FormatUint(crc64.Checksum(rt.data, DefaultCrcTable()), 10))
+	if req.Method == http.MethodHead {
+		rt.heads++
+		header.Set(HeaderContentLength, strconv.Itoa(len(rt.data)))
+		return &Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	}
+	rt.ranges = append(rt.ranges, req.Header.Get(HeaderRange))
+	rt.ifMatch = append(rt.ifMatch, req.Header.Get(HeaderIfMatch))
+	var start, end int64
+	fmt.Sscanf(req.Header.Get(HeaderRange), "bytes=%d-%d", &start, &end)
+	if start == rt.failStart && rt.failCode != 0 {
+		return &Response{StatusCode: rt.failCode, Header: header, Body: ioutil.NopCloser(strings.NewReader(`{"Code":"Failed"}`))}, nil
+	}
+	if req.Header.Get(HeaderIfMatch) != rt.etag {
+		return &Response{StatusCode: http.StatusPreconditionFailed, Header: header,
+			Body: ioutil.NopCloser(strings.NewReader(`{"Code":"PreconditionFailed"}`))}, nil
+	}
+	header.Set(HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, len(rt.data)))
+	return &Response{StatusCode: http.StatusPartialContent, Header: header,
+		Body: ioutil.NopCloser(bytes.NewReader(rt.data[start : end+1]))}, nil
+}
+
+func newDownloadData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+func TestDownloadFileResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-download")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	transport := &downloadTransport{data: newDownloadData(2*MinPartSize + 1), etag: `"etag"`,
+		failStart: 2 * MinPartSize, failCode: http.StatusInternalServerError}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(0))
+	require.Nil(t, err)
+	filePath := filepath.Join(dir, "sub", "file")
+	input := &DownloadFileInput{
+		HeadObjectV2Input: HeadObjectV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:          filePath,
+		EnableCheckpoint:  true,
+	}
+
+	_, err = client.DownloadFile(context.Background(), input)
+	require.Equal(t, http.StatusInternalServerError, StatusCode(err))
+	_, err = os.Stat(filePath)
+	require.True(t, os.IsNotExist(err))
+
+	transport.failCode = 0
+	transport.ranges = nil
+	output, err := client.DownloadFile(context.Background(), input)
+	require.Nil(t, err)
+	require.Equal(t, `"etag"`, output.ETag)
+	// completed parts are not downloaded again
+	require.Equal(t, []string{"bytes=10485760-10485760"}, transport.ranges)
+	for _, ifMatch := range transport.ifMatch {
+		require.Equal(t, `"etag"`, ifMatch)
+	}
+	content, err := ioutil.ReadFile(filePath)
+	require.Nil(t, err)
+	require.Equal(t, transport.data, content)
+	files, err := ioutil.ReadDir(filepath.Dir(filePath))
+	require.Nil(t, err)
+	require.Len(t, files, 1, "temp file and checkpoint file are removed")
+}
+
+func TestDownloadFileObjectInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-download")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	transport := &downloadTransport{data: newDownloadData(MinPartSize + 1), etag: `"etag"`, failStart: -1}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(0))
+	require.Nil(t, err)
+	input := &DownloadFileInput{
+		HeadObjectV2Input: HeadObjectV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:          filepath.Join(dir, "file"),
+		TaskNum:           2,
+		ObjectInfo: &DownloadObjectInfo{
+			ContentLength: int64(len(transport.data)),
+			ETag:          `"etag"`,
+			HashCrc64ecma: crc64.Checksum(transport.data, DefaultCrcTable()),
+		},
+	}
+
+	output, err := client.DownloadFile(context.Background(), input)
+	require.Nil(t, err)
+	require.Equal(t, 0, transport.heads)
+	require.Equal(t, int64(len(transport.data)), output.ContentLength)
+	require.Equal(t, []string{`"etag"`, `"etag"`}, transport.ifMatch)
+	content, err := ioutil.ReadFile(input.FilePath)
+	require.Nil(t, err)
+	require.Equal(t, transport.data, content)
+
+	// ETag is required to validate object info
+	_, err = client.DownloadFile(context.Background(), &DownloadFileInput{
+		HeadObjectV2Input: HeadObjectV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:          filepath.Join(dir, "no-etag"),
+		ObjectInfo:        &DownloadObjectInfo{ContentLength: int64(len(transport.data))},
+	})
+	require.NotNil(t, err)
+	_, ok := err.(*TosClientError)
+	require.True(t, ok)
+	require.Equal(t, 0, transport.heads)
+	require.Len(t, transport.ifMatch, 2)
+
+	// the object is overwritten since object info is collected
+	transport.etag = `"new"`
+	_, err = client.DownloadFile(context.Background(), input)
+	require.Equal(t, http.StatusPreconditionFailed, StatusCode(err))
+	require.Equal(t, 0, transport.heads)
+	_, err = os.Stat(input.FilePath + TempFileSuffix)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestDownloadFileStrictConsistency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-download")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	transport := &downloadTransport{data: newDownloadData(MinPartSize + 1), etag: `"etag"`, failStart: -1}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(0))
+	require.Nil(t, err)
+	input := &DownloadFileInput{
+		HeadObjectV2Input: HeadObjectV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:          filepath.Join(dir, "file"),
+		EnableCheckpoint:  true,
+		ObjectInfo:        &DownloadObjectInfo{ContentLength: int64(len(transport.data)), ETag: `"old"`},
+	}
+	checkpointFile := downloadCheckpointFile(input, "")
+
+	// the part is reported as failed, the checkpoint is kept
+	_, err = client.DownloadFile(context.Background(), input)
+	require.Equal(t, http.StatusPreconditionFailed, StatusCode(err))
+	require.Len(t, transport.ranges, 2)
+	_, err = os.Stat(checkpointFile)
+	require.Nil(t, err)
+
+	// the download is aborted at the first part failed with 412
+	transport.ranges = nil
+	input.StrictConsistency = true
+	_, err = client.DownloadFile(context.Background(), input)
+	require.NotNil(t, err)
+	require.True(t, IsPreconditionFailed(err))
+	_, ok := err.(*TosClientError)
+	require.True(t, ok)
+	require.Len(t, transport.ranges, 1)
+	_, err = os.Stat(checkpointFile)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(input.FilePath + TempFileSuffix)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,82 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// PutBucketEncryptionV2 set the default server side encryption of a bucket, which applies to objects uploaded
+// afterwards without encryption headers
+func (cli *ClientV2) PutBucketEncryptionV2(ctx context.Context, input *PutBucketEncryptionV2Input, options ...Option) (*PutBucketEncryptionV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	sse := input.Rule.ApplyServerSideEncryptionByDefault
+	switch sse.SSEAlgorithm {
+	case enum.SSEAlgorithmAES256:
+		if len(sse.KMSMasterKeyID) > 0 {
+			return nil, newTosClientError("tos: KMSMasterKeyID can only be set with SSE-KMS", nil)
+		}
+	case enum.SSEAlgorithmKMS:
+	default:
+		return nil, newTosClientError("tos: invalid SSEAlgorithm", nil)
+	}
+	in, contentMD5, err := marshalInput("PutBucketEncryptionV2Input", struct {
+		Rule BucketEncryptionRule `json:"Rule"`
+	}{input.Rule})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketEncryption).
+		WithQuery("encryption", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketEncryptionV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketEncryptionV2 get the default server side encryption of a bucket
+func (cli *ClientV2) GetBucketEncryptionV2(ctx context.Context, input *GetBucketEncryptionV2Input, options ...Option) (*GetBucketEncryptionV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketEncryption).
+		WithQuery("encryption", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketEncryptionV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketEncryptionV2 remove the default server side encryption of a bucket, existing objects stay encrypted
+func (cli *ClientV2) DeleteBucketEncryptionV2(ctx context.Context, input *DeleteBucketEncryptionV2Input, options ...Option) (*DeleteBucketEncryptionV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketEncryption).
+		WithQuery("encryption", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketEncryptionV2Output{RequestInfo: res.RequestInfo()}, nil
+}
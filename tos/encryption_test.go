@@ -0,0 +1,54 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketEncryptionV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	rule := BucketEncryptionRule{ApplyServerSideEncryptionByDefault: ApplyServerSideEncryptionByDefault{
+		SSEAlgorithm: enum.SSEAlgorithmKMS, KMSMasterKeyID: "trn:kms:cn-beijing:2100000001:keyrings/r/keys/k"}}
+	_, err := client.PutBucketEncryptionV2(context.Background(), &PutBucketEncryptionV2Input{Bucket: "bucket",
+		Rule: rule})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "encryption")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Rule":{"ApplyServerSideEncryptionByDefault":{"SSEAlgorithm":"kms",
+"KMSMasterKeyID":"trn:kms:cn-beijing:2100000001:keyrings/r/keys/k"}}}`, string(data))
+
+	require.NotEmpty(t, req.Header.Get(HeaderContentMD5))
+
+	transport.respond(http.StatusOK, `{"Rule":{"ApplyServerSideEncryptionByDefault":{"SSEAlgorithm":"AES256"}}}`)
+	out, err := client.GetBucketEncryptionV2(context.Background(), &GetBucketEncryptionV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, BucketEncryptionRule{ApplyServerSideEncryptionByDefault: ApplyServerSideEncryptionByDefault{
+		SSEAlgorithm: enum.SSEAlgorithmAES256}}, out.Rule)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "encryption")
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketEncryptionV2(context.Background(), &DeleteBucketEncryptionV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+
+	for _, sse := range []ApplyServerSideEncryptionByDefault{
+		{},
+		{SSEAlgorithm: "SM4"},
+		{SSEAlgorithm: enum.SSEAlgorithmAES256, KMSMasterKeyID: "key"},
+	} {
+		_, err = client.PutBucketEncryptionV2(context.Background(), &PutBucketEncryptionV2Input{Bucket: "bucket",
+			Rule: BucketEncryptionRule{ApplyServerSideEncryptionByDefault: sse}})
+		require.NotNil(t, err)
+	}
+	require.Len(t, transport.requests, 3)
+}
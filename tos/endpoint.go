@@ -0,0 +1,68 @@
+package tos
+
+// ResolvedEndpoint where requests of a bucket are sent
+type ResolvedEndpoint struct {
+	Scheme    string // "http" or "https"
+	Host      string // required, e.g. tos-cn-beijing.volces.com or 10.0.0.1:8080
+	PathStyle bool   // put bucket in path instead of host, e.g. for IP hosts
+
+	// SigningRegion region to sign requests with, the Region of client is used if empty.
+	// It only takes effect when requests are signed by SignV4.
+	SigningRegion string
+}
+
+// EndpointResolver route requests of each bucket, set by WithEndpointResolver
+type EndpointResolver interface {
+	// ResolveEndpoint return endpoint of the bucket, bucket is empty for requests not on a bucket, e.g. ListBuckets.
+	// region is the Region of client. Return nil to use the endpoint of client.
+	ResolveEndpoint(region, bucket string) (*ResolvedEndpoint, error)
+}
+
+// EndpointResolverFunc adapter to use a function as EndpointResolver
+type EndpointResolverFunc func(region, bucket string) (*ResolvedEndpoint, error)
+
+// ResolveEndpoint implements EndpointResolver
+func (f EndpointResolverFunc) ResolveEndpoint(region, bucket string) (*ResolvedEndpoint, error) {
+	return f(region, bucket)
+}
+
+// ParseEndpoint parse an endpoint like the one passed to NewClientV2, unix socket endpoints are not supported.
+func ParseEndpoint(endpoint string) *ResolvedEndpoint {
+	scheme, host, mode := schemeHost(endpoint)
+	return &ResolvedEndpoint{Scheme: scheme, Host: host, PathStyle: mode == urlModePath}
+}
+
+// resolveEndpoint route rb with cli.endpointResolver
+func (cli *Client) resolveEndpoint(rb *requestBuilder) {
+	endpoint, err := cli.endpointResolver.ResolveEndpoint(cli.config.Region, rb.Bucket)
+	if err != nil {
+		rb.err = newTosClientError("tos: resolve endpoint failed, "+err.Error(), err)
+		return
+	}
+	if endpoint == nil {
+		return
+	}
+	if len(endpoint.Host) == 0 {
+		rb.err = newTosClientError("tos: resolved endpoint has empty host", nil)
+		return
+	}
+	route(rb, endpoint, cli.config.Region)
+}
+
+// route send rb to endpoint, region is the region of client
+func route(rb *requestBuilder, endpoint *ResolvedEndpoint, region string) {
+	rb.Scheme, rb.Host, rb.URLMode = endpoint.Scheme, endpoint.Host, urlModeDefault
+	if len(rb.Scheme) == 0 {
+		rb.Scheme = "http"
+	}
+	if endpoint.PathStyle {
+		rb.URLMode = urlModePath
+	}
+	if len(endpoint.SigningRegion) > 0 && endpoint.SigningRegion != region {
+		if sv, ok := rb.Signer.(*SignV4); ok {
+			signer := *sv
+			signer.region = endpoint.SigningRegion
+			rb.Signer = &signer
+		}
+	}
+}
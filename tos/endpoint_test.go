@@ -0,0 +1,96 @@
+package tos
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointResolver(t *testing.T) {
+	resolver := EndpointResolverFunc(func(region, bucket string) (*ResolvedEndpoint, error) {
+		switch bucket {
+		case "private":
+			return &ResolvedEndpoint{Scheme: "http", Host: "10.0.0.1:8080", PathStyle: true}, nil
+		case "shanghai":
+			endpoint := ParseEndpoint("https://tos-cn-shanghai.volces.com")
+			endpoint.SigningRegion = "cn-shanghai"
+			return endpoint, nil
+		case "broken":
+			return nil, errors.New("unknown bucket")
+		}
+		return nil, nil
+	})
+	transport := &recordTransport{res: newRecordResponse(http.StatusOK, "")}
+	client, err := NewClientV2("https://tos-cn-beijing.volces.com", WithRegion("test-region"),
+		WithCredentials(NewStaticCredentials("ak", "sk")), WithTransport(transport), WithEndpointResolver(resolver))
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	_, err = client.HeadBucket(ctx, &HeadBucketInput{Bucket: "private"})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, "http://10.0.0.1:8080/private", req.URL())
+
+	_, err = client.HeadBucket(ctx, &HeadBucketInput{Bucket: "shanghai"})
+	require.Nil(t, err)
+	req = transport.requests[1]
+	require.Equal(t, "https://shanghai.tos-cn-shanghai.volces.com/", req.URL())
+	require.True(t, strings.Contains(req.Header.Get(authorization), "/cn-shanghai/"))
+
+	_, err = client.HeadBucket(ctx, &HeadBucketInput{Bucket: "other"})
+	require.Nil(t, err)
+	req = transport.requests[2]
+	require.Equal(t, "https://other.tos-cn-beijing.volces.com/", req.URL())
+	require.True(t, strings.Contains(req.Header.Get(authorization), "/test-region/"))
+
+	_, err = client.HeadBucket(ctx, &HeadBucketInput{Bucket: "broken"})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unknown bucket")
+	require.Len(t, transport.requests, 3)
+}
+
+func TestSchemeHostIPLiteral(t *testing.T) {
+	cases := []struct {
+		endpoint, scheme, host string
+		mode                   urlMode
+	}{
+		{"https://tos-cn-beijing.volces.com", "https", "tos-cn-beijing.volces.com", urlModeDefault},
+		{"localhost:8080", "http", "localhost:8080", urlModeDefault},
+		{"10.0.0.1", "http", "10.0.0.1", urlModePath},
+		{"http://10.0.0.1:8080", "http", "10.0.0.1:8080", urlModePath},
+		{"https://[::1]:8080", "https", "[::1]:8080", urlModePath},
+		{"https://[fd00::1]", "https", "[fd00::1]", urlModePath},
+		{"fd00::1", "http", "[fd00::1]", urlModePath},
+	}
+	for _, c := range cases {
+		scheme, host, mode := schemeHost(c.endpoint)
+		require.Equal(t, c.scheme, scheme, c.endpoint)
+		require.Equal(t, c.host, host, c.endpoint)
+		require.Equal(t, c.mode, mode, c.endpoint)
+	}
+
+	rb := &requestBuilder{Scheme: "https", Host: "[::1]:8080", URLMode: urlModePath, Bucket: "bucket", Object: "key"}
+	require.Equal(t, "https://[::1]:8080/bucket/key", rb.build(http.MethodGet, nil).URL())
+}
+
+func TestIPv4Only(t *testing.T) {
+	var network string
+	dialer := &TimeoutDialer{IPv4Only: true, DialContextFunc: func(ctx context.Context, n, address string) (net.Conn, error) {
+		network = n
+		return nil, errors.New("unreachable")
+	}}
+	_, _ = dialer.DialContext(context.Background(), "tcp", "localhost:80")
+	require.Equal(t, "tcp4", network)
+
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithIPv4Only(true))
+	require.Nil(t, err)
+	require.True(t, client.config.TransportConfig.IPv4Only)
+	client, err = NewClientV2("tos-cn-beijing.volces.com")
+	require.Nil(t, err)
+	require.False(t, client.config.TransportConfig.IPv4Only)
+}
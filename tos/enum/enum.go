@@ -17,6 +17,23 @@ type StorageClassType string
 const (
 	StorageClassStandard StorageClassType = "STANDARD"
 	StorageClassIa       StorageClassType = "IA"
+	// StorageClassArchiveFr archive storage with fast retrieval, objects must be restored by RestoreObjectV2 before read
+	StorageClassArchiveFr StorageClassType = "ARCHIVE_FR"
+	// StorageClassArchive archive storage, objects must be restored by RestoreObjectV2 before read
+	StorageClassArchive StorageClassType = "ARCHIVE"
+	// StorageClassColdArchive cold archive storage, objects must be restored by RestoreObjectV2 before read
+	StorageClassColdArchive StorageClassType = "COLD_ARCHIVE"
+	// StorageClassIntelligentTiering objects move between access tiers by access pattern, see AccessTierType
+	StorageClassIntelligentTiering StorageClassType = "INTELLIGENT_TIERING"
+)
+
+// TierType the retrieval speed of RestoreObjectV2, faster tiers cost more
+type TierType string
+
+const (
+	TierExpedited TierType = "Expedited"
+	TierStandard  TierType = "Standard"
+	TierBulk      TierType = "Bulk"
 )
 
 type MetadataDirectiveType string
@@ -29,11 +46,34 @@ const (
 	MetadataDirectiveCopy MetadataDirectiveType = "COPY"
 )
 
+type TaggingDirectiveType string
+
+const (
+	// TaggingDirectiveReplace replace source object tags with Tagging when calling CopyObject
+	TaggingDirectiveReplace TaggingDirectiveType = "REPLACE"
+
+	// TaggingDirectiveCopy copy source object tags when calling CopyObject
+	TaggingDirectiveCopy TaggingDirectiveType = "COPY"
+)
+
+// BucketType the namespace of a bucket
+type BucketType string
+
+const (
+	// BucketTypeFNS flat namespace, directories are simulated by keys ending with '/'
+	BucketTypeFNS BucketType = "fns"
+	// BucketTypeHNS hierarchical namespace, directories are real, and renaming and deleting them are atomic
+	BucketTypeHNS BucketType = "hns"
+)
+
+// AzRedundancyType the data redundancy of a bucket, returned by HeadBucket
 type AzRedundancyType string
 
 const (
+	// AzRedundancySingleAz data is stored in a single availability zone
 	AzRedundancySingleAz AzRedundancyType = "single-az"
-	AzRedundancyMultiAz  AzRedundancyType = "multi-az"
+	// AzRedundancyMultiAz data is stored across multiple availability zones
+	AzRedundancyMultiAz AzRedundancyType = "multi-az"
 )
 
 type PermissionType string
@@ -89,6 +129,7 @@ const (
 	UploadEventUploadPartAborted              UploadEventType = 5 // The task needs to be interrupted in case of 403, 404, 405 errors
 	UploadEventCompleteMultipartUploadSucceed UploadEventType = 6
 	UploadEventCompleteMultipartUploadFailed  UploadEventType = 7
+	UploadEventHeartbeat                      UploadEventType = 8 // sent periodically even if no bytes move, see UploadFileInput.HeartbeatInterval
 )
 
 type DownloadEventType int
@@ -102,3 +143,172 @@ const (
 	DownloadEventRenameTempFileSucceed DownloadEventType = 6
 	DownloadEventRenameTempFileFailed  DownloadEventType = 7
 )
+
+// SelectEventType the type of events streamed by SelectObjectContent
+type SelectEventType int
+
+const (
+	SelectEventRecords  SelectEventType = 1 // a chunk of result records
+	SelectEventProgress SelectEventType = 2 // bytes scanned so far, sent periodically if RequestProgress is enabled
+	SelectEventEnd      SelectEventType = 3 // the query finished, with statistics
+)
+
+// FileHeaderInfoType how the first line of CSV input is handled by SelectObjectContent
+type FileHeaderInfoType string
+
+const (
+	FileHeaderInfoUse    FileHeaderInfoType = "Use"    // the first line is header, columns can be referenced by name
+	FileHeaderInfoIgnore FileHeaderInfoType = "Ignore" // the first line is header, but ignored
+	FileHeaderInfoNone   FileHeaderInfoType = "None"   // there is no header
+)
+
+// SelectJSONType the layout of JSON input of SelectObjectContent
+type SelectJSONType string
+
+const (
+	SelectJSONDocument SelectJSONType = "DOCUMENT" // a single JSON document
+	SelectJSONLines    SelectJSONType = "LINES"    // one JSON object each line
+)
+
+// CompressionType compression of the object queried by SelectObjectContent
+type CompressionType string
+
+const (
+	CompressionNone CompressionType = "None"
+	CompressionGzip CompressionType = "GZIP"
+)
+
+// FetchTaskStateType the state of a task created by PutFetchTaskV2
+type FetchTaskStateType string
+
+const (
+	FetchTaskStateRunning FetchTaskStateType = "StateRunning"
+	FetchTaskStateSucceed FetchTaskStateType = "StateSucceed"
+	FetchTaskStateFailed  FetchTaskStateType = "StateFailed"
+	// FetchTaskStateExpired the task is not found, it's expired or never created
+	FetchTaskStateExpired FetchTaskStateType = "StateExpired"
+)
+
+// ObjectLockModeType the retention mode of Object Lock
+type ObjectLockModeType string
+
+const (
+	// ObjectLockModeGovernance retention can be shortened or removed by users with special permission,
+	// see BypassGovernanceRetention of PutObjectRetentionInput
+	ObjectLockModeGovernance ObjectLockModeType = "GOVERNANCE"
+	// ObjectLockModeCompliance retention can't be shortened or removed by anyone, including the root account
+	ObjectLockModeCompliance ObjectLockModeType = "COMPLIANCE"
+)
+
+// LegalHoldStatusType a legal hold prevents an object version from being deleted until it's removed,
+// regardless of retention
+type LegalHoldStatusType string
+
+const (
+	LegalHoldStatusOn  LegalHoldStatusType = "ON"
+	LegalHoldStatusOff LegalHoldStatusType = "OFF"
+)
+
+type ObjectDiffType string
+
+const (
+	ObjectDiffMissingInSource      ObjectDiffType = "MissingInSource"
+	ObjectDiffMissingInDestination ObjectDiffType = "MissingInDestination"
+	ObjectDiffSize                 ObjectDiffType = "Size"
+	ObjectDiffChecksum             ObjectDiffType = "Checksum" // CRC64, or ETag if CRC64 is absent
+	ObjectDiffMeta                 ObjectDiffType = "Meta"
+)
+
+type LifecycleStatusType string
+
+const (
+	LifecycleStatusEnabled  LifecycleStatusType = "Enabled"
+	LifecycleStatusDisabled LifecycleStatusType = "Disabled"
+)
+
+type PolicyEffectType string
+
+const (
+	PolicyEffectAllow PolicyEffectType = "Allow"
+	PolicyEffectDeny  PolicyEffectType = "Deny"
+)
+
+// NotificationEventType events of objects delivered by bucket notification, "*" matches all events of the kind
+type NotificationEventType string
+
+const (
+	NotificationEventObjectCreatedAll                     NotificationEventType = "tos:ObjectCreated:*"
+	NotificationEventObjectCreatedPut                     NotificationEventType = "tos:ObjectCreated:Put"
+	NotificationEventObjectCreatedPost                    NotificationEventType = "tos:ObjectCreated:Post"
+	NotificationEventObjectCreatedCopy                    NotificationEventType = "tos:ObjectCreated:Copy"
+	NotificationEventObjectCreatedCompleteMultipartUpload NotificationEventType = "tos:ObjectCreated:CompleteMultipartUpload"
+	NotificationEventObjectRemovedAll                     NotificationEventType = "tos:ObjectRemoved:*"
+	NotificationEventObjectRemovedDelete                  NotificationEventType = "tos:ObjectRemoved:Delete"
+	NotificationEventObjectRemovedDeleteMarkerCreated     NotificationEventType = "tos:ObjectRemoved:DeleteMarkerCreated"
+)
+
+// SSEAlgorithmType algorithm of server side encryption managed by TOS
+type SSEAlgorithmType string
+
+const (
+	SSEAlgorithmAES256 SSEAlgorithmType = "AES256" // SSE-TOS, keys are managed by TOS
+	SSEAlgorithmKMS    SSEAlgorithmType = "kms"    // SSE-KMS, keys are managed by KMS
+)
+
+// MirrorBackRedirectType how objects are fetched from the source of mirror-back
+type MirrorBackRedirectType string
+
+const (
+	MirrorBackRedirectMirror MirrorBackRedirectType = "Mirror" // fetch and return the object to the requester
+	MirrorBackRedirectAsync  MirrorBackRedirectType = "Async"  // fetch the object in background, the request fails
+)
+
+// CustomDomainProtocolType the protocol served on a custom domain
+type CustomDomainProtocolType string
+
+const (
+	CustomDomainProtocolTos CustomDomainProtocolType = "tos"
+	CustomDomainProtocolS3  CustomDomainProtocolType = "s3"
+)
+
+// AccessMonitorStatusType whether last access time of objects is recorded, which intelligent tiering depends on
+type AccessMonitorStatusType string
+
+const (
+	AccessMonitorStatusEnabled  AccessMonitorStatusType = "Enabled"
+	AccessMonitorStatusDisabled AccessMonitorStatusType = "Disabled"
+)
+
+// IntelligentTieringStatusType whether intelligent tiering is enabled for a bucket
+type IntelligentTieringStatusType string
+
+const (
+	IntelligentTieringStatusEnabled  IntelligentTieringStatusType = "Enabled"
+	IntelligentTieringStatusDisabled IntelligentTieringStatusType = "Disabled"
+)
+
+// AccessTierType the access tier of objects in StorageClassIntelligentTiering
+type AccessTierType string
+
+const (
+	AccessTierFrequent   AccessTierType = "FREQUENT"
+	AccessTierInfrequent AccessTierType = "INFREQUENT"
+	AccessTierArchiveFr  AccessTierType = "ARCHIVE_FR"
+)
+
+// PayerType who pays for requests and traffic of a bucket
+type PayerType string
+
+const (
+	PayerBucketOwner PayerType = "BucketOwner"
+	// PayerRequester requesters pay, they must set RequestPayer of inputs to "requester"
+	PayerRequester PayerType = "Requester"
+)
+
+// AccelerateStatusType whether transfer acceleration of a bucket is enabled
+type AccelerateStatusType string
+
+const (
+	AccelerateStatusEnabled   AccelerateStatusType = "Enabled"
+	AccelerateStatusSuspended AccelerateStatusType = "Suspended"
+)
@@ -28,7 +28,8 @@ func newTosClientError(message string, cause error) *TosClientError {
 
 type TosClientError struct {
 	TosError
-	Cause error
+	Cause         error
+	OperationName string // e.g. PutObject, empty if the error occurs before sending request
 }
 
 // try to unmarshal server error from response
@@ -47,9 +48,13 @@ func newTosServerError(res *Response) error {
 			RequestInfo: res.RequestInfo(),
 		}
 	}
+	info := res.RequestInfo()
+	if len(se.EC) > 0 {
+		info.EC = se.EC
+	}
 	return &TosServerError{
 		TosError:    TosError{se.Message},
-		RequestInfo: res.RequestInfo(),
+		RequestInfo: info,
 		Code:        se.Code,
 		HostID:      se.HostID,
 		Resource:    se.Resource,
@@ -63,6 +68,8 @@ type TosServerError struct {
 	Code        string `json:"Code,omitempty"`
 	HostID      string `json:"HostID,omitempty"`
 	Resource    string `json:"Resource,omitempty"`
+	// OperationName e.g. PutObject, see Operation* constants
+	OperationName string `json:"OperationName,omitempty"`
 }
 
 type Error struct {
@@ -72,6 +79,7 @@ type Error struct {
 	RequestID  string `json:"RequestId,omitempty"`
 	HostID     string `json:"HostId,omitempty"`
 	Resource   string `json:"Resource,omitempty"`
+	EC         string `json:"EC,omitempty"`
 }
 
 func (e *Error) Error() string {
@@ -105,6 +113,14 @@ func StatusCode(err error) int {
 	return 0
 }
 
+// EC return detailed error code of the server saved in TosServerError
+func EC(err error) string {
+	if er, ok := err.(*TosServerError); ok {
+		return er.EC
+	}
+	return ""
+}
+
 func RequestID(err error) string {
 	switch ev := err.(type) {
 	case *TosServerError:
@@ -193,14 +209,29 @@ func checkError(res *Response, okCode int, okCodes ...int) error {
 	}
 }
 
-// StatusCodeClassifier classifies Errors.
+type RetryAction int
+
+const (
+	NoRetry RetryAction = iota
+	Retry
+)
+
+// Classifier decides whether a failed request should be retried.
+// The error is returned by the last attempt, it may be a TosServerError, or a transport error such as timeout.
+type Classifier interface {
+	Classify(error) RetryAction
+}
+
+// StatusCodeClassifier classifies Errors of idempotent operations.
 // If the error is nil, it returns NoRetry;
 // if the error is TimeoutException or can be interpreted as TosServerError, and the StatusCode is 5xx or 529, it returns Retry;
+// if the error is a transient network error, e.g. connection reset, unexpected EOF, dial or TLS handshake timeout,
+// and temporary DNS errors, it returns Retry, unless it's caused by the context of caller;
 // otherwise, it returns NoRetry.
 type StatusCodeClassifier struct{}
 
-// Classify implements the classifier interface.
-func (classifier StatusCodeClassifier) Classify(err error) retryAction {
+// Classify implements the Classifier interface.
+func (classifier StatusCodeClassifier) Classify(err error) RetryAction {
 	if err == nil {
 		return NoRetry
 	}
@@ -214,7 +245,9 @@ func (classifier StatusCodeClassifier) Classify(err error) retryAction {
 	if ok && t.Timeout() {
 		return Retry
 	}
-
+	if isRetryableNetworkError(err) {
+		return Retry
+	}
 	return NoRetry
 }
 
@@ -224,8 +257,8 @@ func (classifier StatusCodeClassifier) Classify(err error) retryAction {
 // otherwise, it returns NoRetry.
 type ServerErrorClassifier struct{}
 
-// Classify implements the classifier interface.
-func (classifier ServerErrorClassifier) Classify(err error) retryAction {
+// Classify implements the Classifier interface.
+func (classifier ServerErrorClassifier) Classify(err error) RetryAction {
 	if err == nil {
 		return NoRetry
 	}
@@ -240,7 +273,7 @@ func (classifier ServerErrorClassifier) Classify(err error) retryAction {
 
 type NoRetryClassifier struct{}
 
-// Classify implements the classifier interface.
-func (classifier NoRetryClassifier) Classify(_ error) retryAction {
+// Classify implements the Classifier interface.
+func (classifier NoRetryClassifier) Classify(_ error) RetryAction {
 	return NoRetry
 }
@@ -2,6 +2,9 @@ package tos
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -108,3 +111,122 @@ func TestServerErrorRetrierBase(t *testing.T) {
 		require.Equal(t, tt.expect, *count)
 	}
 }
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := exponentialBackoff(4, 10*time.Millisecond)
+	require.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 80 * time.Millisecond}, backoff)
+
+	require.Len(t, exponentialBackoff(0, time.Second), 0)
+	require.Len(t, exponentialBackoff(-1, time.Second), 0)
+
+	// never overflow
+	for _, b := range exponentialBackoff(100, time.Second) {
+		require.Greater(t, int64(b), int64(0))
+	}
+}
+
+func TestRetryerCalcSleep(t *testing.T) {
+	r := newRetryer(exponentialBackoff(10, 100*time.Millisecond))
+	require.Equal(t, 100*time.Millisecond, r.calcSleep(0))
+	require.Equal(t, 200*time.Millisecond, r.calcSleep(1))
+
+	r.SetMaxBackoff(time.Second)
+	require.Equal(t, 800*time.Millisecond, r.calcSleep(3))
+	require.Equal(t, time.Second, r.calcSleep(9))
+
+	r.SetJitter(0.5)
+	for i := 0; i < 100; i++ {
+		sleep := r.calcSleep(1)
+		require.True(t, sleep >= 100*time.Millisecond && sleep <= 300*time.Millisecond)
+		require.True(t, r.calcSleep(9) <= time.Second)
+	}
+}
+
+func TestAdaptiveRetryBudget(t *testing.T) {
+	r := newRetryer(exponentialBackoff(3, time.Millisecond))
+	// enough for 2 retries only
+	r.SetBudget(newRetryBudget(2 * retryBudgetRetryCost))
+
+	work, count := genWork([]error{TosStatus500, TosStatus500, TosStatus500})
+	err := r.Run(context.Background(), work, StatusCodeClassifier{})
+	require.Equal(t, TosStatus500, err)
+	require.Equal(t, 3, *count)
+
+	// budget is exhausted, fail fast
+	work, count = genWork([]error{TosStatus500})
+	err = r.Run(context.Background(), work, StatusCodeClassifier{})
+	require.Equal(t, TosStatus500, err)
+	require.Equal(t, 1, *count)
+
+	// successes replenish the budget
+	for i := 0; i < retryBudgetRetryCost; i++ {
+		work, _ = genWork(nil)
+		require.Nil(t, r.Run(context.Background(), work, StatusCodeClassifier{}))
+	}
+	work, count = genWork([]error{TosStatus500})
+	require.Nil(t, r.Run(context.Background(), work, StatusCodeClassifier{}))
+	require.Equal(t, 2, *count)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("abc", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("-1", now))
+	require.Equal(t, 3*time.Second, parseRetryAfter(" 3 ", now))
+	require.Equal(t, 10*time.Second, parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now))
+	require.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-time.Second).Format(http.TimeFormat), now))
+}
+
+func TestRetryAfter(t *testing.T) {
+	throttled := &TosServerError{RequestInfo: RequestInfo{
+		StatusCode: http.StatusTooManyRequests,
+		RequestID:  "id",
+		Header:     http.Header{HeaderRetryAfter: []string{"1"}},
+	}}
+	r := newRetryer(exponentialBackoff(1, time.Hour))
+	var events []*ThrottleEvent
+	r.SetThrottleCallback(func(event *ThrottleEvent) { events = append(events, event) })
+
+	// Retry-After takes precedence over the back-off
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	work, count := genWork([]error{throttled})
+	start := time.Now()
+	require.Nil(t, r.Run(ctx, work, StatusCodeClassifier{}))
+	require.Equal(t, 2, *count)
+	require.True(t, time.Since(start) >= time.Second)
+	require.Len(t, events, 1)
+	require.Equal(t, http.StatusTooManyRequests, events[0].StatusCode)
+	require.Equal(t, "id", events[0].RequestID)
+	require.Equal(t, 1, events[0].Attempt)
+	require.Equal(t, time.Second, events[0].RetryAfter)
+	require.Equal(t, time.Second, events[0].Backoff)
+
+	// not throttled, no callback
+	work, _ = genWork([]error{TosStatus500})
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.Equal(t, TosStatus500, r.Run(ctx, work, StatusCodeClassifier{}))
+	require.Len(t, events, 1)
+}
+
+func TestRequestInfoEC(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderRequestID, "request")
+	header.Set(HeaderEC, "0000-00000000")
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&recordTransport{
+		res: &Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader(""))}}))
+	require.Nil(t, err)
+	head, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, "request", head.RequestID)
+	require.Equal(t, "0000-00000000", head.EC)
+
+	// EC in body takes precedence over the header
+	err = newTosServerError(&Response{StatusCode: http.StatusForbidden, Header: header,
+		Body: ioutil.NopCloser(strings.NewReader(`{"Code":"AccessDenied","EC":"0003-00000001"}`))})
+	require.Equal(t, "0003-00000001", EC(err))
+	require.Equal(t, "request", RequestID(err))
+	require.Equal(t, "", EC(ClientTimeout))
+}
@@ -0,0 +1,47 @@
+package tos
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// EscapeKey escape an object key for the path of URL, it's exactly how the SDK escapes keys when building URLs,
+// signing requests and generating pre-signed URLs, in both path-style and virtual-host-style URLs.
+//
+// All bytes except unreserved characters (A-Z, a-z, 0-9, '-', '_', '.', '~') and '/' are percent-encoded,
+// e.g. "a b+c?.txt" is escaped to "a%20b%2Bc%3F.txt", and non-ASCII characters are encoded byte by byte in UTF-8.
+func EscapeKey(key string) string {
+	return string(URIEncode(key, false))
+}
+
+// escapePath escape path of a request the same way as signing does
+func escapePath(path string) string {
+	return string(URIEncode(path, false))
+}
+
+// escapeQuery encode query sorted by key the same way as signing does,
+// e.g. space is encoded to "%20" instead of "+" as url.Values.Encode does
+func escapeQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var buf strings.Builder
+	for _, k := range keys {
+		key := URIEncode(k, true)
+		for _, v := range query[k] {
+			if buf.Len() > 0 {
+				buf.WriteByte('&')
+			}
+			buf.Write(key)
+			buf.WriteByte('=')
+			buf.Write(URIEncode(v, true))
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,73 @@
+package tos
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var exoticKeys = []string{"a b.txt", "a+b", "a?b#c", "中文/文件.txt", "a/%2F/b", "~!@$&'()*,;=:"}
+
+func TestEscapeKey(t *testing.T) {
+	require.Equal(t, "a%20b%2Bc%3F.txt", EscapeKey("a b+c?.txt"))
+	require.Equal(t, "dir/%E4%B8%AD", EscapeKey("dir/中"))
+	for _, key := range exoticKeys {
+		unescaped, err := url.PathUnescape(EscapeKey(key))
+		require.Nil(t, err)
+		require.Equal(t, key, unescaped)
+	}
+	require.Equal(t, "/bucket/a%2Fb%20c%2Bd", copySource("bucket", "a/b c+d", ""))
+}
+
+func TestEscapeKeyOnWire(t *testing.T) {
+	var (
+		paths   []string
+		queries []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		queries = append(queries, r.URL.RawQuery)
+	}))
+	defer server.Close()
+	dial := func(ctx context.Context, network, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	// path style with IP endpoint, virtual-host style with domain endpoint
+	for _, endpoint := range []string{server.URL, "http://tos.example.com"} {
+		client, err := NewClientV2(endpoint, WithDialContext(dial), WithRegion("test-region"),
+			WithCredentials(NewStaticCredentials("ak", "sk")))
+		require.Nil(t, err)
+		paths, queries = nil, nil
+		for _, key := range exoticKeys {
+			_, err = client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: key, VersionID: "v 1+"})
+			require.Nil(t, err)
+		}
+		for i, key := range exoticKeys {
+			expected := "/" + EscapeKey(key)
+			if strings.HasPrefix(endpoint, server.URL) {
+				expected = "/bucket" + expected
+			}
+			// what's sent is what's signed
+			require.Equal(t, expected, paths[i])
+			require.Equal(t, string(encodePath(unescape(t, expected))), paths[i])
+			require.Equal(t, "versionId=v%201%2B", queries[i])
+		}
+
+		presigned, err := client.PreSignedURL(&PreSignedURLInput{HTTPMethod: "GET", Bucket: "bucket", Key: "a b+c"})
+		require.Nil(t, err)
+		require.Contains(t, presigned.SignedUrl, "/a%20b%2Bc?")
+	}
+}
+
+func unescape(t *testing.T, path string) string {
+	unescaped, err := url.PathUnescape(path)
+	require.Nil(t, err)
+	return unescaped
+}
@@ -0,0 +1,32 @@
+package tos
+
+import (
+	"context"
+)
+
+// DoesObjectExist report whether the object, or the version input.VersionID, exists by HeadObjectV2.
+// It returns false and nil error if the object or the bucket is not found (404), including that the latest version
+// is a delete marker. Other errors are returned as is, e.g. 403 if the caller has no permission to read the object,
+// which doesn't tell whether the object exists.
+func (cli *ClientV2) DoesObjectExist(ctx context.Context, input *HeadObjectV2Input, options ...Option) (bool, error) {
+	_, err := cli.HeadObjectV2(ctx, input, options...)
+	return existence(err)
+}
+
+// DoesBucketExist report whether the bucket exists by HeadBucket.
+// It returns false and nil error if the bucket is not found (404). Other errors are returned as is,
+// e.g. 403 if the bucket is owned by others or the caller has no permission.
+func (cli *ClientV2) DoesBucketExist(ctx context.Context, input *HeadBucketInput, options ...Option) (bool, error) {
+	_, err := cli.HeadBucket(ctx, input, options...)
+	return existence(err)
+}
+
+func existence(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,48 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoesObjectExist(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "", WithMaxRetryCount(0))
+	input := &HeadObjectV2Input{Bucket: "bucket", Key: "key", VersionID: "v1"}
+
+	exist, err := client.DoesObjectExist(context.Background(), input)
+	require.Nil(t, err)
+	require.True(t, exist)
+	require.Equal(t, http.MethodHead, transport.requests[0].Method)
+	require.Equal(t, "v1", transport.requests[0].Query.Get("versionId"))
+
+	transport.respond(http.StatusNotFound, "")
+	exist, err = client.DoesObjectExist(context.Background(), input)
+	require.Nil(t, err)
+	require.False(t, exist)
+
+	transport.respond(http.StatusForbidden, "")
+	exist, err = client.DoesObjectExist(context.Background(), input)
+	require.True(t, IsAccessDenied(err))
+	require.False(t, exist)
+
+	transport.res, transport.err = nil, newTosClientError("tos: connection refused", nil)
+	_, err = client.DoesObjectExist(context.Background(), input)
+	require.NotNil(t, err)
+}
+
+func TestDoesBucketExist(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "", WithMaxRetryCount(0))
+
+	exist, err := client.DoesBucketExist(context.Background(), &HeadBucketInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.True(t, exist)
+	require.Equal(t, OperationHeadBucket, transport.requests[0].OperationName)
+
+	transport.respond(http.StatusNotFound, "")
+	exist, err = client.DoesBucketExist(context.Background(), &HeadBucketInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.False(t, exist)
+}
@@ -0,0 +1,33 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectExpirationInfo(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderExpiration, `expiry-date="Fri, 19 Apr 2024 00:00:00 GMT", rule-id="rule,1"`)
+	header.Set(HeaderRestore, `ongoing-request="true"`)
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	expected := &ExpirationInfo{RuleID: "rule,1", ExpiryDate: time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC)}
+	head, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, expected, head.ExpirationInfo)
+	require.Equal(t, &RestoreInfo{OngoingRequest: true}, head.RestoreInfo)
+
+	get, err := client.GetObjectV2(context.Background(), &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	defer get.Content.Close()
+	require.Equal(t, expected, get.ExpirationInfo)
+	require.Equal(t, &RestoreInfo{OngoingRequest: true}, get.RestoreInfo)
+
+	require.Nil(t, parseExpirationInfo(""))
+	require.Equal(t, &ExpirationInfo{RuleID: "rule1"}, parseExpirationInfo(`rule-id="rule1"`))
+}
@@ -6,13 +6,6 @@ import (
 	"net/http"
 )
 
-//const (
-//	StateFailed  = "StateFailed"
-//	StateSucceed = "StateSucceed"
-//	StateExpired = "StateExpired"
-//	StateRunning = "StateRunning"
-//)
-
 type FetchObjectInput struct {
 	URL           string `json:"URL,omitempty"`           // required
 	Key           string `json:"Key,omitempty"`           // required
@@ -50,6 +43,7 @@ func (bkt *Bucket) FetchObject(ctx context.Context, input *FetchObjectInput, opt
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, input.Key, options...).
+		WithOperation(OperationFetchObject).
 		WithQuery("fetch", "").
 		WithHeader(HeaderContentMD5, contentMD5).
 		Request(ctx, http.MethodPost, bytes.NewReader(data), bkt.client.roundTripper(http.StatusOK))
@@ -66,62 +60,113 @@ func (bkt *Bucket) FetchObject(ctx context.Context, input *FetchObjectInput, opt
 	return &out, nil
 }
 
-//type PutAsyncFetchTaskInput struct {
-//	URL           string `json:"URL,omitempty"`           // required
-//	Key           string `json:"Object,omitempty"`        // required
-//	IgnoreSameKey bool   `json:"IgnoreSameKey,omitempty"` // optional, default value is false
-//	ContentMD5    string `json:"ContentMD5,omitempty"`    // hex-encoded md5, optional
-//}
-//
-//type PutAsyncFetchTaskOutput struct {
-//	RequestInfo `json:"-"`
-//	TaskID      string `json:"TaskId,omitempty"`
-//}
-//
-//func (bkt *Bucket) PutAsyncFetchTask(ctx context.Context, input *FetchObjectInput, options ...Option) (*PutAsyncFetchTaskOutput, error) {
-//	if err := isValidKey(input.Key); err != nil {
-//		return nil, err
-//	}
-//
-//	data, contentMD5, err := marshalInput("PutAsyncFetchTaskInput", input)
-//	if err != nil {
-//		return nil, err
-//	}
-//
-//	res, err := bkt.client.newBuilder(bkt.name, "", options...).
-//		WithQuery("fetch-task", "").
-//		WithHeader(HeaderContentMD5, contentMD5).
-//		Request(ctx, http.MethodPost, bytes.NewReader(data), bkt.client.roundTripper(http.StatusOK))
-//	if err != nil {
-//		return nil, err
-//	}
-//
-//	out := PutAsyncFetchTaskOutput{RequestInfo: res.RequestInfo()}
-//	if err = marshalOutput(out.RequestID, res.Body, &out); err != nil {
-//		return nil, err
-//	}
-//	return &out, nil
-//}
+// FetchObjectV2 fetch an object from input.URL into input.Key on the server side, so the content isn't proxied
+// by the client. It blocks until the object is fetched, use PutFetchTaskV2 for large objects.
+func (cli *ClientV2) FetchObjectV2(ctx context.Context, input *FetchObjectV2Input, options ...Option) (*FetchObjectV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	if len(input.URL) == 0 {
+		return nil, newTosClientError("tos: empty URL of FetchObject", nil)
+	}
+	data, contentMD5, err := marshalInput("FetchObjectV2Input", &fetchObjectInput{
+		URL:           input.URL,
+		IgnoreSameKey: input.IgnoreSameKey,
+		ContentMD5:    input.ContentMD5,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// a retry of a fetch which succeeded fails if the same key is ignored
+	var classifier Classifier = StatusCodeClassifier{}
+	if input.IgnoreSameKey {
+		classifier = NoRetryClassifier{}
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationFetchObject).
+		WithQuery("fetch", "").
+		WithParams(*input).
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, classifier).
+		Request(ctx, http.MethodPost, bytes.NewReader(data), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	out := FetchObjectV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(out.RequestID, res.Body, &out); err != nil {
+		return nil, err
+	}
+	out.VersionID = res.Header.Get(HeaderVersionID)
+	out.SSECAlgorithm = res.Header.Get(HeaderSSECustomerAlgorithm)
+	out.SSECKeyMD5 = res.Header.Get(HeaderSSECustomerKeyMD5)
+	return &out, nil
+}
 
-//type GetAsyncFetchTaskInput struct {
-//	TaskID string `json:"taskID,omitempty"`
-//}
-//
-//type GetAsyncFetchTaskOutput struct {
-//	RequestInfo `json:"-"`
-//	State       string `json:"State,omitempty"`
-//	Cause       string `json:"Cause,omitempty"`
-//}
-//
-//func (bkt *Bucket) GetAsyncFetchTask(ctx context.Context, input *GetAsyncFetchTaskInput, options ...Option) (*GetAsyncFetchTaskOutput, error) {
-//	res, err := bkt.client.newBuilder(bkt.name, "", options...).
-//		WithQuery("fetch-task", "").
-//		WithQuery("task-id", input.TaskID).
-//		Request(ctx, http.MethodGet, nil, bkt.client.roundTripper(http.StatusOK))
-//
-//	out := GetAsyncFetchTaskOutput{RequestInfo: res.RequestInfo()}
-//	if err = marshalOutput(out.RequestID, res.Body, &out); err != nil {
-//		return nil, err
-//	}
-//	return &out, nil
-//}
+type putFetchTaskInput struct {
+	URL           string `json:"URL,omitempty"`
+	Object        string `json:"Object,omitempty"`
+	IgnoreSameKey bool   `json:"IgnoreSameKey,omitempty"`
+	ContentMD5    string `json:"ContentMD5,omitempty"`
+}
+
+// PutFetchTaskV2 create a task fetching an object from input.URL into input.Key asynchronously, poll the task
+// by GetFetchTaskV2 with TaskID of output until it's finished.
+func (cli *ClientV2) PutFetchTaskV2(ctx context.Context, input *PutFetchTaskV2Input, options ...Option) (*PutFetchTaskV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	if len(input.URL) == 0 {
+		return nil, newTosClientError("tos: empty URL of PutFetchTask", nil)
+	}
+	data, contentMD5, err := marshalInput("PutFetchTaskV2Input", &putFetchTaskInput{
+		URL:           input.URL,
+		Object:        input.Key,
+		IgnoreSameKey: input.IgnoreSameKey,
+		ContentMD5:    input.ContentMD5,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// a retry creates another task, only retry if the server failed to create one
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutFetchTask).
+		WithQuery("fetchTask", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, ServerErrorClassifier{}).
+		Request(ctx, http.MethodPost, bytes.NewReader(data), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	out := PutFetchTaskV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(out.RequestID, res.Body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFetchTaskV2 get state of a task created by PutFetchTaskV2
+func (cli *ClientV2) GetFetchTaskV2(ctx context.Context, input *GetFetchTaskV2Input, options ...Option) (*GetFetchTaskV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if len(input.TaskID) == 0 {
+		return nil, newTosClientError("tos: empty task id of GetFetchTask", nil)
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetFetchTask).
+		WithQuery("fetchTask", "").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	out := GetFetchTaskV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(out.RequestID, res.Body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,67 @@
+package tos
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestFetchObjectV2(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderVersionID, "v1")
+	client, transport := newRecordClient(t, http.StatusOK, `{"ETag":"\"abc\""}`)
+	transport.res.Header = header
+
+	output, err := client.FetchObjectV2(context.Background(), &FetchObjectV2Input{Bucket: "bucket", Key: "key",
+		URL: "https://example.com/a.png", IgnoreSameKey: true, StorageClass: enum.StorageClassIa})
+	require.Nil(t, err)
+	require.Equal(t, "v1", output.VersionID)
+	require.Equal(t, `"abc"`, output.ETag)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, OperationFetchObject, req.OperationName)
+	require.Contains(t, req.Query, "fetch")
+	require.Equal(t, string(enum.StorageClassIa), req.Header.Get(HeaderStorageClass))
+	body, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"URL":"https://example.com/a.png","IgnoreSameKey":true}`, string(body))
+
+	_, err = client.FetchObjectV2(context.Background(), &FetchObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 1)
+}
+
+func TestFetchTaskV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"TaskId":"task-1"}`)
+
+	put, err := client.PutFetchTaskV2(context.Background(), &PutFetchTaskV2Input{Bucket: "bucket", Key: "key",
+		URL: "https://example.com/a.png"})
+	require.Nil(t, err)
+	require.Equal(t, "task-1", put.TaskID)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, OperationPutFetchTask, req.OperationName)
+	require.Contains(t, req.Query, "fetchTask")
+	body, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	var in map[string]interface{}
+	require.Nil(t, json.Unmarshal(body, &in))
+	require.Equal(t, "key", in["Object"])
+
+	transport.respond(http.StatusOK, `{"State":"StateFailed","Cause":"404 Not Found"}`)
+	get, err := client.GetFetchTaskV2(context.Background(), &GetFetchTaskV2Input{Bucket: "bucket", TaskID: put.TaskID})
+	require.Nil(t, err)
+	require.Equal(t, enum.FetchTaskStateFailed, get.State)
+	require.Equal(t, "404 Not Found", get.Cause)
+	require.True(t, get.Finished())
+	req = transport.requests[1]
+	require.Equal(t, http.MethodGet, req.Method)
+	require.Equal(t, OperationGetFetchTask, req.OperationName)
+	require.Equal(t, "task-1", req.Query.Get("taskId"))
+	require.Equal(t, "/", req.Path)
+}
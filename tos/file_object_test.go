@@ -0,0 +1,62 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetObjectToFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "get-object-to-file")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	client, transport := newRecordClient(t, http.StatusOK, "content", WithMaxRetryCount(0))
+
+	filePath := filepath.Join(dir, "a", "b", "file")
+	_, err = client.GetObjectToFile(context.Background(), &GetObjectToFileInput{
+		GetObjectV2Input: GetObjectV2Input{Bucket: "bucket", Key: "key"}, FilePath: filePath})
+	require.Nil(t, err)
+	content, err := ioutil.ReadFile(filePath)
+	require.Nil(t, err)
+	require.Equal(t, "content", string(content))
+	entries, err := ioutil.ReadDir(filepath.Dir(filePath))
+	require.Nil(t, err)
+	require.Len(t, entries, 1) // no temp file left
+
+	// the file is untouched if the download fails
+	transport.respond(http.StatusNotFound, "")
+	_, err = client.GetObjectToFile(context.Background(), &GetObjectToFileInput{
+		GetObjectV2Input: GetObjectV2Input{Bucket: "bucket", Key: "key"}, FilePath: filePath})
+	require.True(t, IsNotFound(err))
+	content, err = ioutil.ReadFile(filePath)
+	require.Nil(t, err)
+	require.Equal(t, "content", string(content))
+
+	_, err = client.GetObjectToFile(context.Background(), &GetObjectToFileInput{
+		GetObjectV2Input: GetObjectV2Input{Bucket: "bucket", Key: "key"}, FilePath: dir})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 2)
+}
+
+func TestPutObjectFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "put-object-from-file")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "file")
+	require.Nil(t, ioutil.WriteFile(filePath, []byte("content"), DefaultFilePerm))
+	client, transport := newRecordClient(t, http.StatusOK, "", WithMaxRetryCount(0))
+
+	_, err = client.PutObjectFromFile(context.Background(), &PutObjectFromFileInput{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"}, FilePath: filePath})
+	require.Nil(t, err)
+	require.Equal(t, int64(7), *transport.requests[0].ContentLength)
+
+	_, err = client.PutObjectFromFile(context.Background(), &PutObjectFromFileInput{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"}, FilePath: dir})
+	require.NotNil(t, err)
+}
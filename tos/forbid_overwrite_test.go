@@ -0,0 +1,45 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForbidOverwrite(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{}`)
+
+	_, err := client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"}, Content: strings.NewReader("a")})
+	require.Nil(t, err)
+	require.Empty(t, transport.requests[0].Header.Get(HeaderForbidOverwrite))
+
+	_, err = client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", ForbidOverwrite: true},
+		Content:             strings.NewReader("a")})
+	require.Nil(t, err)
+	require.Equal(t, "true", transport.requests[1].Header.Get(HeaderForbidOverwrite))
+
+	_, err = client.CompleteMultipartUploadV2(context.Background(), &CompleteMultipartUploadV2Input{Bucket: "bucket",
+		Key: "key", UploadID: "upload", Parts: []UploadedPartV2{{PartNumber: 1, ETag: "etag"}}, ForbidOverwrite: true})
+	require.Nil(t, err)
+	require.Equal(t, "true", transport.requests[2].Header.Get(HeaderForbidOverwrite))
+
+	// not retried even if the error is retryable, the previous attempt may have created the object
+	transport.respond(http.StatusServiceUnavailable, `{"Code":"ServiceUnavailable"}`)
+	_, err = client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", ForbidOverwrite: true},
+		Content:             strings.NewReader("a")})
+	require.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+	require.Len(t, transport.requests, 4)
+
+	transport.respond(http.StatusConflict, `{"Code":"ObjectAlreadyExists"}`)
+	_, err = client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", ForbidOverwrite: true},
+		Content:             strings.NewReader("a")})
+	require.True(t, IsObjectAlreadyExists(err))
+	require.False(t, IsObjectAlreadyExists(newTosClientError("tos: connection refused", nil)))
+}
@@ -0,0 +1,48 @@
+package tos
+
+import (
+	"context"
+	"time"
+)
+
+// Hooks typed callbacks around each attempt of requests, set by WithHooks. All of them are nullable.
+// They are called synchronously in the goroutine sending the request, so they should return quickly.
+type Hooks struct {
+	// OnRequest is called before each attempt, including retries and redirects
+	OnRequest func(ctx context.Context, event *RequestEvent)
+	// OnResponse is called after each attempt, with either the response or the error
+	OnResponse func(ctx context.Context, event *ResponseEvent)
+	// OnRetry is called before each retry, with the error triggering it
+	OnRetry func(ctx context.Context, event *RetryEvent)
+}
+
+// RequestEvent is passed to Hooks.OnRequest
+type RequestEvent struct {
+	OperationName string // see Operation* constants
+	Attempt       int    // starts from 1
+	Request       *Request
+}
+
+// ResponseEvent is passed to Hooks.OnResponse
+type ResponseEvent struct {
+	OperationName string
+	Attempt       int
+	Request       *Request
+	Response      *Response // nil if Err is not nil, its body must not be read or closed
+	Err           error     // e.g. TosServerError for unexpected status codes, or network errors
+	Latency       time.Duration
+}
+
+// RetryEvent is passed to Hooks.OnRetry
+type RetryEvent struct {
+	OperationName string
+	Attempt       int   // the attempt to be sent, starts from 2
+	Err           error // error of the last attempt triggering the retry
+}
+
+// callHook call hook, return TosClientError if it panics
+func callHook(hook func()) (err error) {
+	defer recoverPanic("Hooks", &err)
+	hook()
+	return nil
+}
@@ -0,0 +1,55 @@
+package tos
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// flakyTransport fails the first failures requests with 503
+type flakyTransport struct {
+	failures int
+}
+
+func (rt *flakyTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	if rt.failures > 0 {
+		rt.failures--
+		return &Response{StatusCode: http.StatusServiceUnavailable, Header: make(http.Header),
+			Body: ioutil.NopCloser(strings.NewReader(`{"Code":"ServiceUnavailable"}`))}, nil
+	}
+	return &Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestWithHooks(t *testing.T) {
+	var events []string
+	hooks := Hooks{
+		OnRequest: func(ctx context.Context, event *RequestEvent) {
+			events = append(events, fmt.Sprintf("request %s %d", event.OperationName, event.Attempt))
+		},
+		OnResponse: func(ctx context.Context, event *ResponseEvent) {
+			events = append(events, fmt.Sprintf("response %d %d", event.Attempt, StatusCode(event.Err)))
+		},
+		OnRetry: func(ctx context.Context, event *RetryEvent) {
+			events = append(events, fmt.Sprintf("retry %d %d", event.Attempt, StatusCode(event.Err)))
+		},
+	}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&flakyTransport{failures: 1}), WithHooks(hooks),
+		WithMaxRetryCount(2), WithRetryBackoff(time.Millisecond, time.Millisecond))
+	require.Nil(t, err)
+
+	_, err = client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"request HeadObject 1",
+		"response 1 503",
+		"retry 2 503",
+		"request HeadObject 2",
+		"response 2 0",
+	}, events)
+}
@@ -0,0 +1,32 @@
+package tos
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// setIdempotencyToken set a new token of mutating method if it's not set yet.
+// The token is set once per call, so all retries and redirects of the call carry the same one.
+func (rb *requestBuilder) setIdempotencyToken(method string) {
+	switch method {
+	case http.MethodPut, http.MethodPost, http.MethodDelete:
+	default:
+		return
+	}
+	if rb.Header.Get(HeaderIdempotencyToken) == "" {
+		rb.Header.Set(HeaderIdempotencyToken, newIdempotencyToken())
+	}
+}
+
+// newIdempotencyToken return 32 random hex characters
+func newIdempotencyToken() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		// crypto/rand hardly fails, fall back to the clock which is still unique enough within a client
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(buf[:])
+}
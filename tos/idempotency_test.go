@@ -0,0 +1,50 @@
+package tos
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// tokenTransport record idempotency tokens of requests, and fails the first failures requests
+type tokenTransport struct {
+	flakyTransport
+	tokens []string
+}
+
+func (rt *tokenTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.tokens = append(rt.tokens, req.Header.Get(HeaderIdempotencyToken))
+	return rt.flakyTransport.RoundTrip(ctx, req)
+}
+
+func TestWithIdempotencyToken(t *testing.T) {
+	rt := &tokenTransport{flakyTransport: flakyTransport{failures: 1}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(rt), WithIdempotencyToken(true),
+		WithMaxRetryCount(2), WithRetryBackoff(time.Millisecond, time.Millisecond))
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	// retries share the token
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"},
+		Content: strings.NewReader("data")})
+	require.Nil(t, err)
+	require.Len(t, rt.tokens, 2)
+	require.Len(t, rt.tokens[0], 32)
+	require.Equal(t, rt.tokens[0], rt.tokens[1])
+
+	// each call has its own token
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"},
+		Content: strings.NewReader("data")})
+	require.Nil(t, err)
+	require.Len(t, rt.tokens, 3)
+	require.NotEqual(t, rt.tokens[0], rt.tokens[2])
+	require.NotEmpty(t, rt.tokens[2])
+
+	// reads have no token
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Empty(t, rt.tokens[3])
+}
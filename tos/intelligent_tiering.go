@@ -0,0 +1,137 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// PutBucketAccessMonitorV2 enable or disable recording last access time of objects in a bucket, it must be
+// enabled before PutBucketIntelligentTieringV2
+func (cli *ClientV2) PutBucketAccessMonitorV2(ctx context.Context, input *PutBucketAccessMonitorV2Input, options ...Option) (*PutBucketAccessMonitorV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if input.Status != enum.AccessMonitorStatusEnabled && input.Status != enum.AccessMonitorStatusDisabled {
+		return nil, newTosClientError("tos: Status of access monitor must be Enabled or Disabled", nil)
+	}
+	in, contentMD5, err := marshalInput("PutBucketAccessMonitorV2Input", struct {
+		Status enum.AccessMonitorStatusType `json:"Status"`
+	}{input.Status})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketAccessMonitor).
+		WithQuery("accessmonitor", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketAccessMonitorV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketAccessMonitorV2 get whether last access time of objects in a bucket is recorded
+func (cli *ClientV2) GetBucketAccessMonitorV2(ctx context.Context, input *GetBucketAccessMonitorV2Input, options ...Option) (*GetBucketAccessMonitorV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketAccessMonitor).
+		WithQuery("accessmonitor", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketAccessMonitorV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// PutBucketIntelligentTieringV2 configure when objects in StorageClassIntelligentTiering move to colder access
+// tiers, the access tier of an object is returned as AccessTier of HeadObjectV2
+func (cli *ClientV2) PutBucketIntelligentTieringV2(ctx context.Context, input *PutBucketIntelligentTieringV2Input, options ...Option) (*PutBucketIntelligentTieringV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := validateIntelligentTiering(input); err != nil {
+		return nil, err
+	}
+	in, contentMD5, err := marshalInput("PutBucketIntelligentTieringV2Input", struct {
+		Status      enum.IntelligentTieringStatusType `json:"Status"`
+		Transitions []IntelligentTieringTransition    `json:"Transitions,omitempty"`
+	}{input.Status, input.Transitions})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketIntelligentTiering).
+		WithQuery("intelligenttiering", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketIntelligentTieringV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketIntelligentTieringV2 get intelligent tiering configuration of a bucket
+func (cli *ClientV2) GetBucketIntelligentTieringV2(ctx context.Context, input *GetBucketIntelligentTieringV2Input, options ...Option) (*GetBucketIntelligentTieringV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketIntelligentTiering).
+		WithQuery("intelligenttiering", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketIntelligentTieringV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+func validateIntelligentTiering(input *PutBucketIntelligentTieringV2Input) error {
+	switch input.Status {
+	case enum.IntelligentTieringStatusEnabled:
+		if len(input.Transitions) == 0 {
+			return newTosClientError("tos: Transitions are required if intelligent tiering is enabled", nil)
+		}
+	case enum.IntelligentTieringStatusDisabled:
+	default:
+		return newTosClientError("tos: Status of intelligent tiering must be Enabled or Disabled", nil)
+	}
+	tiers := make(map[enum.AccessTierType]struct{}, len(input.Transitions))
+	for _, transition := range input.Transitions {
+		switch transition.AccessTier {
+		case enum.AccessTierInfrequent, enum.AccessTierArchiveFr:
+		default:
+			return newTosClientError(fmt.Sprintf("tos: objects can not transition to access tier %q", transition.AccessTier), nil)
+		}
+		if _, ok := tiers[transition.AccessTier]; ok {
+			return newTosClientError(fmt.Sprintf("tos: duplicate transition to access tier %s", transition.AccessTier), nil)
+		}
+		tiers[transition.AccessTier] = struct{}{}
+		if transition.Days <= 0 {
+			return newTosClientError("tos: Days of intelligent tiering transition must be positive", nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketIntelligentTieringV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	_, err := client.PutBucketAccessMonitorV2(context.Background(), &PutBucketAccessMonitorV2Input{Bucket: "bucket",
+		Status: enum.AccessMonitorStatusEnabled})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Contains(t, req.Query, "accessmonitor")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Status":"Enabled"}`, string(data))
+
+	transport.respond(http.StatusOK, `{"Status":"Disabled"}`)
+	monitor, err := client.GetBucketAccessMonitorV2(context.Background(), &GetBucketAccessMonitorV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, enum.AccessMonitorStatusDisabled, monitor.Status)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "accessmonitor")
+
+	transitions := []IntelligentTieringTransition{{Days: 30, AccessTier: enum.AccessTierInfrequent},
+		{Days: 90, AccessTier: enum.AccessTierArchiveFr}}
+	transport.respond(http.StatusOK, "")
+	_, err = client.PutBucketIntelligentTieringV2(context.Background(), &PutBucketIntelligentTieringV2Input{
+		Bucket: "bucket", Status: enum.IntelligentTieringStatusEnabled, Transitions: transitions})
+	require.Nil(t, err)
+	req = transport.requests[2]
+	require.Contains(t, req.Query, "intelligenttiering")
+	data, err = ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Status":"Enabled","Transitions":[{"Days":30,"AccessTier":"INFREQUENT"},
+{"Days":90,"AccessTier":"ARCHIVE_FR"}]}`, string(data))
+
+	transport.respond(http.StatusOK, `{"Status":"Enabled","Transitions":[{"Days":60,"AccessTier":"INFREQUENT"}]}`)
+	tiering, err := client.GetBucketIntelligentTieringV2(context.Background(),
+		&GetBucketIntelligentTieringV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, enum.IntelligentTieringStatusEnabled, tiering.Status)
+	require.Equal(t, []IntelligentTieringTransition{{Days: 60, AccessTier: enum.AccessTierInfrequent}},
+		tiering.Transitions)
+	require.Contains(t, transport.requests[3].Query, "intelligenttiering")
+
+	_, err = client.PutBucketAccessMonitorV2(context.Background(), &PutBucketAccessMonitorV2Input{Bucket: "bucket"})
+	require.NotNil(t, err)
+	for _, input := range []PutBucketIntelligentTieringV2Input{
+		{Bucket: "bucket"},
+		{Bucket: "bucket", Status: enum.IntelligentTieringStatusEnabled},
+		{Bucket: "bucket", Status: enum.IntelligentTieringStatusEnabled,
+			Transitions: []IntelligentTieringTransition{{Days: 30, AccessTier: enum.AccessTierFrequent}}},
+		{Bucket: "bucket", Status: enum.IntelligentTieringStatusEnabled,
+			Transitions: []IntelligentTieringTransition{{Days: 0, AccessTier: enum.AccessTierInfrequent}}},
+		{Bucket: "bucket", Status: enum.IntelligentTieringStatusEnabled, Transitions: append(transitions, transitions[0])},
+	} {
+		_, err = client.PutBucketIntelligentTieringV2(context.Background(), &input)
+		require.NotNil(t, err)
+	}
+	require.Len(t, transport.requests, 4)
+}
+
+func TestHeadObjectAccessTier(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderStorageClass, string(enum.StorageClassIntelligentTiering))
+	header.Set(HeaderAccessTier, string(enum.AccessTierInfrequent))
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	output, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, enum.StorageClassIntelligentTiering, output.StorageClass)
+	require.Equal(t, enum.AccessTierInfrequent, output.AccessTier)
+}
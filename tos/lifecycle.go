@@ -0,0 +1,324 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+const maxLifecycleRules = 1000
+
+// PutBucketLifecycleV2 replace lifecycle rules of a bucket, rules are validated before sending, see LifecycleRuleBuilder
+func (cli *ClientV2) PutBucketLifecycleV2(ctx context.Context, input *PutBucketLifecycleV2Input, options ...Option) (*PutBucketLifecycleV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := validateLifecycleRules(input.Rules); err != nil {
+		return nil, err
+	}
+	in, contentMD5, err := marshalInput("PutBucketLifecycleV2Input", struct {
+		Rules []LifecycleRule `json:"Rules"`
+	}{input.Rules})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketLifecycle).
+		WithQuery("lifecycle", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketLifecycleV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketLifecycleV2 get lifecycle rules of a bucket, it fails with code NoSuchLifecycleConfiguration if there's none
+func (cli *ClientV2) GetBucketLifecycleV2(ctx context.Context, input *GetBucketLifecycleV2Input, options ...Option) (*GetBucketLifecycleV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketLifecycle).
+		WithQuery("lifecycle", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketLifecycleV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketLifecycleV2 delete all lifecycle rules of a bucket
+func (cli *ClientV2) DeleteBucketLifecycleV2(ctx context.Context, input *DeleteBucketLifecycleV2Input, options ...Option) (*DeleteBucketLifecycleV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketLifecycle).
+		WithQuery("lifecycle", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketLifecycleV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// MarshalJSON omit zero Date, which is formatted as midnight UTC
+func (e LifecycleExpiration) MarshalJSON() ([]byte, error) {
+	type expiration LifecycleExpiration
+	return json.Marshal(struct {
+		expiration
+		Date string `json:"Date,omitempty"`
+	}{expiration(e), formatLifecycleDate(e.Date)})
+}
+
+// MarshalJSON omit zero Date, which is formatted as midnight UTC
+func (t LifecycleTransition) MarshalJSON() ([]byte, error) {
+	type transition LifecycleTransition
+	return json.Marshal(struct {
+		transition
+		Date string `json:"Date,omitempty"`
+	}{transition(t), formatLifecycleDate(t.Date)})
+}
+
+func formatLifecycleDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.UTC().Format(time.RFC3339)
+}
+
+// LifecycleRuleBuilder build a LifecycleRule, which is validated by Build, e.g.
+//
+//	rule, err := NewLifecycleRuleBuilder("logs").Prefix("logs/").
+//		TransitionAfterDays(30, enum.StorageClassIa).ExpireAfterDays(365).Build()
+type LifecycleRuleBuilder struct {
+	rule LifecycleRule
+}
+
+// NewLifecycleRuleBuilder create a builder of an enabled rule with id, which is unique in rules of a bucket
+func NewLifecycleRuleBuilder(id string) *LifecycleRuleBuilder {
+	return &LifecycleRuleBuilder{rule: LifecycleRule{ID: id, Status: enum.LifecycleStatusEnabled}}
+}
+
+// Prefix apply the rule to objects with prefix only
+func (b *LifecycleRuleBuilder) Prefix(prefix string) *LifecycleRuleBuilder {
+	b.rule.Prefix = prefix
+	return b
+}
+
+// Tag apply the rule to objects with the tag only, the rule applies to objects with all the tags if called repeatedly
+func (b *LifecycleRuleBuilder) Tag(key, value string) *LifecycleRuleBuilder {
+	b.rule.Tags = append(b.rule.Tags, Tag{Key: key, Value: value})
+	return b
+}
+
+// Disabled keep the rule without applying it
+func (b *LifecycleRuleBuilder) Disabled() *LifecycleRuleBuilder {
+	b.rule.Status = enum.LifecycleStatusDisabled
+	return b
+}
+
+// ExpireAfterDays delete objects days after they're created
+func (b *LifecycleRuleBuilder) ExpireAfterDays(days int) *LifecycleRuleBuilder {
+	b.rule.Expiration = &LifecycleExpiration{Days: days}
+	return b
+}
+
+// ExpireOnDate delete objects created before date, which must be midnight UTC
+func (b *LifecycleRuleBuilder) ExpireOnDate(date time.Time) *LifecycleRuleBuilder {
+	b.rule.Expiration = &LifecycleExpiration{Date: date}
+	return b
+}
+
+// TransitionAfterDays change storage class of objects to class days after they're created
+func (b *LifecycleRuleBuilder) TransitionAfterDays(days int, class enum.StorageClassType) *LifecycleRuleBuilder {
+	b.rule.Transitions = append(b.rule.Transitions, LifecycleTransition{Days: days, StorageClass: class})
+	return b
+}
+
+// TransitionOnDate change storage class of objects created before date to class, date must be midnight UTC
+func (b *LifecycleRuleBuilder) TransitionOnDate(date time.Time, class enum.StorageClassType) *LifecycleRuleBuilder {
+	b.rule.Transitions = append(b.rule.Transitions, LifecycleTransition{Date: date, StorageClass: class})
+	return b
+}
+
+// ExpireNoncurrentAfterDays delete noncurrent versions days after they become noncurrent
+func (b *LifecycleRuleBuilder) ExpireNoncurrentAfterDays(days int) *LifecycleRuleBuilder {
+	b.rule.NoncurrentVersionExpiration = &NoncurrentVersionExpiration{NoncurrentDays: days}
+	return b
+}
+
+// TransitionNoncurrentAfterDays change storage class of noncurrent versions to class days after they become noncurrent
+func (b *LifecycleRuleBuilder) TransitionNoncurrentAfterDays(days int, class enum.StorageClassType) *LifecycleRuleBuilder {
+	b.rule.NoncurrentVersionTransitions = append(b.rule.NoncurrentVersionTransitions,
+		NoncurrentVersionTransition{NoncurrentDays: days, StorageClass: class})
+	return b
+}
+
+// AbortIncompleteUploadAfterDays abort multipart uploads days after they're created, it can't be used with Tag
+func (b *LifecycleRuleBuilder) AbortIncompleteUploadAfterDays(days int) *LifecycleRuleBuilder {
+	b.rule.AbortIncompleteMultipartUpload = &AbortIncompleteMultipartUpload{DaysAfterInitiation: days}
+	return b
+}
+
+// Build validate and return the rule
+func (b *LifecycleRuleBuilder) Build() (LifecycleRule, error) {
+	rule := b.rule
+	rule.Tags = append([]Tag(nil), rule.Tags...)
+	rule.Transitions = append([]LifecycleTransition(nil), rule.Transitions...)
+	rule.NoncurrentVersionTransitions = append([]NoncurrentVersionTransition(nil), rule.NoncurrentVersionTransitions...)
+	if err := validateLifecycleRule(&rule); err != nil {
+		return LifecycleRule{}, err
+	}
+	return rule, nil
+}
+
+// validateLifecycleRules validate each rule, and that rules don't conflict, i.e. IDs are unique and prefixes of rules
+// without tags don't overlap
+func validateLifecycleRules(rules []LifecycleRule) error {
+	if len(rules) == 0 || len(rules) > maxLifecycleRules {
+		return newTosClientError(fmt.Sprintf("tos: number of lifecycle rules must be [1, %d]", maxLifecycleRules), nil)
+	}
+	ids := make(map[string]struct{}, len(rules))
+	for i := range rules {
+		rule := &rules[i]
+		if err := validateLifecycleRule(rule); err != nil {
+			return err
+		}
+		if len(rule.ID) > 0 {
+			if _, ok := ids[rule.ID]; ok {
+				return newTosClientError("tos: duplicate lifecycle rule ID "+rule.ID, nil)
+			}
+			ids[rule.ID] = struct{}{}
+		}
+		for j := 0; j < i; j++ {
+			other := &rules[j]
+			if len(rule.Tags) > 0 || len(other.Tags) > 0 {
+				continue
+			}
+			if strings.HasPrefix(rule.Prefix, other.Prefix) || strings.HasPrefix(other.Prefix, rule.Prefix) {
+				return newTosClientError(fmt.Sprintf("tos: prefixes of lifecycle rules %q and %q overlap",
+					other.ID, rule.ID), nil)
+			}
+		}
+	}
+	return nil
+}
+
+func validateLifecycleRule(rule *LifecycleRule) error {
+	invalid := func(reason string) error {
+		return newTosClientError(fmt.Sprintf("tos: invalid lifecycle rule %q, %s", rule.ID, reason), nil)
+	}
+	if rule.Status != enum.LifecycleStatusEnabled && rule.Status != enum.LifecycleStatusDisabled {
+		return invalid("status must be Enabled or Disabled")
+	}
+	if len(rule.ID) > 255 {
+		return invalid("the length of ID must be at most 255")
+	}
+	if rule.Expiration == nil && len(rule.Transitions) == 0 && rule.NoncurrentVersionExpiration == nil &&
+		len(rule.NoncurrentVersionTransitions) == 0 && rule.AbortIncompleteMultipartUpload == nil {
+		return invalid("no action is set")
+	}
+	keys := make(map[string]struct{}, len(rule.Tags))
+	for _, tag := range rule.Tags {
+		if len(tag.Key) == 0 {
+			return invalid("empty tag key")
+		}
+		if _, ok := keys[tag.Key]; ok {
+			return invalid("duplicate tag key " + tag.Key)
+		}
+		keys[tag.Key] = struct{}{}
+	}
+
+	expiration := rule.Expiration
+	if expiration != nil {
+		if err := validateLifecycleTime(expiration.Days, expiration.Date); err != nil {
+			return invalid("expiration " + err.Error())
+		}
+	}
+	classes := make(map[enum.StorageClassType]struct{}, len(rule.Transitions))
+	for _, transition := range rule.Transitions {
+		if err := validateLifecycleTime(transition.Days, transition.Date); err != nil {
+			return invalid("transition " + err.Error())
+		}
+		if err := validateTransitionClass(classes, transition.StorageClass); err != nil {
+			return invalid(err.Error())
+		}
+		if (transition.Days > 0) != (rule.Transitions[0].Days > 0) {
+			return invalid("transitions by days and by date can't be mixed")
+		}
+		if expiration != nil && (transition.Days > 0) != (expiration.Days > 0) {
+			return invalid("transitions and expiration by days and by date can't be mixed")
+		}
+		if expiration != nil && (transition.Days >= expiration.Days && expiration.Days > 0 ||
+			!transition.Date.Before(expiration.Date) && !expiration.Date.IsZero()) {
+			return invalid("objects must transition before they expire")
+		}
+	}
+
+	noncurrent := rule.NoncurrentVersionExpiration
+	if noncurrent != nil && noncurrent.NoncurrentDays <= 0 {
+		return invalid("NoncurrentDays of noncurrent version expiration must be positive")
+	}
+	classes = make(map[enum.StorageClassType]struct{}, len(rule.NoncurrentVersionTransitions))
+	for _, transition := range rule.NoncurrentVersionTransitions {
+		if transition.NoncurrentDays <= 0 {
+			return invalid("NoncurrentDays of noncurrent version transition must be positive")
+		}
+		if err := validateTransitionClass(classes, transition.StorageClass); err != nil {
+			return invalid(err.Error())
+		}
+		if noncurrent != nil && transition.NoncurrentDays >= noncurrent.NoncurrentDays {
+			return invalid("noncurrent versions must transition before they expire")
+		}
+	}
+
+	if abort := rule.AbortIncompleteMultipartUpload; abort != nil {
+		if abort.DaysAfterInitiation <= 0 {
+			return invalid("DaysAfterInitiation must be positive")
+		}
+		if len(rule.Tags) > 0 {
+			return invalid("aborting incomplete multipart uploads can't be filtered by tags")
+		}
+	}
+	return nil
+}
+
+// validateLifecycleTime validate that one of days and date is set, and date is midnight UTC
+func validateLifecycleTime(days int, date time.Time) error {
+	if (days > 0) == !date.IsZero() {
+		return fmt.Errorf("must set one of positive Days and Date")
+	}
+	if !date.IsZero() && !date.UTC().Equal(date.UTC().Truncate(24*time.Hour)) {
+		return fmt.Errorf("date must be midnight UTC")
+	}
+	return nil
+}
+
+func validateTransitionClass(classes map[enum.StorageClassType]struct{}, class enum.StorageClassType) error {
+	if len(class) == 0 || class == enum.StorageClassStandard {
+		return fmt.Errorf("transition to storage class %q is not allowed", class)
+	}
+	if _, ok := classes[class]; ok {
+		return fmt.Errorf("duplicate transitions to storage class %s", class)
+	}
+	classes[class] = struct{}{}
+	return nil
+}
@@ -0,0 +1,91 @@
+package tos
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketLifecycleV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	logs, err := NewLifecycleRuleBuilder("logs").Prefix("logs/").
+		TransitionAfterDays(30, enum.StorageClassIa).ExpireAfterDays(365).
+		AbortIncompleteUploadAfterDays(7).Build()
+	require.Nil(t, err)
+	tmp, err := NewLifecycleRuleBuilder("tmp").Prefix("logs/tmp/").Tag("k", "v").
+		ExpireOnDate(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)).
+		ExpireNoncurrentAfterDays(30).TransitionNoncurrentAfterDays(10, enum.StorageClassArchive).Build()
+	require.Nil(t, err)
+	_, err = client.PutBucketLifecycleV2(context.Background(), &PutBucketLifecycleV2Input{Bucket: "bucket",
+		Rules: []LifecycleRule{logs, tmp}})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "lifecycle")
+	require.NotEmpty(t, req.Header.Get(HeaderContentMD5))
+	var body map[string][]map[string]interface{}
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(data, &body))
+	require.Equal(t, map[string]interface{}{"Days": float64(365)}, body["Rules"][0]["Expiration"])
+	require.Equal(t, map[string]interface{}{"Date": "2030-01-01T00:00:00Z"}, body["Rules"][1]["Expiration"])
+
+	transport.respond(http.StatusOK, `{"Rules":[{"ID":"tmp","Prefix":"logs/tmp/","Status":"Enabled",
+"Expiration":{"Date":"2030-01-01T00:00:00.000Z"},"Transitions":[{"Days":30,"StorageClass":"IA"}]}]}`)
+	out, err := client.GetBucketLifecycleV2(context.Background(), &GetBucketLifecycleV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Len(t, out.Rules, 1)
+	require.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), out.Rules[0].Expiration.Date.UTC())
+	require.Equal(t, enum.StorageClassIa, out.Rules[0].Transitions[0].StorageClass)
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketLifecycleV2(context.Background(), &DeleteBucketLifecycleV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+
+	// conflicting rules are rejected before sending
+	other, err := NewLifecycleRuleBuilder("other").Prefix("logs/old/").ExpireAfterDays(1).Build()
+	require.Nil(t, err)
+	_, err = client.PutBucketLifecycleV2(context.Background(), &PutBucketLifecycleV2Input{Bucket: "bucket",
+		Rules: []LifecycleRule{logs, other}})
+	require.NotNil(t, err)
+	_, err = client.PutBucketLifecycleV2(context.Background(), &PutBucketLifecycleV2Input{Bucket: "bucket",
+		Rules: []LifecycleRule{tmp, tmp}})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 3)
+}
+
+func TestLifecycleRuleBuilder(t *testing.T) {
+	invalid := []*LifecycleRuleBuilder{
+		NewLifecycleRuleBuilder("no action"),
+		NewLifecycleRuleBuilder("zero days").ExpireAfterDays(0),
+		NewLifecycleRuleBuilder("not midnight").ExpireOnDate(time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)),
+		NewLifecycleRuleBuilder("late transition").ExpireAfterDays(30).TransitionAfterDays(30, enum.StorageClassIa),
+		NewLifecycleRuleBuilder("mixed").ExpireAfterDays(30).
+			TransitionOnDate(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), enum.StorageClassIa),
+		NewLifecycleRuleBuilder("duplicate class").TransitionAfterDays(1, enum.StorageClassIa).
+			TransitionAfterDays(2, enum.StorageClassIa),
+		NewLifecycleRuleBuilder("standard").TransitionAfterDays(1, enum.StorageClassStandard),
+		NewLifecycleRuleBuilder("noncurrent").ExpireNoncurrentAfterDays(5).
+			TransitionNoncurrentAfterDays(5, enum.StorageClassIa),
+		NewLifecycleRuleBuilder("abort with tag").Tag("k", "v").AbortIncompleteUploadAfterDays(1),
+		NewLifecycleRuleBuilder("duplicate tag").Tag("k", "v").Tag("k", "w").ExpireAfterDays(1),
+	}
+	for _, builder := range invalid {
+		_, err := builder.Build()
+		require.NotNil(t, err, builder.rule.ID)
+	}
+
+	rule, err := NewLifecycleRuleBuilder("archive").Disabled().TransitionAfterDays(30, enum.StorageClassIa).
+		TransitionAfterDays(90, enum.StorageClassArchive).ExpireAfterDays(365).Build()
+	require.Nil(t, err)
+	require.Equal(t, enum.LifecycleStatusDisabled, rule.Status)
+	require.Len(t, rule.Transitions, 2)
+}
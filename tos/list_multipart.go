@@ -0,0 +1,131 @@
+package tos
+
+import (
+	"context"
+)
+
+// PartsIterator iterates all uploaded parts of a multipart upload, following PartNumberMarker page by page.
+// Parts are ordered by part number. It's not safe for concurrent use.
+type PartsIterator struct {
+	cli     *ClientV2
+	input   ListPartsInput
+	options []Option
+	page    []UploadedPart
+	done    bool
+}
+
+// NewPartsIterator create an iterator listing parts by ListParts with input, PartNumberMarker of input is where
+// the iteration starts.
+func (cli *ClientV2) NewPartsIterator(input *ListPartsInput, options ...Option) *PartsIterator {
+	return &PartsIterator{cli: cli, input: *input, options: options}
+}
+
+// Next return the next part, or nil if there's no more. Errors of listing are returned as is, calling Next again
+// retries the failed page.
+func (it *PartsIterator) Next(ctx context.Context) (*UploadedPart, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for len(it.page) == 0 {
+		if it.done {
+			return nil, nil
+		}
+		if err := it.nextPage(ctx); err != nil {
+			return nil, err
+		}
+	}
+	part := it.page[0]
+	it.page = it.page[1:]
+	return &part, nil
+}
+
+func (it *PartsIterator) nextPage(ctx context.Context) error {
+	out, err := it.cli.ListParts(ctx, &it.input, it.options...)
+	if err != nil {
+		return err
+	}
+	it.page = out.Parts
+	it.done = !out.IsTruncated
+	if it.done {
+		return nil
+	}
+	if out.NextPartNumberMarker > 0 {
+		it.input.PartNumberMarker = out.NextPartNumberMarker
+		return nil
+	}
+	if len(it.page) == 0 {
+		// nothing to continue from, stop rather than listing the same page forever
+		return &TosServerError{
+			TosError:      TosError{"tos: truncated ListParts without NextPartNumberMarker"},
+			RequestInfo:   out.RequestInfo,
+			OperationName: OperationListParts,
+		}
+	}
+	it.input.PartNumberMarker = int(it.page[len(it.page)-1].PartNumber)
+	return nil
+}
+
+// MultipartUploadsIterator iterates all in-progress multipart uploads matching the input of
+// NewMultipartUploadsIterator, following KeyMarker and UploadIDMarker page by page. Uploads are ordered by key and
+// then by initiated time. CommonPrefixes are skipped, list without Delimiter to iterate uploads of all keys.
+// It's not safe for concurrent use.
+type MultipartUploadsIterator struct {
+	cli     *ClientV2
+	input   ListMultipartUploadsV2Input
+	options []Option
+	page    []ListedUpload
+	done    bool
+}
+
+// NewMultipartUploadsIterator create an iterator listing uploads by ListMultipartUploadsV2 with input, KeyMarker
+// and UploadIDMarker of input are where the iteration starts.
+func (cli *ClientV2) NewMultipartUploadsIterator(input *ListMultipartUploadsV2Input, options ...Option) *MultipartUploadsIterator {
+	return &MultipartUploadsIterator{cli: cli, input: *input, options: options}
+}
+
+// Next return the next upload, or nil if there's no more. Errors of listing are returned as is, calling Next again
+// retries the failed page.
+func (it *MultipartUploadsIterator) Next(ctx context.Context) (*ListedUpload, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for len(it.page) == 0 {
+		if it.done {
+			return nil, nil
+		}
+		if err := it.nextPage(ctx); err != nil {
+			return nil, err
+		}
+	}
+	upload := it.page[0]
+	it.page = it.page[1:]
+	return &upload, nil
+}
+
+func (it *MultipartUploadsIterator) nextPage(ctx context.Context) error {
+	out, err := it.cli.ListMultipartUploadsV2(ctx, &it.input, it.options...)
+	if err != nil {
+		return err
+	}
+	it.page = out.Uploads
+	it.done = !out.IsTruncated
+	if it.done {
+		return nil
+	}
+	if len(out.NextKeyMarker) > 0 {
+		it.input.KeyMarker = out.NextKeyMarker
+		it.input.UploadIDMarker = out.NextUploadIDMarker
+		return nil
+	}
+	if len(it.page) == 0 {
+		return &TosServerError{
+			TosError:      TosError{"tos: truncated ListMultipartUploads without NextKeyMarker"},
+			RequestInfo:   out.RequestInfo,
+			OperationName: OperationListMultipartUploads,
+		}
+	}
+	last := it.page[len(it.page)-1]
+	it.input.KeyMarker = last.Key
+	it.input.UploadIDMarker = last.UploadID
+	return nil
+}
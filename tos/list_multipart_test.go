@@ -0,0 +1,82 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// markerPagesTransport respond with pages keyed by the query marker
+type markerPagesTransport struct {
+	marker   string
+	pages    map[string]string
+	requests []*Request
+}
+
+func (rt *markerPagesTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.requests = append(rt.requests, req)
+	return &Response{StatusCode: http.StatusOK, Header: make(http.Header),
+		Body: ioutil.NopCloser(strings.NewReader(rt.pages[req.Query.Get(rt.marker)]))}, nil
+}
+
+func TestPartsIterator(t *testing.T) {
+	transport := &markerPagesTransport{marker: "part-number-marker", pages: map[string]string{
+		"":  `{"IsTruncated":true,"NextPartNumberMarker":2,"Parts":[{"PartNumber":1},{"PartNumber":2}]}`,
+		"2": `{"IsTruncated":true,"Parts":[{"PartNumber":3}]}`,
+		"3": `{"IsTruncated":false,"Parts":[{"PartNumber":4,"Size":5}]}`,
+	}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+
+	it := client.NewPartsIterator(&ListPartsInput{Bucket: "bucket", Key: "key", UploadID: "upload", MaxParts: 2})
+	var got []int32
+	for {
+		part, err := it.Next(context.Background())
+		require.Nil(t, err)
+		if part == nil {
+			break
+		}
+		got = append(got, part.PartNumber)
+	}
+	require.Equal(t, []int32{1, 2, 3, 4}, got)
+	require.Len(t, transport.requests, 3)
+	require.Equal(t, "upload", transport.requests[2].Query.Get("uploadId"))
+	require.Equal(t, "2", transport.requests[2].Query.Get("max-parts"))
+
+	// truncated without any marker
+	transport.pages[""] = `{"IsTruncated":true}`
+	_, err = client.NewPartsIterator(&ListPartsInput{Bucket: "bucket", Key: "key", UploadID: "upload"}).
+		Next(context.Background())
+	require.NotNil(t, err)
+}
+
+func TestMultipartUploadsIterator(t *testing.T) {
+	transport := &markerPagesTransport{marker: "key-marker", pages: map[string]string{
+		"": `{"IsTruncated":true,"NextKeyMarker":"b","NextUploadIdMarker":"b1",
+"Uploads":[{"Key":"a","UploadId":"a1"},{"Key":"b","UploadId":"b1"}]}`,
+		"b": `{"IsTruncated":true,"Uploads":[{"Key":"c","UploadId":"c1"}],"CommonPrefixes":[{"Prefix":"p/"}]}`,
+		"c": `{"IsTruncated":false}`,
+	}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+
+	it := client.NewMultipartUploadsIterator(&ListMultipartUploadsV2Input{Bucket: "bucket", Prefix: "p"})
+	var got []string
+	for {
+		upload, err := it.Next(context.Background())
+		require.Nil(t, err)
+		if upload == nil {
+			break
+		}
+		got = append(got, upload.UploadID)
+	}
+	require.Equal(t, []string{"a1", "b1", "c1"}, got)
+	require.Len(t, transport.requests, 3)
+	require.Equal(t, "p", transport.requests[0].Query.Get("prefix"))
+	require.Equal(t, "b1", transport.requests[1].Query.Get("upload-id-marker"))
+	require.Equal(t, "c1", transport.requests[2].Query.Get("upload-id-marker"))
+}
@@ -0,0 +1,33 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListObjectsType2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"Name":"bucket","Prefix":"dir/","MaxKeys":2,"KeyCount":2,
+"ContinuationToken":"token-1","NextContinuationToken":"token-2","IsTruncated":true,
+"CommonPrefixes":[{"Prefix":"dir/sub/"}],"Contents":[{"Key":"dir/a","Size":3,"Owner":{"ID":"owner"}}]}`)
+
+	output, err := client.ListObjectsType2(context.Background(), &ListObjectsType2Input{Bucket: "bucket",
+		Prefix: "dir/", Delimiter: "/", ContinuationToken: "token-1", MaxKeys: 2, FetchOwner: true})
+	require.Nil(t, err)
+	require.True(t, output.IsTruncated)
+	require.Equal(t, "token-2", output.NextContinuationToken)
+	require.Equal(t, 2, output.KeyCount)
+	require.Equal(t, "dir/sub/", output.CommonPrefixes[0].Prefix)
+	require.Equal(t, "owner", output.Contents[0].Owner.ID)
+
+	req := transport.requests[0]
+	require.Equal(t, http.MethodGet, req.Method)
+	require.Equal(t, OperationListObjectsType2, req.OperationName)
+	require.Equal(t, "2", req.Query.Get("list-type"))
+	require.Equal(t, "token-1", req.Query.Get("continuation-token"))
+	require.Equal(t, "2", req.Query.Get("max-keys"))
+	require.Equal(t, "true", req.Query.Get("fetch-owner"))
+	require.Equal(t, "/", req.Query.Get("delimiter"))
+}
@@ -0,0 +1,121 @@
+package tos
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// ObjectVersionEntry a version or a delete marker yielded by ObjectVersionsIterator, one of Version and DeleteMarker
+// is set
+type ObjectVersionEntry struct {
+	Key            string
+	VersionID      string
+	IsLatest       bool
+	IsDeleteMarker bool
+	LastModified   time.Time
+	Version        *ListedObjectVersion
+	DeleteMarker   *ListedDeleteMarker
+}
+
+// ObjectVersionsIterator iterates all versions and delete markers matching the input of NewObjectVersionsIterator,
+// following KeyMarker and VersionIDMarker page by page. Entries are ordered by key, and from the newest to the oldest
+// of each key. It's not safe for concurrent use.
+type ObjectVersionsIterator struct {
+	cli     *ClientV2
+	input   ListObjectVersionsV2Input
+	options []Option
+	page    []ObjectVersionEntry
+	done    bool
+}
+
+// NewObjectVersionsIterator create an iterator listing versions by ListObjectVersionsV2 with input, KeyMarker and
+// VersionIDMarker of input are where the iteration starts.
+func (cli *ClientV2) NewObjectVersionsIterator(input *ListObjectVersionsV2Input, options ...Option) *ObjectVersionsIterator {
+	return &ObjectVersionsIterator{cli: cli, input: *input, options: options}
+}
+
+// Next return the next entry, or nil if there's no more. Errors of listing are returned as is, calling Next again
+// retries the failed page.
+func (it *ObjectVersionsIterator) Next(ctx context.Context) (*ObjectVersionEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for len(it.page) == 0 {
+		if it.done {
+			return nil, nil
+		}
+		if err := it.nextPage(ctx); err != nil {
+			return nil, err
+		}
+	}
+	entry := it.page[0]
+	it.page = it.page[1:]
+	return &entry, nil
+}
+
+func (it *ObjectVersionsIterator) nextPage(ctx context.Context) error {
+	out, err := it.cli.ListObjectVersionsV2(ctx, &it.input, it.options...)
+	if err != nil {
+		return err
+	}
+	it.page = mergeVersionEntries(out.Versions, out.DeleteMarkers)
+	it.done = !out.IsTruncated
+	if it.done {
+		return nil
+	}
+	if len(out.NextKeyMarker) > 0 {
+		it.input.KeyMarker = out.NextKeyMarker
+		it.input.VersionIDMarker = out.NextVersionIDMarker
+		return nil
+	}
+	if len(it.page) == 0 {
+		// nothing to continue from, stop rather than listing the same page forever
+		return &TosServerError{
+			TosError:      TosError{"tos: truncated ListObjectVersions without NextKeyMarker"},
+			RequestInfo:   out.RequestInfo,
+			OperationName: OperationListObjectVersions,
+		}
+	}
+	last := it.page[len(it.page)-1]
+	it.input.KeyMarker = last.Key
+	it.input.VersionIDMarker = last.VersionID
+	return nil
+}
+
+// mergeVersionEntries merge versions and delete markers of a page, ordered by key, and from the newest to the oldest
+func mergeVersionEntries(versions []ListedObjectVersion, markers []ListedDeleteMarker) []ObjectVersionEntry {
+	entries := make([]ObjectVersionEntry, 0, len(versions)+len(markers))
+	for i := range versions {
+		v := &versions[i]
+		lastModified, _ := time.Parse(time.RFC3339, v.LastModified)
+		entries = append(entries, ObjectVersionEntry{
+			Key:          v.Key,
+			VersionID:    v.VersionID,
+			IsLatest:     v.IsLatest,
+			LastModified: lastModified,
+			Version:      v,
+		})
+	}
+	for i := range markers {
+		m := &markers[i]
+		entries = append(entries, ObjectVersionEntry{
+			Key:            m.Key,
+			VersionID:      m.VersionID,
+			IsLatest:       m.IsLatest,
+			IsDeleteMarker: true,
+			LastModified:   m.LastModified,
+			DeleteMarker:   m,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Key != entries[j].Key {
+			return entries[i].Key < entries[j].Key
+		}
+		if entries[i].IsLatest != entries[j].IsLatest {
+			return entries[i].IsLatest
+		}
+		return entries[i].LastModified.After(entries[j].LastModified)
+	})
+	return entries
+}
@@ -0,0 +1,63 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// versionPagesTransport respond ListObjectVersions with pages keyed by key-marker
+type versionPagesTransport struct {
+	pages    map[string]string
+	requests []*Request
+}
+
+func (rt *versionPagesTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.requests = append(rt.requests, req)
+	return &Response{StatusCode: http.StatusOK, Header: make(http.Header),
+		Body: ioutil.NopCloser(strings.NewReader(rt.pages[req.Query.Get("key-marker")]))}, nil
+}
+
+func TestObjectVersionsIterator(t *testing.T) {
+	transport := &versionPagesTransport{pages: map[string]string{
+		"": `{"IsTruncated":true,"NextKeyMarker":"b","NextVersionIdMarker":"b1",
+"Versions":[{"Key":"a","VersionId":"a1","IsLatest":false,"LastModified":"2024-01-01T00:00:00.000Z"},
+{"Key":"b","VersionId":"b1","IsLatest":true,"LastModified":"2024-01-01T00:00:00.000Z"}],
+"DeleteMarkers":[{"Key":"a","VersionId":"a2","IsLatest":true,"LastModified":"2024-01-02T00:00:00.000Z"}]}`,
+		"b": `{"IsTruncated":false,"Versions":[{"Key":"c","VersionId":"c1","IsLatest":true}]}`,
+	}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+
+	it := client.NewObjectVersionsIterator(&ListObjectVersionsV2Input{Bucket: "bucket",
+		ListObjectVersionsInput: ListObjectVersionsInput{Prefix: "p", MaxKeys: 3}})
+	var got []string
+	for {
+		entry, err := it.Next(context.Background())
+		require.Nil(t, err)
+		if entry == nil {
+			break
+		}
+		got = append(got, entry.VersionID)
+		if entry.IsDeleteMarker {
+			require.NotNil(t, entry.DeleteMarker)
+		} else {
+			require.NotNil(t, entry.Version)
+		}
+	}
+	require.Equal(t, []string{"a2", "a1", "b1", "c1"}, got)
+	require.Len(t, transport.requests, 2)
+	require.Equal(t, "p", transport.requests[0].Query.Get("prefix"))
+	require.Equal(t, "3", transport.requests[0].Query.Get("max-keys"))
+	require.Equal(t, "b1", transport.requests[1].Query.Get("version-id-marker"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.NewObjectVersionsIterator(&ListObjectVersionsV2Input{Bucket: "bucket"}).Next(ctx)
+	require.Equal(t, context.Canceled, err)
+	require.Len(t, transport.requests, 2)
+}
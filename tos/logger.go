@@ -0,0 +1,109 @@
+package tos
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+)
+
+// Logger leveled logger used by the client, retryer and transfer helpers like UploadFile, set by WithLogger.
+// keysAndValues are alternating keys and values, e.g. "bucket", "my-bucket", "partNumber", 1.
+//
+// Use NewStdLogger to adapt log.Logger, or NewSlogLogger to adapt slog.Logger with Go 1.21 or later.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// nopLogger drops all logs, it's the default Logger of client
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, keysAndValues ...interface{}) {}
+
+func (nopLogger) Info(msg string, keysAndValues ...interface{}) {}
+
+func (nopLogger) Warn(msg string, keysAndValues ...interface{}) {}
+
+func (nopLogger) Error(msg string, keysAndValues ...interface{}) {}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+// NewStdLogger adapt logger to Logger, logs below level are dropped.
+// If logger is nil, logs are written to stderr with the standard flags.
+//
+// Logs are formatted like: [WARN] tos: retry request attempt=1 error="..."
+func NewStdLogger(logger *log.Logger, level LogLevel) Logger {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+	return &stdLogger{logger: logger, level: level}
+}
+
+func (l *stdLogger) log(level LogLevel, msg string, keysAndValues []interface{}) {
+	if level < l.level {
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteString("[" + level.String() + "] " + msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		var value interface{} = "MISSING"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		switch v := value.(type) {
+		case string:
+			fmt.Fprintf(&buf, " %v=%q", keysAndValues[i], v)
+		case error:
+			fmt.Fprintf(&buf, " %v=%q", keysAndValues[i], v.Error())
+		default:
+			fmt.Fprintf(&buf, " %v=%v", keysAndValues[i], v)
+		}
+	}
+	l.logger.Output(3, buf.String())
+}
+
+func (l *stdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(LogLevelDebug, msg, keysAndValues)
+}
+
+func (l *stdLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(LogLevelInfo, msg, keysAndValues)
+}
+
+func (l *stdLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log(LogLevelWarn, msg, keysAndValues)
+}
+
+func (l *stdLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(LogLevelError, msg, keysAndValues)
+}
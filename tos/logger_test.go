@@ -0,0 +1,78 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type logEntry struct {
+	level         LogLevel
+	msg           string
+	keysAndValues []interface{}
+}
+
+type recordLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordLogger) record(level LogLevel, msg string, keysAndValues []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, keysAndValues: keysAndValues})
+}
+
+func (l *recordLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.record(LogLevelDebug, msg, keysAndValues)
+}
+
+func (l *recordLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record(LogLevelInfo, msg, keysAndValues)
+}
+
+func (l *recordLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.record(LogLevelWarn, msg, keysAndValues)
+}
+
+func (l *recordLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.record(LogLevelError, msg, keysAndValues)
+}
+
+func (l *recordLogger) messages(level LogLevel) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var msgs []string
+	for _, e := range l.entries {
+		if e.level == level {
+			msgs = append(msgs, e.msg)
+		}
+	}
+	return msgs
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := &recordLogger{}
+	client, _ := newRecordClient(t, http.StatusServiceUnavailable, "", WithLogger(logger),
+		WithMaxRetryCount(2), WithRetryBackoff(time.Millisecond, time.Millisecond))
+
+	_, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+	require.Equal(t, []string{"tos: retry request", "tos: retry request"}, logger.messages(LogLevelWarn))
+	require.Equal(t, []string{"tos: request failed"}, logger.messages(LogLevelDebug))
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0), LogLevelWarn)
+	logger.Info("tos: dropped")
+	logger.Warn("tos: retry request", "attempt", 1, "error", errors.New("tos: timeout"), "odd")
+	require.Equal(t, "[WARN] tos: retry request attempt=1 error=\"tos: timeout\" odd=\"MISSING\"\n", buf.String())
+}
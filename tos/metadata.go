@@ -46,7 +46,23 @@ type ObjectMetaV2 struct {
 	ObjectType              string
 	HashCrc64ecma           uint64
 	StorageClass            enum.StorageClassType
-	Meta                    map[string]string
+	// AccessTier current access tier of the object if StorageClass is StorageClassIntelligentTiering
+	AccessTier enum.AccessTierType
+	// RestoreInfo status of restoring an archived object, nil if it's never restored, see RestoreObjectV2
+	RestoreInfo *RestoreInfo
+	// ExpirationInfo when the object expires by a lifecycle rule of the bucket, nil if no rule applies
+	ExpirationInfo *ExpirationInfo
+	// SymlinkTargetKey, SymlinkTargetBucket and SymlinkTargetSize are the target object of the symlink if ObjectType
+	// is "Symlink", metadata of the target object is returned by HeadObjectV2 in this case, see PutSymlinkV2
+	SymlinkTargetKey    string
+	SymlinkTargetBucket string
+	SymlinkTargetSize   int64
+	// ObjectLockMode, ObjectLockRetainUntilDate and ObjectLockLegalHold are Object Lock status of the object version,
+	// they're empty if the object is not locked, see PutObjectRetention and PutObjectLegalHold
+	ObjectLockMode            enum.ObjectLockModeType
+	ObjectLockRetainUntilDate time.Time
+	ObjectLockLegalHold       enum.LegalHoldStatusType
+	Meta                      map[string]string
 
 	ContentLength      int64
 	ContentType        string
@@ -100,6 +116,15 @@ func (om *ObjectMetaV2) fromResponseV2(res *Response) {
 	om.ObjectType = res.Header.Get(HeaderObjectType)
 	om.HashCrc64ecma = crc64
 	om.StorageClass = enum.StorageClassType(res.Header.Get(HeaderStorageClass))
+	om.AccessTier = enum.AccessTierType(res.Header.Get(HeaderAccessTier))
+	om.RestoreInfo = parseRestoreInfo(res.Header.Get(HeaderRestore))
+	om.ExpirationInfo = parseExpirationInfo(res.Header.Get(HeaderExpiration))
+	om.SymlinkTargetKey = symlinkTargetKey(res.Header)
+	om.SymlinkTargetBucket = res.Header.Get(HeaderSymlinkBucket)
+	om.SymlinkTargetSize, _ = strconv.ParseInt(res.Header.Get(HeaderSymlinkTargetSize), 10, 64)
+	om.ObjectLockMode = enum.ObjectLockModeType(res.Header.Get(HeaderObjectLockMode))
+	om.ObjectLockRetainUntilDate, _ = time.Parse(time.RFC3339, res.Header.Get(HeaderObjectLockRetainUntilDate))
+	om.ObjectLockLegalHold = enum.LegalHoldStatusType(res.Header.Get(HeaderObjectLockLegalHold))
 	om.Meta = userMetadata(res.Header)
 	om.ContentLength = length
 	om.ContentType = res.Header.Get(HeaderContentType)
@@ -110,6 +135,86 @@ func (om *ObjectMetaV2) fromResponseV2(res *Response) {
 	om.Expires = expires
 }
 
+// RestoreInfo status of restoring an archived object from X-Tos-Restore header
+type RestoreInfo struct {
+	// OngoingRequest is true while the object is being restored
+	OngoingRequest bool
+	// ExpiryDate when the restored copy expires, zero while OngoingRequest is true
+	ExpiryDate time.Time
+}
+
+// parseRestoreInfo parse X-Tos-Restore header, e.g.
+// ongoing-request="false", expiry-date="Fri, 19 Apr 2024 00:00:00 GMT", return nil if restore is empty
+func parseRestoreInfo(restore string) *RestoreInfo {
+	if len(restore) == 0 {
+		return nil
+	}
+	info := &RestoreInfo{}
+	for key, value := range parseHeaderParams(restore) {
+		switch key {
+		case "ongoing-request":
+			info.OngoingRequest, _ = strconv.ParseBool(value)
+		case "expiry-date":
+			info.ExpiryDate, _ = time.ParseInLocation(http.TimeFormat, value, time.UTC)
+		}
+	}
+	return info
+}
+
+// ExpirationInfo when the object expires by a lifecycle rule, from X-Tos-Expiration header
+type ExpirationInfo struct {
+	RuleID     string
+	ExpiryDate time.Time
+}
+
+// parseExpirationInfo parse X-Tos-Expiration header, e.g.
+// expiry-date="Fri, 19 Apr 2024 00:00:00 GMT", rule-id="rule1", return nil if expiration is empty
+func parseExpirationInfo(expiration string) *ExpirationInfo {
+	if len(expiration) == 0 {
+		return nil
+	}
+	info := &ExpirationInfo{}
+	for key, value := range parseHeaderParams(expiration) {
+		switch key {
+		case "rule-id":
+			info.RuleID = value
+		case "expiry-date":
+			info.ExpiryDate, _ = time.ParseInLocation(http.TimeFormat, value, time.UTC)
+		}
+	}
+	return info
+}
+
+// parseHeaderParams parse comma separated key="value" pairs of a header, keys are lower cased
+func parseHeaderParams(header string) map[string]string {
+	params := make(map[string]string)
+	// values are quoted, and dates contain comma
+	for len(header) > 0 {
+		header = strings.TrimLeft(header, " ,")
+		eq := strings.Index(header, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.TrimSpace(header[:eq])
+		header = header[eq+1:]
+		var value string
+		if strings.HasPrefix(header, `"`) {
+			end := strings.Index(header[1:], `"`)
+			if end < 0 {
+				value, header = header[1:], ""
+			} else {
+				value, header = header[1:end+1], header[end+2:]
+			}
+		} else if comma := strings.Index(header, ","); comma >= 0 {
+			value, header = header[:comma], header[comma:]
+		} else {
+			value, header = header, ""
+		}
+		params[strings.ToLower(key)] = value
+	}
+	return params
+}
+
 func userMetadata(header http.Header) map[string]string {
 	meta := make(map[string]string)
 	for key := range header {
@@ -0,0 +1,92 @@
+package tos
+
+import (
+	"sync"
+	"time"
+)
+
+// MetricsCollector records metrics of requests, set by WithMetricsCollector.
+// It's called concurrently, implementations must be safe for concurrent use.
+//
+// Package github.com/volcengine/ve-tos-golang-sdk/v2/tos/prometheus provides a Prometheus adapter.
+type MetricsCollector interface {
+	// RecordRequest is called once an operation completes, after all its retries.
+	//   operation: see Operation* constants, or "UploadFile" for the whole transfer of UploadFile
+	//   statusCode: status code of the last response, 0 if no response is received, e.g. network errors
+	//   latency: time from sending the first request to receiving the last response header
+	//   bytes: length of request body for uploads, Content-Length of response for others, 0 if unknown
+	//   retries: number of retries, 0 if succeeded at the first attempt
+	RecordRequest(operation string, statusCode int, latency time.Duration, bytes int64, retries int)
+}
+
+// TransferMetricsCollector is optionally implemented by MetricsCollector to record scheduling of parts
+// in transfer managers such as UploadFile, helping to tune TaskNum and PartSize.
+type TransferMetricsCollector interface {
+	// RecordPartScheduled is called each time a part is picked up by a worker.
+	//   operation: "UploadFile"
+	//   wait: time the part waits in the task queue, from the start of transfer, or from being rescheduled after a failure
+	//   queueDepth: number of parts still waiting in the task queue after the part is picked up
+	RecordPartScheduled(operation string, wait time.Duration, queueDepth int)
+}
+
+// partQueue track parts waiting in the task queue of a transfer, a nil partQueue records nothing
+type partQueue struct {
+	collector TransferMetricsCollector
+	operation string
+	mu        sync.Mutex
+	queued    map[task]time.Time
+}
+
+// newPartQueue return nil if collector doesn't implement TransferMetricsCollector
+func newPartQueue(collector MetricsCollector, operation string) *partQueue {
+	transfer, ok := collector.(TransferMetricsCollector)
+	if !ok {
+		return nil
+	}
+	return &partQueue{collector: transfer, operation: operation, queued: make(map[task]time.Time)}
+}
+
+// enqueue record t is waiting for a worker from now
+func (q *partQueue) enqueue(t task) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	q.queued[t] = time.Now()
+	q.mu.Unlock()
+}
+
+// dequeue record t is picked up by a worker
+func (q *partQueue) dequeue(t task) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	start, ok := q.queued[t]
+	delete(q.queued, t)
+	depth := len(q.queued)
+	q.mu.Unlock()
+	if ok {
+		q.collector.RecordPartScheduled(q.operation, time.Since(start), depth)
+	}
+}
+
+// recordMetrics record the result of rb started at start
+func (rb *requestBuilder) recordMetrics(start time.Time, res *Response, err error) {
+	var (
+		statusCode int
+		bytes      int64
+	)
+	if rb.ContentLength != nil && *rb.ContentLength > 0 {
+		bytes = *rb.ContentLength
+	}
+	if err != nil {
+		statusCode = StatusCode(err)
+	} else {
+		statusCode = res.StatusCode
+		if bytes == 0 && res.ContentLength > 0 {
+			bytes = res.ContentLength
+		}
+	}
+	rb.metrics.RecordRequest(rb.OperationName, statusCode, time.Since(start), bytes, retryCount(rb.attempts))
+}
@@ -0,0 +1,109 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type metricsRecord struct {
+	operation  string
+	statusCode int
+	bytes      int64
+	retries    int
+}
+
+type recordCollector struct {
+	mu      sync.Mutex
+	records []metricsRecord
+}
+
+func (c *recordCollector) RecordRequest(operation string, statusCode int, latency time.Duration, bytes int64, retries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = append(c.records, metricsRecord{operation: operation, statusCode: statusCode, bytes: bytes, retries: retries})
+}
+
+func TestWithMetricsCollector(t *testing.T) {
+	collector := &recordCollector{}
+	client, _ := newRecordClient(t, http.StatusServiceUnavailable, "", WithMetricsCollector(collector),
+		WithMaxRetryCount(1), WithRetryBackoff(time.Millisecond, time.Millisecond))
+
+	_, err := client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"},
+		Content:             strings.NewReader("hello"),
+	})
+	require.NotNil(t, err)
+	require.Equal(t, []metricsRecord{{operation: OperationPutObject, statusCode: http.StatusServiceUnavailable,
+		bytes: 5, retries: 1}}, collector.records)
+}
+
+func TestUploadFileMetrics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-upload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	require.Nil(t, ioutil.WriteFile(file, []byte("hello"), 0666))
+
+	collector := &recordCollector{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&multipartTransport{failParts: 1}),
+		WithMetricsCollector(collector))
+	require.Nil(t, err)
+	_, err = client.UploadFile(context.Background(), &UploadFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:                     file,
+		PartRetryPolicy:              PartRetryPolicy{MaxReschedules: 1},
+	})
+	require.Nil(t, err)
+	last := collector.records[len(collector.records)-1]
+	require.Equal(t, metricsRecord{operation: "UploadFile", statusCode: http.StatusOK, bytes: 5, retries: 1}, last)
+}
+
+type partQueueRecord struct {
+	operation  string
+	queueDepth int
+}
+
+// transferCollector records scheduling of parts besides requests
+type transferCollector struct {
+	recordCollector
+	parts []partQueueRecord
+}
+
+func (c *transferCollector) RecordPartScheduled(operation string, wait time.Duration, queueDepth int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.parts = append(c.parts, partQueueRecord{operation: operation, queueDepth: queueDepth})
+}
+
+func TestUploadFilePartQueueMetrics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-upload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	require.Nil(t, ioutil.WriteFile(file, make([]byte, 3*MinPartSize), 0666))
+
+	collector := &transferCollector{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&multipartTransport{failParts: 1}),
+		WithMetricsCollector(collector))
+	require.Nil(t, err)
+	_, err = client.UploadFile(context.Background(), &UploadFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:                     file,
+		PartSize:                     MinPartSize,
+		PartRetryPolicy:              PartRetryPolicy{MaxReschedules: 1},
+	})
+	require.Nil(t, err)
+	// 3 parts and 1 rescheduled part are picked up, all parts are queued when the transfer starts
+	require.Len(t, collector.parts, 4)
+	require.Equal(t, partQueueRecord{operation: "UploadFile", queueDepth: 2}, collector.parts[0])
+	require.Equal(t, 0, collector.parts[3].queueDepth)
+}
@@ -0,0 +1,37 @@
+package tos
+
+import (
+	"context"
+)
+
+// RoundTripFunc sends a request and returns its response
+type RoundTripFunc func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps the signing and sending of requests, set by WithMiddleware.
+//
+// It's called for each attempt of a request, including retries and redirects.
+// The request is not signed yet when it's passed to middlewares, so they can change it, e.g. add custom auth headers,
+// and next signs and sends it. A middleware can also return a response or an error without calling next,
+// e.g. for caching or chaos injection. next returns TosServerError for unexpected status codes,
+// while responses returned by middlewares without calling next are returned to caller as is.
+//
+// example:
+//
+//	func withHeader(key, value string) tos.Middleware {
+//		return func(next tos.RoundTripFunc) tos.RoundTripFunc {
+//			return func(ctx context.Context, req *tos.Request) (*tos.Response, error) {
+//				req.Header.Set(key, value)
+//				return next(ctx, req)
+//			}
+//		}
+//	}
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chainMiddlewares wrap next with middlewares, the first one is the outermost
+func chainMiddlewares(middlewares []Middleware, next roundTripper) roundTripper {
+	handler := RoundTripFunc(next)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return roundTripper(handler)
+}
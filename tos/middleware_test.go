@@ -0,0 +1,58 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	transport := &recordTransport{res: newRecordResponse(http.StatusOK, "")}
+	var (
+		order    []string
+		failures = 1
+	)
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				order = append(order, name)
+				return next(ctx, req)
+			}
+		}
+	}
+	addHeader := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			require.Equal(t, "", req.Header.Get(authorization))
+			req.Header.Set("X-Tos-Custom", "value")
+			return next(ctx, req)
+		}
+	}
+	chaos := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if failures > 0 {
+				failures--
+				return nil, newTosServerError(&Response{StatusCode: http.StatusServiceUnavailable, Header: make(http.Header),
+					Body: ioutil.NopCloser(strings.NewReader(""))})
+			}
+			return next(ctx, req)
+		}
+	}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithRegion("test-region"),
+		WithCredentials(NewStaticCredentials("ak", "sk")), WithMaxRetryCount(1), WithRetryBackoff(time.Millisecond, time.Millisecond),
+		WithMiddleware(trace("outer"), trace("inner")), WithMiddleware(addHeader, chaos))
+	require.Nil(t, err)
+
+	_, err = client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	// called for each attempt
+	require.Equal(t, []string{"outer", "inner", "outer", "inner"}, order)
+	require.Len(t, transport.requests, 1)
+	req := transport.requests[0]
+	require.Equal(t, "value", req.Header.Get("X-Tos-Custom"))
+	require.Contains(t, req.Header.Get(authorization), "x-tos-custom")
+}
@@ -0,0 +1,118 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+const maxMirrorBackRules = 20
+
+// PutBucketMirrorBackV2 replace mirror-back rules of a bucket, objects not found in the bucket are fetched from
+// the source of the first matching rule
+func (cli *ClientV2) PutBucketMirrorBackV2(ctx context.Context, input *PutBucketMirrorBackV2Input, options ...Option) (*PutBucketMirrorBackV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := validateMirrorBackRules(input.Rules); err != nil {
+		return nil, err
+	}
+	in, contentMD5, err := marshalInput("PutBucketMirrorBackV2Input", struct {
+		Rules []MirrorBackRule `json:"Rules"`
+	}{input.Rules})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketMirrorBack).
+		WithQuery("mirror", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketMirrorBackV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketMirrorBackV2 get mirror-back rules of a bucket
+func (cli *ClientV2) GetBucketMirrorBackV2(ctx context.Context, input *GetBucketMirrorBackV2Input, options ...Option) (*GetBucketMirrorBackV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketMirrorBack).
+		WithQuery("mirror", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketMirrorBackV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketMirrorBackV2 delete all mirror-back rules of a bucket
+func (cli *ClientV2) DeleteBucketMirrorBackV2(ctx context.Context, input *DeleteBucketMirrorBackV2Input, options ...Option) (*DeleteBucketMirrorBackV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketMirrorBack).
+		WithQuery("mirror", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketMirrorBackV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+func validateMirrorBackRules(rules []MirrorBackRule) error {
+	if len(rules) == 0 || len(rules) > maxMirrorBackRules {
+		return newTosClientError(fmt.Sprintf("tos: number of mirror-back rules must be [1, %d]", maxMirrorBackRules), nil)
+	}
+	ids := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		invalid := func(reason string) error {
+			return newTosClientError(fmt.Sprintf("tos: invalid mirror-back rule %q, %s", rule.ID, reason), nil)
+		}
+		if len(rule.ID) == 0 {
+			return newTosClientError("tos: ID of mirror-back rule is required", nil)
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return invalid("duplicate ID")
+		}
+		ids[rule.ID] = struct{}{}
+		if rule.Condition.HttpCode != http.StatusNotFound {
+			return invalid("HttpCode of condition must be 404")
+		}
+		switch rule.Redirect.RedirectType {
+		case enum.MirrorBackRedirectMirror, enum.MirrorBackRedirectAsync:
+		default:
+			return invalid("RedirectType must be Mirror or Async")
+		}
+		endpoint := rule.Redirect.PublicSource.SourceEndpoint
+		if len(endpoint.Primary) == 0 {
+			return invalid("no primary source endpoint is set")
+		}
+		for _, sources := range [][]string{endpoint.Primary, endpoint.Follower} {
+			for _, source := range sources {
+				if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+					return invalid("source endpoint " + source + " must start with 'http://' or 'https://'")
+				}
+			}
+		}
+	}
+	return nil
+}
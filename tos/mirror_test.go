@@ -0,0 +1,73 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketMirrorBackV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	rule := MirrorBackRule{
+		ID:        "migrate",
+		Condition: MirrorBackCondition{HttpCode: http.StatusNotFound, KeyPrefix: "images/"},
+		Redirect: MirrorBackRedirect{
+			RedirectType:   enum.MirrorBackRedirectMirror,
+			PassQuery:      true,
+			FollowRedirect: true,
+			PublicSource: MirrorBackPublicSource{SourceEndpoint: MirrorBackSourceEndpoint{
+				Primary: []string{"https://origin.example.com"}}},
+		},
+	}
+	_, err := client.PutBucketMirrorBackV2(context.Background(), &PutBucketMirrorBackV2Input{Bucket: "bucket",
+		Rules: []MirrorBackRule{rule}})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "mirror")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Rules":[{"ID":"migrate","Condition":{"HttpCode":404,"KeyPrefix":"images/"},
+"Redirect":{"RedirectType":"Mirror","FetchSourceOnRedirect":false,"PassQuery":true,"FollowRedirect":true,
+"PublicSource":{"SourceEndpoint":{"Primary":["https://origin.example.com"]}}}}]}`, string(data))
+
+	transport.respond(http.StatusOK, `{"Rules":[{"ID":"async","Condition":{"HttpCode":404},
+"Redirect":{"RedirectType":"Async","PublicSource":{"SourceEndpoint":{"Primary":["https://a.example.com"],
+"Follower":["https://b.example.com"]}}}}]}`)
+	out, err := client.GetBucketMirrorBackV2(context.Background(), &GetBucketMirrorBackV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, []MirrorBackRule{{
+		ID:        "async",
+		Condition: MirrorBackCondition{HttpCode: http.StatusNotFound},
+		Redirect: MirrorBackRedirect{
+			RedirectType: enum.MirrorBackRedirectAsync,
+			PublicSource: MirrorBackPublicSource{SourceEndpoint: MirrorBackSourceEndpoint{
+				Primary: []string{"https://a.example.com"}, Follower: []string{"https://b.example.com"}}},
+		},
+	}}, out.Rules)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "mirror")
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketMirrorBackV2(context.Background(), &DeleteBucketMirrorBackV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+
+	badCode := rule
+	badCode.Condition.HttpCode = http.StatusForbidden
+	noSource := rule
+	noSource.Redirect.PublicSource = MirrorBackPublicSource{}
+	badSource := rule
+	badSource.Redirect.PublicSource.SourceEndpoint.Follower = []string{"origin.example.com"}
+	for _, rules := range [][]MirrorBackRule{nil, {rule, rule}, {badCode}, {noSource}, {badSource}, {{ID: "a"}}} {
+		_, err = client.PutBucketMirrorBackV2(context.Background(), &PutBucketMirrorBackV2Input{Bucket: "bucket",
+			Rules: rules})
+		require.NotNil(t, err)
+	}
+	require.Len(t, transport.requests, 3)
+}
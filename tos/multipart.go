@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"hash"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"sort"
@@ -35,6 +36,7 @@ func (bkt *Bucket) CreateMultipartUpload(ctx context.Context, objectKey string,
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, objectKey, options...).
+		WithOperation(OperationCreateMultipartUpload).
 		WithQuery("uploads", "").
 		Request(ctx, http.MethodPost, nil, bkt.client.roundTripper(http.StatusOK))
 	if err != nil {
@@ -60,18 +62,25 @@ func (bkt *Bucket) CreateMultipartUpload(ctx context.Context, objectKey string,
 // CreateMultipartUploadV2 create a multipart upload operation
 func (cli *ClientV2) CreateMultipartUploadV2(
 	ctx context.Context,
-	input *CreateMultipartUploadV2Input) (*CreateMultipartUploadV2Output, error) {
+	input *CreateMultipartUploadV2Input, options ...Option) (*CreateMultipartUploadV2Output, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
 	if err := isValidKey(input.Key); err != nil {
 		return nil, err
 	}
+	if err := isValidWebsiteRedirectLocation(input.WebsiteRedirectLocation); err != nil {
+		return nil, err
+	}
 
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	rb := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationCreateMultipartUpload).
 		WithQuery("uploads", "").
-		WithParams(*input).
-		WithRetry(nil, ServerErrorClassifier{}).
+		WithParams(*input)
+	if err := rb.withTagSet(input.Tagging, input.TagSet); err != nil {
+		return nil, err
+	}
+	res, err := rb.WithRetry(nil, ServerErrorClassifier{}).
 		Request(ctx, http.MethodPost, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
@@ -106,6 +115,7 @@ func (bkt *Bucket) UploadPart(ctx context.Context, input *UploadPartInput, optio
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, input.Key, options...).
+		WithOperation(OperationUploadPart).
 		WithQuery("uploadId", input.UploadID).
 		WithQuery("partNumber", strconv.Itoa(input.PartNumber)).
 		Request(ctx, http.MethodPut, input.Content, bkt.client.roundTripper(http.StatusOK))
@@ -124,45 +134,59 @@ func (bkt *Bucket) UploadPart(ctx context.Context, input *UploadPartInput, optio
 }
 
 // UploadPartV2 upload a part for a multipart upload operation
-func (cli *ClientV2) UploadPartV2(ctx context.Context, input *UploadPartV2Input) (*UploadPartV2Output, error) {
+func (cli *ClientV2) UploadPartV2(ctx context.Context, input *UploadPartV2Input, options ...Option) (*UploadPartV2Output, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
 	var (
 		checker       hash.Hash64
-		content       = input.Content
 		contentLength = input.ContentLength
 	)
+	body, err := newUploadBody(input.Content, input.ContentProvider, cli.retryBufferSize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.close()
+	raw, err := body.open()
+	if err != nil {
+		return nil, err
+	}
 	if contentLength == 0 {
-		contentLength = tryResolveLength(content)
+		contentLength = tryResolveLength(raw)
 	}
 	if cli.enableCRC {
 		checker = NewCRC(DefaultCrcTable(), 0)
 	}
+	object := input.object
+	if object == nil {
+		object = &TransferObject{Bucket: input.Bucket, Key: input.Key, Size: -1}
+	}
+	wrap := func(raw io.Reader) io.Reader {
+		if checker != nil {
+			checker.Reset()
+		}
+		return cli.limitReader(wrapReader(raw, contentLength, input.DataTransferListener, input.RateLimiter, checker, object), object)
+	}
+	content := wrap(raw)
 	var (
-		onRetry    func(req *Request) = nil
-		classifier classifier
+		onRetry    func(req *Request)
+		classifier Classifier = StatusCodeClassifier{}
 	)
-	content = wrapReader(content, contentLength, input.DataTransferListener, input.RateLimiter, checker)
-	classifier = StatusCodeClassifier{}
-	if seeker, ok := content.(io.Seeker); ok {
-		start, err := seeker.Seek(0, io.SeekCurrent)
-		if err != nil {
-			return nil, err
-		}
-		onRetry = func(req *Request) {
-			// PutObject/UploadPartV2 can be treated as an idempotent semantics if the request message body
-			// supports a reset operation. e.g. the request message body is a string,
-			// a local file handle, binary data in memory
-			if seeker, ok := req.Content.(io.Seeker); ok {
-				seeker.Seek(start, io.SeekStart)
+	// UploadPartV2 can be treated as an idempotent semantics if the request message body can be read again,
+	// otherwise the request is not retried
+	if body.rewindable() {
+		onRetry = body.retry(func() (io.Reader, error) {
+			raw, err := body.open()
+			if err != nil {
+				return nil, err
 			}
-		}
+			return wrap(raw), nil
+		})
+	} else {
+		classifier = NoRetryClassifier{}
 	}
-	if onRetry == nil {
-		classifier = ServerErrorClassifier{}
-	}
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationUploadPart).
 		WithParams(*input).
 		WithContentLength(input.ContentLength).
 		WithRetry(onRetry, classifier).
@@ -186,7 +210,7 @@ func (cli *ClientV2) UploadPartV2(ctx context.Context, input *UploadPartV2Input)
 }
 
 // UploadPartFromFile upload a part for a multipart upload operation from file
-func (cli *ClientV2) UploadPartFromFile(ctx context.Context, input *UploadPartFromFileInput) (*UploadPartFromFileOutput, error) {
+func (cli *ClientV2) UploadPartFromFile(ctx context.Context, input *UploadPartFromFileInput, options ...Option) (*UploadPartFromFileOutput, error) {
 	file, err := os.Open(input.FilePath)
 	if err != nil {
 		return nil, err
@@ -200,7 +224,7 @@ func (cli *ClientV2) UploadPartFromFile(ctx context.Context, input *UploadPartFr
 		UploadPartBasicInput: input.UploadPartBasicInput,
 		Content:              file,
 		ContentLength:        input.PartSize,
-	})
+	}, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -229,6 +253,7 @@ func (bkt *Bucket) CompleteMultipartUpload(ctx context.Context, input *CompleteM
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, input.Key, options...).
+		WithOperation(OperationCompleteMultipartUpload).
 		WithQuery("uploadId", input.UploadID).
 		Request(ctx, http.MethodPost, bytes.NewReader(data), bkt.client.roundTripper(http.StatusOK))
 	if err != nil {
@@ -244,7 +269,7 @@ func (bkt *Bucket) CompleteMultipartUpload(ctx context.Context, input *CompleteM
 
 // CompleteMultipartUploadV2 complete a multipart upload operation
 func (cli *ClientV2) CompleteMultipartUploadV2(
-	ctx context.Context, input *CompleteMultipartUploadV2Input) (*CompleteMultipartUploadV2Output, error) {
+	ctx context.Context, input *CompleteMultipartUploadV2Input, options ...Option) (*CompleteMultipartUploadV2Output, error) {
 
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
@@ -260,9 +285,19 @@ func (cli *ClientV2) CompleteMultipartUploadV2(
 		return nil, newTosClientError("tos: marshal uploadParts", err)
 	}
 
-	res, err := cli.newBuilder(input.Bucket, input.Key).
-		WithParams(*input).
-		WithRetry(nil, ServerErrorClassifier{}).
+	var classifier Classifier = ServerErrorClassifier{}
+	rb := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationCompleteMultipartUpload).
+		WithParams(*input)
+	if input.ForbidOverwrite {
+		// a retry of a completion which succeeded fails
+		classifier = NoRetryClassifier{}
+		rb.WithHeader(HeaderForbidOverwrite, "true")
+	}
+	if err = rb.withCallback(input.Callback, input.CallbackVar); err != nil {
+		return nil, err
+	}
+	res, err := rb.WithRetry(nil, classifier).
 		Request(ctx, http.MethodPost, bytes.NewReader(data), cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
@@ -275,6 +310,16 @@ func (cli *ClientV2) CompleteMultipartUploadV2(
 		VersionID:     res.Header.Get(HeaderVersionID),
 		HashCrc64ecma: crc64,
 	}
+	if input.Callback != nil {
+		// the body is the response of application server instead
+		result, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, newTosClientError("tos: read callback result failed", err)
+		}
+		output.ETag = res.Header.Get(HeaderETag)
+		output.CallbackResult = string(result)
+		return output, nil
+	}
 	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
 		return nil, err
 	}
@@ -289,6 +334,7 @@ func (bkt *Bucket) AbortMultipartUpload(ctx context.Context, input *AbortMultipa
 		return nil, err
 	}
 	res, err := bkt.client.newBuilder(bkt.name, input.Key, options...).
+		WithOperation(OperationAbortMultipartUpload).
 		WithQuery("uploadId", input.UploadID).
 		Request(ctx, http.MethodDelete, nil, bkt.client.roundTripper(http.StatusNoContent))
 	if err != nil {
@@ -300,11 +346,12 @@ func (bkt *Bucket) AbortMultipartUpload(ctx context.Context, input *AbortMultipa
 }
 
 // AbortMultipartUpload abort a multipart upload operation
-func (cli *ClientV2) AbortMultipartUpload(ctx context.Context, input *AbortMultipartUploadInput) (*AbortMultipartUploadOutput, error) {
+func (cli *ClientV2) AbortMultipartUpload(ctx context.Context, input *AbortMultipartUploadInput, options ...Option) (*AbortMultipartUploadOutput, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationAbortMultipartUpload).
 		WithParams(*input).
 		WithRetry(nil, ServerErrorClassifier{}).
 		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
@@ -326,6 +373,7 @@ func (bkt *Bucket) ListUploadedParts(ctx context.Context, input *ListUploadedPar
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, input.Key, options...).
+		WithOperation(OperationListParts).
 		WithQuery("uploadId", input.UploadID).
 		WithQuery("max-parts", strconv.Itoa(input.MaxParts)).
 		WithQuery("part-number-marker", strconv.Itoa(input.PartNumberMarker)).
@@ -343,11 +391,12 @@ func (bkt *Bucket) ListUploadedParts(ctx context.Context, input *ListUploadedPar
 }
 
 // ListParts List Uploaded Parts
-func (cli *ClientV2) ListParts(ctx context.Context, input *ListPartsInput) (*ListPartsOutput, error) {
+func (cli *ClientV2) ListParts(ctx context.Context, input *ListPartsInput, options ...Option) (*ListPartsOutput, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationListParts).
 		WithParams(*input).
 		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
@@ -367,6 +416,7 @@ func (cli *ClientV2) ListParts(ctx context.Context, input *ListPartsInput) (*Lis
 // Deprecated: use ListMultipartUploads of ClientV2 instead
 func (bkt *Bucket) ListMultipartUploads(ctx context.Context, input *ListMultipartUploadsInput, options ...Option) (*ListMultipartUploadsOutput, error) {
 	res, err := bkt.client.newBuilder(bkt.name, "", options...).
+		WithOperation(OperationListMultipartUploads).
 		WithQuery("uploads", "").
 		WithQuery("prefix", input.Prefix).
 		WithQuery("delimiter", input.Delimiter).
@@ -389,11 +439,12 @@ func (bkt *Bucket) ListMultipartUploads(ctx context.Context, input *ListMultipar
 // ListMultipartUploadsV2 list multipart uploads
 func (cli *ClientV2) ListMultipartUploadsV2(
 	ctx context.Context,
-	input *ListMultipartUploadsV2Input) (*ListMultipartUploadsV2Output, error) {
+	input *ListMultipartUploadsV2Input, options ...Option) (*ListMultipartUploadsV2Output, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
-	res, err := cli.newBuilder(input.Bucket, "").
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationListMultipartUploads).
 		WithQuery("uploads", "").
 		WithParams(*input).
 		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
@@ -0,0 +1,36 @@
+package tos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+)
+
+// retryableErrnos errors of connections which are safe to retry for idempotent operations
+var retryableErrnos = []syscall.Errno{syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.ECONNABORTED, syscall.EPIPE}
+
+// isRetryableNetworkError report whether err, which may be wrapped by TosClientError, is a transient network error:
+// connection reset or refused, unexpected EOF, dial or TLS handshake timeout, and temporary DNS errors.
+// Errors caused by canceling or timeout of the context of caller are not retryable.
+func isRetryableNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	for _, errno := range retryableErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	// dial timeouts, TLS handshake timeouts of http.Transport, and responseHeaderTimeoutError
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
@@ -0,0 +1,71 @@
+package tos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableNetworkError(t *testing.T) {
+	wrap := func(err error) error {
+		return newTosClientError(err.Error(), &url.Error{Op: "Get", URL: "https://host", Err: err})
+	}
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	dialTimeout := &net.OpError{Op: "dial", Net: "tcp", Err: &TimeoutErr{timeout: true}}
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{wrap(reset), true},
+		{wrap(io.EOF), true},
+		{wrap(io.ErrUnexpectedEOF), true},
+		{wrap(dialTimeout), true},
+		{wrap(&net.DNSError{Err: "server misbehaving", IsTemporary: true}), true},
+		{wrap(&net.DNSError{Err: "no such host"}), false},
+		{newTosClientError("tos: timeout awaiting response headers", responseHeaderTimeoutError{}), true},
+		{wrap(context.Canceled), false},
+		{wrap(context.DeadlineExceeded), false},
+		{wrap(errors.New("unsupported protocol scheme")), false},
+		{TosStatus500, false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.retryable, isRetryableNetworkError(test.err), test.err.Error())
+		if test.retryable {
+			require.Equal(t, Retry, StatusCodeClassifier{}.Classify(test.err))
+			require.Equal(t, NoRetry, ServerErrorClassifier{}.Classify(test.err))
+		}
+	}
+}
+
+// resetTransport resets the first failures connections
+type resetTransport struct {
+	failures int
+}
+
+func (rt *resetTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	if rt.failures > 0 {
+		rt.failures--
+		err := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+		return nil, newTosClientError(err.Error(), err)
+	}
+	return &Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestRetryConnectionReset(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&resetTransport{failures: 1}),
+		WithMaxRetryCount(1), WithRetryBackoff(time.Millisecond, time.Millisecond))
+	require.Nil(t, err)
+	_, err = client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+}
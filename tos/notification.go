@@ -0,0 +1,150 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// PutBucketNotificationV2 replace notification rules of a bucket, rules are removed if Rules is empty
+func (cli *ClientV2) PutBucketNotificationV2(ctx context.Context, input *PutBucketNotificationV2Input, options ...Option) (*PutBucketNotificationV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := validateNotificationRules(input.Rules); err != nil {
+		return nil, err
+	}
+	rules := input.Rules
+	if rules == nil {
+		rules = []NotificationRule{}
+	}
+	in, contentMD5, err := marshalInput("PutBucketNotificationV2Input", struct {
+		Rules []NotificationRule `json:"Rules"`
+	}{rules})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketNotification).
+		WithQuery("notification_v2", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketNotificationV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketNotificationV2 get notification rules of a bucket
+func (cli *ClientV2) GetBucketNotificationV2(ctx context.Context, input *GetBucketNotificationV2Input, options ...Option) (*GetBucketNotificationV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketNotification).
+		WithQuery("notification_v2", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketNotificationV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+func validateNotificationRules(rules []NotificationRule) error {
+	ids := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		invalid := func(reason string) error {
+			return newTosClientError(fmt.Sprintf("tos: invalid notification rule %q, %s", rule.RuleID, reason), nil)
+		}
+		if len(rule.RuleID) == 0 {
+			return newTosClientError("tos: RuleID of notification rule is required", nil)
+		}
+		if _, ok := ids[rule.RuleID]; ok {
+			return invalid("duplicate RuleID")
+		}
+		ids[rule.RuleID] = struct{}{}
+		if len(rule.Events) == 0 {
+			return invalid("no event is set")
+		}
+		if len(rule.Destination.RocketMQ) == 0 && len(rule.Destination.VeFaaS) == 0 {
+			return invalid("no destination is set")
+		}
+		if rule.Filter == nil {
+			continue
+		}
+		names := make(map[string]struct{}, 2)
+		for _, filter := range rule.Filter.TOSKey.FilterRules {
+			if filter.Name != "prefix" && filter.Name != "suffix" {
+				return invalid("name of filter must be prefix or suffix")
+			}
+			if _, ok := names[filter.Name]; ok {
+				return invalid("duplicate " + filter.Name + " filter")
+			}
+			names[filter.Name] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// notificationMessage the payload of notification messages
+type notificationMessage struct {
+	Events []struct {
+		EventName         enum.NotificationEventType `json:"eventName"`
+		EventSource       string                     `json:"eventSource"`
+		EventTime         time.Time                  `json:"eventTime"`
+		EventVersion      string                     `json:"eventVersion"`
+		Region            string                     `json:"region"`
+		RequestParameters struct {
+			SourceIPAddress string `json:"sourceIPAddress"`
+		} `json:"requestParameters"`
+		ResponseElements struct {
+			RequestID string `json:"requestId"`
+		} `json:"responseElements"`
+		UserIdentity struct {
+			PrincipalID string `json:"principalId"`
+		} `json:"userIdentity"`
+		TOS struct {
+			RuleID string                  `json:"ruleId"`
+			Bucket NotificationEventBucket `json:"bucket"`
+			Object NotificationEventObject `json:"object"`
+		} `json:"tos"`
+	} `json:"events"`
+}
+
+// ParseNotificationMessage parse the payload of a message delivered to destinations of bucket notification
+func ParseNotificationMessage(payload []byte) (*NotificationMessage, error) {
+	var message notificationMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, newTosClientError("tos: unmarshal notification message failed", err)
+	}
+	output := NotificationMessage{Events: make([]NotificationEvent, 0, len(message.Events))}
+	for _, event := range message.Events {
+		output.Events = append(output.Events, NotificationEvent{
+			EventName:    event.EventName,
+			EventSource:  event.EventSource,
+			EventTime:    event.EventTime,
+			EventVersion: event.EventVersion,
+			Region:       event.Region,
+			RuleID:       event.TOS.RuleID,
+			RequestID:    event.ResponseElements.RequestID,
+			PrincipalID:  event.UserIdentity.PrincipalID,
+			SourceIP:     event.RequestParameters.SourceIPAddress,
+			Bucket:       event.TOS.Bucket,
+			Object:       event.TOS.Object,
+		})
+	}
+	return &output, nil
+}
@@ -0,0 +1,94 @@
+package tos
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketNotificationV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	rule := NotificationRule{
+		RuleID: "images",
+		Events: []enum.NotificationEventType{enum.NotificationEventObjectCreatedAll},
+		Filter: &NotificationFilter{TOSKey: NotificationFilterKey{FilterRules: []NotificationFilterRule{
+			{Name: "prefix", Value: "images/"}, {Name: "suffix", Value: ".jpg"}}}},
+		Destination: NotificationDestination{VeFaaS: []VeFaaSDestination{{FunctionID: "function"}}},
+	}
+	_, err := client.PutBucketNotificationV2(context.Background(), &PutBucketNotificationV2Input{Bucket: "bucket",
+		Rules: []NotificationRule{rule}})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "notification_v2")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Rules":[{"RuleId":"images","Events":["tos:ObjectCreated:*"],"Filter":{"TOSKey":{"FilterRules":[
+{"Name":"prefix","Value":"images/"},{"Name":"suffix","Value":".jpg"}]}},
+"Destination":{"VeFaaS":[{"FunctionId":"function"}]}}]}`, string(data))
+
+	transport.respond(http.StatusOK, `{"Rules":[{"RuleId":"logs","Events":["tos:ObjectRemoved:*"],
+"Destination":{"RocketMQ":[{"Role":"trn:iam::2100000001:role/tos","InstanceId":"instance","Topic":"topic",
+"AccessKeyId":"ak"}]}}]}`)
+	out, err := client.GetBucketNotificationV2(context.Background(), &GetBucketNotificationV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, []NotificationRule{{
+		RuleID: "logs",
+		Events: []enum.NotificationEventType{enum.NotificationEventObjectRemovedAll},
+		Destination: NotificationDestination{RocketMQ: []RocketMQDestination{{Role: "trn:iam::2100000001:role/tos",
+			InstanceID: "instance", Topic: "topic", AccessKeyID: "ak"}}},
+	}}, out.Rules)
+	require.Contains(t, transport.requests[1].Query, "notification_v2")
+
+	// removing all rules sends an empty list
+	_, err = client.PutBucketNotificationV2(context.Background(), &PutBucketNotificationV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	data, err = ioutil.ReadAll(transport.requests[2].Content)
+	require.Nil(t, err)
+	var body map[string]interface{}
+	require.Nil(t, json.Unmarshal(data, &body))
+	require.Equal(t, []interface{}{}, body["Rules"])
+
+	noDestination := rule
+	noDestination.Destination = NotificationDestination{}
+	badFilter := rule
+	badFilter.Filter = &NotificationFilter{TOSKey: NotificationFilterKey{FilterRules: []NotificationFilterRule{
+		{Name: "contains", Value: "a"}}}}
+	for _, rules := range [][]NotificationRule{{rule, rule}, {noDestination}, {badFilter}, {{RuleID: "a"}}} {
+		_, err = client.PutBucketNotificationV2(context.Background(), &PutBucketNotificationV2Input{Bucket: "bucket",
+			Rules: rules})
+		require.NotNil(t, err)
+	}
+	require.Len(t, transport.requests, 3)
+}
+
+func TestParseNotificationMessage(t *testing.T) {
+	message, err := ParseNotificationMessage([]byte(`{"events":[{"eventName":"tos:ObjectCreated:Put",
+"eventSource":"tos","eventTime":"2024-04-19T08:00:00.000Z","eventVersion":"1.0","region":"cn-beijing",
+"requestParameters":{"sourceIPAddress":"10.0.0.1"},"responseElements":{"requestId":"request"},
+"userIdentity":{"principalId":"2100000001"},"tos":{"ruleId":"images",
+"bucket":{"name":"bucket","trn":"trn:tos:::bucket","ownerIdentify":"2100000001"},
+"object":{"key":"images/a.jpg","eTag":"\"etag\"","size":1024,"versionId":"v1"}}}]}`))
+	require.Nil(t, err)
+	require.Len(t, message.Events, 1)
+	event := message.Events[0]
+	require.Equal(t, enum.NotificationEventObjectCreatedPut, event.EventName)
+	require.Equal(t, time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC), event.EventTime.UTC())
+	require.Equal(t, "images", event.RuleID)
+	require.Equal(t, "request", event.RequestID)
+	require.Equal(t, "2100000001", event.PrincipalID)
+	require.Equal(t, "10.0.0.1", event.SourceIP)
+	require.Equal(t, "bucket", event.Bucket.Name)
+	require.Equal(t, NotificationEventObject{Key: "images/a.jpg", ETag: `"etag"`, Size: 1024, VersionID: "v1"},
+		event.Object)
+
+	_, err = ParseNotificationMessage([]byte("not json"))
+	require.NotNil(t, err)
+}
@@ -11,7 +11,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 type Bucket struct {
@@ -31,7 +33,8 @@ func (bkt *Bucket) GetObject(ctx context.Context, objectKey string, options ...O
 	if err := isValidKey(objectKey); err != nil {
 		return nil, err
 	}
-	rb := bkt.client.newBuilder(bkt.name, objectKey, options...)
+	rb := bkt.client.newBuilder(bkt.name, objectKey, options...).
+		WithOperation(OperationGetObject)
 	res, err := rb.Request(ctx, http.MethodGet, nil, bkt.client.roundTripper(expectedCode(rb)))
 	if err != nil {
 		return nil, err
@@ -45,37 +48,62 @@ func (bkt *Bucket) GetObject(ctx context.Context, objectKey string, options ...O
 	return &output, nil
 }
 
-// GetObjectToFile get object and write it to file
-func (cli *ClientV2) GetObjectToFile(ctx context.Context, input *GetObjectToFileInput) (*GetObjectToFileOutput, error) {
-	tempFilePath := input.FilePath + TempFileSuffix
-	fd, err := os.OpenFile(tempFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, DefaultFilePerm)
-	if err != nil {
-		return nil, err
+// GetObjectToFile get object and write it to input.FilePath, parent directories are created if they don't exist.
+// The object is written to a temp file in the same directory, or in TempFileDir of ClientV2, and renamed to FilePath
+// once it's complete, so FilePath is either untouched or complete, and concurrent downloads never mix their content.
+//
+// Use DownloadFile for large objects, which downloads concurrently and can be resumed.
+func (cli *ClientV2) GetObjectToFile(ctx context.Context, input *GetObjectToFileInput, options ...Option) (*GetObjectToFileOutput, error) {
+	if len(input.FilePath) == 0 {
+		return nil, newTosClientError("tos: empty file path of GetObjectToFile", nil)
+	}
+	if stat, err := os.Stat(input.FilePath); err == nil && stat.IsDir() {
+		return nil, newTosClientError("tos: file path of GetObjectToFile is a directory", nil)
 	}
-	defer fd.Close()
-	get, err := cli.GetObjectV2(ctx, &input.GetObjectV2Input)
+	get, err := cli.GetObjectV2(ctx, &input.GetObjectV2Input, options...)
 	if err != nil {
 		return nil, err
 	}
-	_, err = io.Copy(fd, get.Content)
+	defer get.Content.Close()
+	if err = os.MkdirAll(filepath.Dir(input.FilePath), 0755); err != nil {
+		return nil, newTosClientError("tos: create parent directories failed", err)
+	}
+	tempFilePath := cli.tempFilePath(input.FilePath, input.Bucket, input.Key)
+	// the temp file is created exclusively, so concurrent downloads to the same path don't share it
+	fd, err := ioutil.TempFile(filepath.Dir(tempFilePath), filepath.Base(tempFilePath)+".*")
 	if err != nil {
-		return nil, err
+		return nil, newTosClientError("tos: create temp file failed", err)
+	}
+	err = func() error {
+		defer fd.Close()
+		if err := fd.Chmod(DefaultFilePerm); err != nil {
+			return err
+		}
+		_, err := io.Copy(fd, get.Content)
+		return err
+	}()
+	if err == nil {
+		err = os.Rename(fd.Name(), input.FilePath)
 	}
-	err = os.Rename(tempFilePath, input.FilePath)
 	if err != nil {
+		_ = os.Remove(fd.Name())
 		return nil, err
 	}
 	return &GetObjectToFileOutput{get.GetObjectBasicOutput}, nil
 }
 
 // GetObjectV2 get data and metadata of an object
-func (cli *ClientV2) GetObjectV2(ctx context.Context, input *GetObjectV2Input) (*GetObjectV2Output, error) {
+func (cli *ClientV2) GetObjectV2(ctx context.Context, input *GetObjectV2Input, options ...Option) (*GetObjectV2Output, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
-	rb := cli.newBuilder(input.Bucket, input.Key).
+	rb := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationGetObject).
 		WithQuery("versionId", input.VersionID).
 		WithParams(*input)
+	if len(input.Process) > 0 {
+		rb.WithQuery(QueryProcess, input.Process)
+	}
 	if input.RangeEnd != 0 || input.RangeStart != 0 {
 		if input.RangeEnd < input.RangeStart {
 			return nil, errors.New("tos: invalid range")
@@ -93,9 +121,42 @@ func (cli *ClientV2) GetObjectV2(ctx context.Context, input *GetObjectV2Input) (
 		ContentRange: res.Header.Get(HeaderContentRange),
 	}
 	basic.ObjectMetaV2.fromResponseV2(res)
+	pipeline := NewReaderPipeline()
+	decompress := input.DecompressGzip && rb.Range == nil &&
+		strings.EqualFold(strings.TrimSpace(res.Header.Get(HeaderContentEncoding)), CodecGzip)
+	// processed or decompressed content doesn't match CRC of the object
+	if cli.enableCRC && rb.Range == nil && len(input.Process) == 0 && !decompress {
+		pipeline.Append(ReaderStageCRC, crcStage(res, NewCRC(DefaultCrcTable(), 0)))
+	}
+	object := &TransferObject{Bucket: input.Bucket, Key: input.Key, Size: objectSize(res), StorageClass: basic.StorageClass}
+	if decompress {
+		pipeline.Append(ReaderStageDecompress, decodeStage(gzipCodec{}))
+	}
+	if name := res.Header.Get(HeaderMetaCodec); input.DecodeContent && len(name) > 0 {
+		codec, err := lookupCodec(name)
+		if err != nil {
+			res.Close()
+			return nil, err
+		}
+		pipeline.Append(ReaderStageDecode, decodeStage(codec))
+	}
+	if input.DataTransferListener != nil {
+		totalBytes := res.ContentLength
+		if pipeline.index(ReaderStageDecompress) >= 0 || pipeline.index(ReaderStageDecode) >= 0 {
+			// size of decompressed content is unknown
+			totalBytes = -1
+		}
+		pipeline.Append(ReaderStageListener, listenerStage(input.DataTransferListener, totalBytes, object))
+	}
+	if input.RateLimiter != nil || cli.rateLimiter != nil {
+		pipeline.Append(ReaderStageLimiter, limiterStage(object, input.RateLimiter, cli.rateLimiter))
+	}
+	if input.ReaderPipelineHook != nil {
+		input.ReaderPipelineHook(pipeline)
+	}
 	output := GetObjectV2Output{
 		GetObjectBasicOutput: basic,
-		Content:              wrapReader(res.Body, res.ContentLength, input.DataTransferListener, input.RateLimiter, nil),
+		Content:              pipeline.Build(res.Body),
 	}
 	return &output, nil
 }
@@ -113,7 +174,8 @@ func (bkt *Bucket) HeadObject(ctx context.Context, objectKey string, options ...
 		return nil, err
 	}
 
-	rb := bkt.client.newBuilder(bkt.name, objectKey, options...)
+	rb := bkt.client.newBuilder(bkt.name, objectKey, options...).
+		WithOperation(OperationHeadObject)
 	res, err := rb.Request(ctx, http.MethodHead, nil, bkt.client.roundTripper(expectedCode(rb)))
 	if err != nil {
 		return nil, err
@@ -129,12 +191,13 @@ func (bkt *Bucket) HeadObject(ctx context.Context, objectKey string, options ...
 }
 
 // HeadObjectV2 get metadata of an object
-func (cli *ClientV2) HeadObjectV2(ctx context.Context, input *HeadObjectV2Input) (*HeadObjectV2Output, error) {
+func (cli *ClientV2) HeadObjectV2(ctx context.Context, input *HeadObjectV2Input, options ...Option) (*HeadObjectV2Output, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
 
-	rb := cli.newBuilder(input.Bucket, input.Key).
+	rb := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationHeadObject).
 		WithParams(*input).
 		WithRetry(nil, StatusCodeClassifier{})
 	res, err := rb.Request(ctx, http.MethodHead, nil, cli.roundTripper(expectedCode(rb)))
@@ -158,6 +221,17 @@ func expectedCode(rb *requestBuilder) int {
 	return okCode
 }
 
+// objectSize return size of the whole object from Content-Range of a range get, or Content-Length, -1 if unknown
+func objectSize(res *Response) int64 {
+	contentRange := res.Header.Get(HeaderContentRange)
+	if i := strings.LastIndex(contentRange, "/"); i >= 0 {
+		if size, err := strconv.ParseInt(contentRange[i+1:], 10, 64); err == nil {
+			return size
+		}
+	}
+	return res.ContentLength
+}
+
 // DeleteObject delete an object
 //  objectKey: the name of object
 //  options: WithVersionID which version of this object will be deleted
@@ -169,6 +243,7 @@ func (bkt *Bucket) DeleteObject(ctx context.Context, objectKey string, options .
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, objectKey, options...).
+		WithOperation(OperationDeleteObject).
 		Request(ctx, http.MethodDelete, nil, bkt.client.roundTripper(http.StatusNoContent))
 	if err != nil {
 		return nil, err
@@ -184,12 +259,13 @@ func (bkt *Bucket) DeleteObject(ctx context.Context, objectKey string, options .
 }
 
 // DeleteObjectV2 delete an object
-func (cli *ClientV2) DeleteObjectV2(ctx context.Context, input *DeleteObjectV2Input) (*DeleteObjectV2Output, error) {
+func (cli *ClientV2) DeleteObjectV2(ctx context.Context, input *DeleteObjectV2Input, options ...Option) (*DeleteObjectV2Output, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
 
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationDeleteObject).
 		WithParams(*input).
 		WithRetry(nil, StatusCodeClassifier{}).
 		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
@@ -225,6 +301,7 @@ func (bkt *Bucket) DeleteMultiObjects(ctx context.Context, input *DeleteMultiObj
 		return nil, err
 	}
 	res, err := bkt.client.newBuilder(bkt.name, "", options...).
+		WithOperation(OperationDeleteMultiObjects).
 		WithHeader(HeaderContentMD5, contentMD5).
 		WithQuery("delete", "").
 		Request(ctx, http.MethodPost, bytes.NewReader(in), bkt.client.roundTripper(http.StatusOK))
@@ -239,16 +316,47 @@ func (bkt *Bucket) DeleteMultiObjects(ctx context.Context, input *DeleteMultiObj
 	return &output, nil
 }
 
-// DeleteMultiObjects delete multi-objects
-func (cli *ClientV2) DeleteMultiObjects(ctx context.Context, input *DeleteMultiObjectsInput) (*DeleteMultiObjectsOutput, error) {
+// DeleteMultiObjects delete multi-objects, or versions of them if VersionID is set. Result of each object is
+// returned in Deleted or Error of output, objects deleted successfully are omitted if input.Quiet is set.
+//
+// Objects more than MaxDeleteObjects are deleted by multiple requests, and the outputs are merged.
+// If a request fails, the error is returned, objects of previous requests are deleted already,
+// it's safe to retry with the same input since deleting is idempotent.
+func (cli *ClientV2) DeleteMultiObjects(ctx context.Context, input *DeleteMultiObjectsInput, options ...Option) (*DeleteMultiObjectsOutput, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
+	if len(input.Objects) == 0 {
+		return nil, newTosClientError("tos: no objects to delete", nil)
+	}
 	for _, object := range input.Objects {
 		if err := isValidKey(object.Key); err != nil {
 			return nil, err
 		}
 	}
+	var output *DeleteMultiObjectsOutput
+	for start := 0; start < len(input.Objects); start += MaxDeleteObjects {
+		end := min(start+MaxDeleteObjects, len(input.Objects))
+		batch := *input
+		batch.Objects = input.Objects[start:end]
+		out, err := cli.deleteMultiObjects(ctx, &batch, options...)
+		if err != nil {
+			return nil, err
+		}
+		if output == nil {
+			output = out
+			continue
+		}
+		output.RequestInfo = out.RequestInfo
+		output.Deleted = append(output.Deleted, out.Deleted...)
+		output.Error = append(output.Error, out.Error...)
+	}
+	return output, nil
+}
+
+// deleteMultiObjects delete Objects of input by one request, at most MaxDeleteObjects
+func (cli *ClientV2) deleteMultiObjects(ctx context.Context, input *DeleteMultiObjectsInput,
+	options ...Option) (*DeleteMultiObjectsOutput, error) {
 	in, contentMD5, err := marshalInput("DeleteMultiObjectsInput", deleteMultiObjectsInput{
 		Objects: input.Objects,
 		Quiet:   input.Quiet,
@@ -257,8 +365,10 @@ func (cli *ClientV2) DeleteMultiObjects(ctx context.Context, input *DeleteMultiO
 		return nil, err
 	}
 	// POST method, don't retry
-	res, err := cli.newBuilder(input.Bucket, "").
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteMultiObjects).
 		WithQuery("delete", "").
+		WithParams(*input).
 		WithHeader(HeaderContentMD5, contentMD5).
 		WithRetry(nil, ServerErrorClassifier{}).
 		Request(ctx, http.MethodPost, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
@@ -302,6 +412,7 @@ func (bkt *Bucket) PutObject(ctx context.Context, objectKey string, content io.R
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, objectKey, options...).
+		WithOperation(OperationPutObject).
 		Request(ctx, http.MethodPut, content, bkt.client.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
@@ -353,7 +464,8 @@ func checkCrc64(res *Response, checker hash.Hash64) error {
 
 // wrapReader wrap reader with some extension function.
 // If reader can be interpreted as io.ReadCloser, use itself as base ReadCloser, else wrap it a NopCloser.
-func wrapReader(reader io.Reader, totalBytes int64, listener DataTransferListener, limiter RateLimiter, checker hash.Hash64) io.ReadCloser {
+func wrapReader(reader io.Reader, totalBytes int64, listener DataTransferListener, limiter RateLimiter, checker hash.Hash64,
+	object *TransferObject) io.ReadCloser {
 	var wrapped io.ReadCloser
 	// get base ReadCloser
 	if rc, ok := reader.(io.ReadCloser); ok {
@@ -367,10 +479,11 @@ func wrapReader(reader io.Reader, totalBytes int64, listener DataTransferListene
 			base:     wrapped,
 			consumed: 0,
 			total:    totalBytes,
+			object:   object,
 		}
 	}
 	// wrap with limiter
-	if limiter != nil {
+	if limiter = limiterFor(limiter, object); limiter != nil {
 		wrapped = &ReadCloserWithLimiter{
 			limiter: limiter,
 			base:    wrapped,
@@ -387,48 +500,100 @@ func wrapReader(reader io.Reader, totalBytes int64, listener DataTransferListene
 }
 
 // PutObjectV2 put an object
-func (cli *ClientV2) PutObjectV2(ctx context.Context, input *PutObjectV2Input) (*PutObjectV2Output, error) {
+func (cli *ClientV2) PutObjectV2(ctx context.Context, input *PutObjectV2Input, options ...Option) (*PutObjectV2Output, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
+	if err := isValidWebsiteRedirectLocation(input.WebsiteRedirectLocation); err != nil {
+		return nil, err
+	}
 	var (
 		checker       hash.Hash64
-		content       = input.Content
 		contentLength = input.ContentLength
+		encoded       io.ReadCloser
 	)
 	if cli.enableCRC {
 		checker = NewCRC(DefaultCrcTable(), 0)
 	}
+	body, err := newUploadBody(input.Content, input.ContentProvider, cli.retryBufferSize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.close()
+	raw, err := body.open()
+	if err != nil {
+		return nil, err
+	}
 	if contentLength <= 0 {
-		contentLength = tryResolveLength(content)
+		contentLength = tryResolveLength(raw)
 	}
-	content = wrapReader(content, contentLength, input.DataTransferListener, input.RateLimiter, checker)
-	var (
-		onRetry    func(req *Request) = nil
-		classifier classifier
-	)
-	classifier = StatusCodeClassifier{}
-	if seeker, ok := content.(io.Seeker); ok {
-		start, err := seeker.Seek(0, io.SeekCurrent)
-		if err != nil {
+	object := &TransferObject{Bucket: input.Bucket, Key: input.Key, Size: contentLength, StorageClass: input.StorageClass}
+	var codec Codec
+	if len(input.Codec) > 0 {
+		if codec, err = lookupCodec(input.Codec); err != nil {
 			return nil, err
 		}
-		onRetry = func(req *Request) {
-			// PutObject/UploadPart can be treated as an idempotent semantics if the request message body
-			// supports a reset operation. e.g. the request message body is a string,
-			// a local file handle, binary data in memory
-			if seeker, ok := req.Content.(io.Seeker); ok {
-				seeker.Seek(start, io.SeekStart)
-			}
+	}
+	// wrap content of each attempt, listener reports progress of the original content, others work on the compressed one
+	wrap := func(raw io.Reader) io.Reader {
+		if checker != nil {
+			checker.Reset()
+		}
+		if codec == nil {
+			return cli.limitReader(wrapReader(raw, contentLength, input.DataTransferListener, input.RateLimiter, checker, object), object)
+		}
+		if encoded != nil {
+			_ = encoded.Close()
 		}
+		encoded = encodeReader(codec, wrapReader(raw, contentLength, input.DataTransferListener, nil, nil, object))
+		return cli.limitReader(wrapReader(encoded, -1, nil, input.RateLimiter, checker, object), object)
 	}
-	if onRetry == nil {
-		classifier = ServerErrorClassifier{}
+	content := wrap(raw)
+	defer func() {
+		if encoded != nil {
+			_ = encoded.Close()
+		}
+	}()
+	var (
+		onRetry    func(req *Request)
+		classifier Classifier = StatusCodeClassifier{}
+	)
+	// PutObject can be treated as an idempotent semantics if the request message body can be read again,
+	// otherwise the request is not retried
+	if body.rewindable() {
+		onRetry = body.retry(func() (io.Reader, error) {
+			raw, err := body.open()
+			if err != nil {
+				return nil, err
+			}
+			return wrap(raw), nil
+		})
+	} else {
+		classifier = NoRetryClassifier{}
+	}
+	if input.ForbidOverwrite {
+		// a retry of a put which succeeded fails
+		classifier = NoRetryClassifier{}
 	}
-	rb := cli.newBuilder(input.Bucket, input.Key).
+	rb := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationPutObject).
 		WithContentLength(contentLength).
 		WithParams(*input).
 		WithRetry(onRetry, classifier)
+	if input.ForbidOverwrite {
+		rb.WithHeader(HeaderForbidOverwrite, "true")
+	}
+	if err = rb.withCallback(input.Callback, input.CallbackVar); err != nil {
+		return nil, err
+	}
+	if err = rb.withTagSet(input.Tagging, input.TagSet); err != nil {
+		return nil, err
+	}
+	if len(input.Codec) > 0 {
+		rb.Header.Del(HeaderContentLength)
+		rb.ContentLength = nil
+		rb.WithHeader(HeaderMetaCodec, input.Codec)
+	}
 	res, err := rb.Request(ctx, http.MethodPut, content, cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
@@ -438,27 +603,51 @@ func (cli *ClientV2) PutObjectV2(ctx context.Context, input *PutObjectV2Input) (
 		return nil, err
 	}
 	crc64, _ := strconv.ParseUint(res.Header.Get(HeaderHashCrc64ecma), 10, 64)
-	return &PutObjectV2Output{
+	output := &PutObjectV2Output{
 		RequestInfo:   res.RequestInfo(),
 		ETag:          res.Header.Get(HeaderETag),
 		SSECAlgorithm: res.Header.Get(HeaderSSECustomerAlgorithm),
 		SSECKeyMD5:    res.Header.Get(HeaderSSECustomerKeyMD5),
 		VersionID:     res.Header.Get(HeaderVersionID),
 		HashCrc64ecma: crc64,
-	}, nil
+	}
+	if input.Callback != nil {
+		result, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, newTosClientError("tos: read callback result failed", err)
+		}
+		output.CallbackResult = string(result)
+	}
+	return output, nil
 }
 
-// PutObjectFromFile put an object from file
-func (cli *ClientV2) PutObjectFromFile(ctx context.Context, input *PutObjectFromFileInput) (*PutObjectFromFileOutput, error) {
+// PutObjectFromFile put an object from the file input.FilePath, ContentLength defaults to size of the file.
+//
+// Use UploadFile for large files, which uploads concurrently and can be resumed.
+func (cli *ClientV2) PutObjectFromFile(ctx context.Context, input *PutObjectFromFileInput, options ...Option) (*PutObjectFromFileOutput, error) {
+	if len(input.FilePath) == 0 {
+		return nil, newTosClientError("tos: empty file path of PutObjectFromFile", nil)
+	}
 	file, err := os.Open(input.FilePath)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if stat.IsDir() {
+		return nil, newTosClientError("tos: file path of PutObjectFromFile is a directory", nil)
+	}
+	basic := input.PutObjectBasicInput
+	if basic.ContentLength == 0 {
+		basic.ContentLength = stat.Size()
+	}
 	putOutput, err := cli.PutObjectV2(ctx, &PutObjectV2Input{
-		PutObjectBasicInput: input.PutObjectBasicInput,
+		PutObjectBasicInput: basic,
 		Content:             file,
-	})
+	}, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -493,6 +682,7 @@ func (bkt *Bucket) AppendObject(ctx context.Context, objectKey string, content i
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, objectKey, options...).
+		WithOperation(OperationAppendObject).
 		WithQuery("append", "").
 		WithQuery("offset", strconv.FormatInt(offset, 10)).
 		Request(ctx, http.MethodPost, content, bkt.client.roundTripper(http.StatusOK))
@@ -513,24 +703,40 @@ func (bkt *Bucket) AppendObject(ctx context.Context, objectKey string, content i
 	}, nil
 }
 
-// AppendObjectV2 append content at the tail of an appendable object
-func (cli *ClientV2) AppendObjectV2(ctx context.Context, input *AppendObjectV2Input) (*AppendObjectV2Output, error) {
+// AppendObjectV2 append content at the tail of an appendable object, the object is created if Offset is 0.
+// Offset must equal to the current size of object, which is NextAppendOffset of the last output.
+// Content must have a known length, see tryResolveLength, or ContentLength must be set.
+// Appending is not retried, since it's not idempotent.
+//
+// Objects uploaded by PutObject or multipart uploads can't be appended, and some buckets don't support appending,
+// e.g. buckets with versioning enabled, use IsNotAppendable to check the error returned in these cases.
+func (cli *ClientV2) AppendObjectV2(ctx context.Context, input *AppendObjectV2Input, options ...Option) (*AppendObjectV2Output, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
+	if input.Offset < 0 {
+		return nil, newTosClientError("tos: offset of AppendObject must not be negative", nil)
+	}
 	var (
 		checker       hash.Hash64
 		content       = input.Content
 		contentLength = input.ContentLength
 	)
-	if contentLength <= 0 {
+	if content == nil {
+		contentLength = 0
+	} else if contentLength <= 0 {
 		contentLength = tryResolveLength(content)
 	}
+	if contentLength < 0 {
+		return nil, newTosClientError("tos: unknown content length of AppendObject, set ContentLength of input", nil)
+	}
 	if cli.enableCRC {
 		checker = NewCRC(DefaultCrcTable(), input.PreHashCrc64ecma)
 	}
-	content = wrapReader(content, contentLength, input.DataTransferListener, input.RateLimiter, checker)
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	object := &TransferObject{Bucket: input.Bucket, Key: input.Key, Size: input.Offset + contentLength, StorageClass: input.StorageClass}
+	content = cli.limitReader(wrapReader(content, contentLength, input.DataTransferListener, input.RateLimiter, checker, object), object)
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationAppendObject).
 		WithQuery("append", "").
 		WithParams(*input).
 		WithContentLength(contentLength).
@@ -581,6 +787,7 @@ func (bkt *Bucket) SetObjectMeta(ctx context.Context, objectKey string, options
 	}
 
 	res, err := bkt.client.newBuilder(bkt.name, objectKey, options...).
+		WithOperation(OperationSetObjectMeta).
 		WithQuery("metadata", "").
 		Request(ctx, http.MethodPost, nil, bkt.client.roundTripper(http.StatusOK))
 	if err != nil {
@@ -592,12 +799,13 @@ func (bkt *Bucket) SetObjectMeta(ctx context.Context, objectKey string, options
 }
 
 // SetObjectMeta overwrites metadata of the object
-func (cli *ClientV2) SetObjectMeta(ctx context.Context, input *SetObjectMetaInput) (*SetObjectMetaOutput, error) {
+func (cli *ClientV2) SetObjectMeta(ctx context.Context, input *SetObjectMetaInput, options ...Option) (*SetObjectMetaOutput, error) {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return nil, err
 	}
 
-	res, err := cli.newBuilder(input.Bucket, input.Key).
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationSetObjectMeta).
 		WithQuery("metadata", "").
 		WithParams(*input).
 		WithRetry(nil, StatusCodeClassifier{}).
@@ -610,11 +818,33 @@ func (cli *ClientV2) SetObjectMeta(ctx context.Context, input *SetObjectMetaInpu
 	return &SetObjectMetaOutput{RequestInfo: res.RequestInfo()}, nil
 }
 
+// SetObjectMetaV2 replace metadata of an existing object, or of the version input.VersionID, in place. The content
+// is not copied, so it's cheap even for huge objects, unlike CopyObject to itself with MetadataDirectiveReplace.
+//
+// NOTICE: all metadata are replaced, metadata not set in input are removed, use HeadObjectV2 to get the previous ones.
+func (cli *ClientV2) SetObjectMetaV2(ctx context.Context, input *SetObjectMetaV2Input, options ...Option) (*SetObjectMetaV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationSetObjectMeta).
+		WithQuery("metadata", "").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPost, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &SetObjectMetaV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
 // ListObjects list objects of a bucket
 //
 // Deprecated: use ListObjects of ClientV2 instead
 func (bkt *Bucket) ListObjects(ctx context.Context, input *ListObjectsInput, options ...Option) (*ListObjectsOutput, error) {
 	res, err := bkt.client.newBuilder(bkt.name, "", options...).
+		WithOperation(OperationListObjects).
 		WithQuery("prefix", input.Prefix).
 		WithQuery("delimiter", input.Delimiter).
 		WithQuery("marker", input.Marker).
@@ -635,11 +865,12 @@ func (bkt *Bucket) ListObjects(ctx context.Context, input *ListObjectsInput, opt
 }
 
 // ListObjectsV2 list objects of a bucket
-func (cli *ClientV2) ListObjectsV2(ctx context.Context, input *ListObjectsV2Input) (*ListObjectsV2Output, error) {
+func (cli *ClientV2) ListObjectsV2(ctx context.Context, input *ListObjectsV2Input, options ...Option) (*ListObjectsV2Output, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
-	res, err := cli.newBuilder(input.Bucket, "").
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationListObjects).
 		WithParams(*input).
 		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
@@ -655,11 +886,36 @@ func (cli *ClientV2) ListObjectsV2(ctx context.Context, input *ListObjectsV2Inpu
 	return &output, nil
 }
 
+// ListObjectsType2 list objects of a bucket page by page with continuation tokens, which are stable on buckets of
+// huge number of keys, unlike markers of ListObjectsV2. Keep listing with NextContinuationToken of output while
+// IsTruncated is true.
+func (cli *ClientV2) ListObjectsType2(ctx context.Context, input *ListObjectsType2Input, options ...Option) (*ListObjectsType2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationListObjectsType2).
+		WithQuery("list-type", "2").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := ListObjectsType2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
 // ListObjectVersions list multi-version objects of a bucket
 //
 // Deprecated: use ListObjectV2Versions of ClientV2 instead
 func (bkt *Bucket) ListObjectVersions(ctx context.Context, input *ListObjectVersionsInput, options ...Option) (*ListObjectVersionsOutput, error) {
 	res, err := bkt.client.newBuilder(bkt.name, "", options...).
+		WithOperation(OperationListObjectVersions).
 		WithQuery("prefix", input.Prefix).
 		WithQuery("delimiter", input.Delimiter).
 		WithQuery("key-marker", input.KeyMarker).
@@ -682,12 +938,15 @@ func (bkt *Bucket) ListObjectVersions(ctx context.Context, input *ListObjectVers
 // ListObjectVersionsV2 list multi-version objects of a bucket
 func (cli *ClientV2) ListObjectVersionsV2(
 	ctx context.Context,
-	input *ListObjectVersionsV2Input) (*ListObjectVersionsV2Output, error) {
+	input *ListObjectVersionsV2Input, options ...Option) (*ListObjectVersionsV2Output, error) {
 	if err := IsValidBucketName(input.Bucket); err != nil {
 		return nil, err
 	}
-	res, err := cli.newBuilder(input.Bucket, "").
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationListObjectVersions).
 		WithQuery("versions", "").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
 		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
 		return nil, err
@@ -0,0 +1,121 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// PutObjectLockConfiguration enable Object Lock of a versioned bucket and set the default retention of new objects.
+// Object Lock can't be disabled once it's enabled.
+func (cli *ClientV2) PutObjectLockConfiguration(ctx context.Context, input *PutObjectLockConfigurationInput, options ...Option) (*PutObjectLockConfigurationOutput, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	in, contentMD5, err := marshalInput("PutObjectLockConfigurationInput", input.ObjectLockConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutObjectLockConfiguration).
+		WithQuery("object-lock", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutObjectLockConfigurationOutput{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetObjectLockConfiguration get Object Lock of a bucket
+func (cli *ClientV2) GetObjectLockConfiguration(ctx context.Context, input *GetObjectLockConfigurationInput, options ...Option) (*GetObjectLockConfigurationOutput, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetObjectLockConfiguration).
+		WithQuery("object-lock", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetObjectLockConfigurationOutput{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output.ObjectLockConfiguration); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+type objectRetention struct {
+	Mode            enum.ObjectLockModeType `json:"Mode,omitempty"`
+	RetainUntilDate string                  `json:"RetainUntilDate,omitempty"`
+}
+
+// PutObjectRetention set retention of an object version in a bucket with Object Lock enabled, the version can't be
+// overwritten or deleted until RetainUntilDate. Retention can be extended, but shortening or removing it is only
+// allowed in GOVERNANCE mode with BypassGovernanceRetention.
+func (cli *ClientV2) PutObjectRetention(ctx context.Context, input *PutObjectRetentionInput, options ...Option) (*PutObjectRetentionOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	retention := objectRetention{Mode: input.Mode}
+	if !input.RetainUntilDate.IsZero() {
+		retention.RetainUntilDate = input.RetainUntilDate.UTC().Format(time.RFC3339)
+	}
+	in, contentMD5, err := marshalInput("PutObjectRetentionInput", retention)
+	if err != nil {
+		return nil, err
+	}
+	rb := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationPutObjectRetention).
+		WithQuery("retention", "").
+		WithParams(*input).
+		WithHeader(HeaderContentMD5, contentMD5)
+	if input.BypassGovernanceRetention {
+		rb.WithHeader(HeaderBypassGovernanceRetention, "true")
+	}
+	res, err := rb.WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutObjectRetentionOutput{RequestInfo: res.RequestInfo()}, nil
+}
+
+type objectLegalHold struct {
+	Status enum.LegalHoldStatusType `json:"Status"`
+}
+
+// PutObjectLegalHold place or remove a legal hold on an object version in a bucket with Object Lock enabled
+func (cli *ClientV2) PutObjectLegalHold(ctx context.Context, input *PutObjectLegalHoldInput, options ...Option) (*PutObjectLegalHoldOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	if input.Status != enum.LegalHoldStatusOn && input.Status != enum.LegalHoldStatusOff {
+		return nil, newTosClientError("tos: invalid legal hold status", nil)
+	}
+	in, contentMD5, err := marshalInput("PutObjectLegalHoldInput", objectLegalHold{Status: input.Status})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationPutObjectLegalHold).
+		WithQuery("legal-hold", "").
+		WithParams(*input).
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutObjectLegalHoldOutput{RequestInfo: res.RequestInfo()}, nil
+}
@@ -0,0 +1,88 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestObjectLockConfiguration(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	config := ObjectLockConfiguration{ObjectLockEnabled: "Enabled", Rule: &ObjectLockRule{
+		DefaultRetention: DefaultRetention{Mode: enum.ObjectLockModeCompliance, Days: 30}}}
+	_, err := client.PutObjectLockConfiguration(context.Background(), &PutObjectLockConfigurationInput{Bucket: "bucket",
+		ObjectLockConfiguration: config})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Equal(t, OperationPutObjectLockConfiguration, req.OperationName)
+	require.Contains(t, req.Query, "object-lock")
+	body, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"ObjectLockEnabled":"Enabled","Rule":{"DefaultRetention":{"Mode":"COMPLIANCE","Days":30}}}`,
+		string(body))
+	require.NotEmpty(t, req.Header.Get(HeaderContentMD5))
+
+	transport.respond(http.StatusOK, `{"ObjectLockEnabled":"Enabled","Rule":{"DefaultRetention":{"Mode":"GOVERNANCE",
+"Years":1}}}`)
+	output, err := client.GetObjectLockConfiguration(context.Background(), &GetObjectLockConfigurationInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Equal(t, ObjectLockConfiguration{ObjectLockEnabled: "Enabled", Rule: &ObjectLockRule{
+		DefaultRetention: DefaultRetention{Mode: enum.ObjectLockModeGovernance, Years: 1}}}, output.ObjectLockConfiguration)
+
+	// Object Lock is enabled without a default retention
+	transport.respond(http.StatusOK, `{"ObjectLockEnabled":"Enabled"}`)
+	output, err = client.GetObjectLockConfiguration(context.Background(), &GetObjectLockConfigurationInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Nil(t, output.Rule)
+}
+
+func TestObjectRetentionAndLegalHold(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	// the date is sent in UTC
+	until := time.Date(2030, 1, 2, 11, 4, 5, 0, time.FixedZone("UTC+8", 8*3600))
+	_, err := client.PutObjectRetention(context.Background(), &PutObjectRetentionInput{Bucket: "bucket", Key: "key",
+		VersionID: "v1", Mode: enum.ObjectLockModeGovernance, RetainUntilDate: until, BypassGovernanceRetention: true})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, OperationPutObjectRetention, req.OperationName)
+	require.Contains(t, req.Query, "retention")
+	require.Equal(t, "v1", req.Query.Get("versionId"))
+	require.Equal(t, "true", req.Header.Get(HeaderBypassGovernanceRetention))
+	body, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Mode":"GOVERNANCE","RetainUntilDate":"2030-01-02T03:04:05Z"}`, string(body))
+
+	_, err = client.PutObjectLegalHold(context.Background(), &PutObjectLegalHoldInput{Bucket: "bucket", Key: "key",
+		Status: enum.LegalHoldStatusOn})
+	require.Nil(t, err)
+	req = transport.requests[1]
+	require.Equal(t, OperationPutObjectLegalHold, req.OperationName)
+	require.Contains(t, req.Query, "legal-hold")
+	require.Empty(t, req.Header.Get(HeaderBypassGovernanceRetention))
+	body, err = ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Status":"ON"}`, string(body))
+
+	_, err = client.PutObjectLegalHold(context.Background(), &PutObjectLegalHoldInput{Bucket: "bucket", Key: "key"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 2)
+
+	header := transport.respond(http.StatusOK, "").Header
+	header.Set(HeaderObjectLockMode, "COMPLIANCE")
+	header.Set(HeaderObjectLockRetainUntilDate, "2030-01-02T03:04:05Z")
+	header.Set(HeaderObjectLockLegalHold, "ON")
+	head, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, enum.ObjectLockModeCompliance, head.ObjectLockMode)
+	require.True(t, until.Equal(head.ObjectLockRetainUntilDate))
+	require.Equal(t, enum.LegalHoldStatusOn, head.ObjectLockLegalHold)
+}
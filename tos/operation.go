@@ -0,0 +1,117 @@
+package tos
+
+// Operation names are stable identifiers of API calls, see Request.OperationName and OperationName
+const (
+	OperationCreateBucket                     = "CreateBucket"
+	OperationHeadBucket                       = "HeadBucket"
+	OperationGetBucketLocation                = "GetBucketLocation"
+	OperationGetBucketStat                    = "GetBucketStat"
+	OperationPutBucketStorageClass            = "PutBucketStorageClass"
+	OperationDeleteBucket                     = "DeleteBucket"
+	OperationListBuckets                      = "ListBuckets"
+	OperationGetBucketPolicy                  = "GetBucketPolicy"
+	OperationPutBucketPolicy                  = "PutBucketPolicy"
+	OperationDeleteBucketPolicy               = "DeleteBucketPolicy"
+	OperationGetBucketVersioning              = "GetBucketVersioning"
+	OperationPutBucketLifecycle               = "PutBucketLifecycle"
+	OperationGetBucketLifecycle               = "GetBucketLifecycle"
+	OperationDeleteBucketLifecycle            = "DeleteBucketLifecycle"
+	OperationPutBucketCORS                    = "PutBucketCORS"
+	OperationGetBucketCORS                    = "GetBucketCORS"
+	OperationDeleteBucketCORS                 = "DeleteBucketCORS"
+	OperationPutBucketWebsite                 = "PutBucketWebsite"
+	OperationGetBucketWebsite                 = "GetBucketWebsite"
+	OperationDeleteBucketWebsite              = "DeleteBucketWebsite"
+	OperationPutBucketNotification            = "PutBucketNotification"
+	OperationGetBucketNotification            = "GetBucketNotification"
+	OperationPutBucketEncryption              = "PutBucketEncryption"
+	OperationGetBucketEncryption              = "GetBucketEncryption"
+	OperationDeleteBucketEncryption           = "DeleteBucketEncryption"
+	OperationPutBucketTagging                 = "PutBucketTagging"
+	OperationGetBucketTagging                 = "GetBucketTagging"
+	OperationDeleteBucketTagging              = "DeleteBucketTagging"
+	OperationPutBucketACL                     = "PutBucketACL"
+	OperationGetBucketACL                     = "GetBucketACL"
+	OperationPutBucketMirrorBack              = "PutBucketMirrorBack"
+	OperationGetBucketMirrorBack              = "GetBucketMirrorBack"
+	OperationDeleteBucketMirrorBack           = "DeleteBucketMirrorBack"
+	OperationPutBucketCustomDomain            = "PutBucketCustomDomain"
+	OperationListBucketCustomDomain           = "ListBucketCustomDomain"
+	OperationDeleteBucketCustomDomain         = "DeleteBucketCustomDomain"
+	OperationPutBucketRealTimeLog             = "PutBucketRealTimeLog"
+	OperationGetBucketRealTimeLog             = "GetBucketRealTimeLog"
+	OperationDeleteBucketRealTimeLog          = "DeleteBucketRealTimeLog"
+	OperationPutBucketAccessMonitor           = "PutBucketAccessMonitor"
+	OperationGetBucketAccessMonitor           = "GetBucketAccessMonitor"
+	OperationPutBucketIntelligentTiering      = "PutBucketIntelligentTiering"
+	OperationGetBucketIntelligentTiering      = "GetBucketIntelligentTiering"
+	OperationPutBucketRequestPayment          = "PutBucketRequestPayment"
+	OperationGetBucketRequestPayment          = "GetBucketRequestPayment"
+	OperationPutBucketAccelerateConfiguration = "PutBucketAccelerateConfiguration"
+	OperationGetBucketAccelerateConfiguration = "GetBucketAccelerateConfiguration"
+	OperationPutBucketAlias                   = "PutBucketAlias"
+	OperationListBucketAlias                  = "ListBucketAlias"
+	OperationDeleteBucketAlias                = "DeleteBucketAlias"
+	OperationPutObjectLockConfiguration       = "PutObjectLockConfiguration"
+	OperationGetObjectLockConfiguration       = "GetObjectLockConfiguration"
+	OperationPutObjectRetention               = "PutObjectRetention"
+	OperationPutObjectLegalHold               = "PutObjectLegalHold"
+	OperationPutObjectACL                     = "PutObjectACL"
+	OperationGetObjectACL                     = "GetObjectACL"
+	OperationCopyObject                       = "CopyObject"
+	OperationUploadPartCopy                   = "UploadPartCopy"
+	OperationFetchObject                      = "FetchObject"
+	OperationPutFetchTask                     = "PutFetchTask"
+	OperationGetFetchTask                     = "GetFetchTask"
+	OperationCreateMultipartUpload            = "CreateMultipartUpload"
+	OperationUploadPart                       = "UploadPart"
+	OperationCompleteMultipartUpload          = "CompleteMultipartUpload"
+	OperationAbortMultipartUpload             = "AbortMultipartUpload"
+	OperationListParts                        = "ListParts"
+	OperationListMultipartUploads             = "ListMultipartUploads"
+	OperationGetObject                        = "GetObject"
+	OperationProcessObject                    = "ProcessObject"
+	OperationHeadObject                       = "HeadObject"
+	OperationDeleteObject                     = "DeleteObject"
+	OperationDeleteMultiObjects               = "DeleteMultiObjects"
+	OperationPutObject                        = "PutObject"
+	OperationAppendObject                     = "AppendObject"
+	OperationSetObjectMeta                    = "SetObjectMeta"
+	OperationRestoreObject                    = "RestoreObject"
+	OperationRenameObject                     = "RenameObject"
+	OperationCreateDirectory                  = "CreateDirectory"
+	OperationDeleteDirectory                  = "DeleteDirectory"
+	OperationGetFileStatus                    = "GetFileStatus"
+	OperationPutSymlink                       = "PutSymlink"
+	OperationGetSymlink                       = "GetSymlink"
+	OperationSelectObject                     = "SelectObject"
+	OperationListObjects                      = "ListObjects"
+	OperationListObjectsType2                 = "ListObjectsType2"
+	OperationListObjectVersions               = "ListObjectVersions"
+)
+
+// OperationName return operation name of the API call which returns err, or "" if it's unknown
+func OperationName(err error) string {
+	switch e := err.(type) {
+	case *TosServerError:
+		return e.OperationName
+	case *TosClientError:
+		return e.OperationName
+	}
+	return ""
+}
+
+// withOperationName attach operation name to err if it's not set
+func withOperationName(err error, name string) error {
+	switch e := err.(type) {
+	case *TosServerError:
+		if len(e.OperationName) == 0 {
+			e.OperationName = name
+		}
+	case *TosClientError:
+		if len(e.OperationName) == 0 {
+			e.OperationName = name
+		}
+	}
+	return err
+}
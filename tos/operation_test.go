@@ -0,0 +1,59 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordTransport struct {
+	requests []*Request
+	res      *Response
+	err      error
+}
+
+func (rt *recordTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.requests = append(rt.requests, req)
+	return rt.res, rt.err
+}
+
+// respond answer the following requests with status and body, the response is returned to set headers
+func (rt *recordTransport) respond(status int, body string) *Response {
+	rt.res = newRecordResponse(status, body)
+	return rt.res
+}
+
+func newRecordResponse(status int, body string) *Response {
+	return &Response{StatusCode: status, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(body))}
+}
+
+// newRecordClient return a client whose requests are recorded by the returned transport and answered with status
+// and body
+func newRecordClient(t *testing.T, status int, body string, options ...ClientOption) (*ClientV2, *recordTransport) {
+	transport := &recordTransport{res: newRecordResponse(status, body)}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", append([]ClientOption{WithTransport(transport)}, options...)...)
+	require.Nil(t, err)
+	return client, transport
+}
+
+func TestOperationName(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusNotFound, "")
+
+	_, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Equal(t, http.StatusNotFound, StatusCode(err))
+	require.Equal(t, OperationHeadObject, OperationName(err))
+	require.Equal(t, OperationHeadObject, transport.requests[0].OperationName)
+
+	transport.err = newTosClientError("tos: connection refused", nil)
+	_, err = client.ListObjectsV2(context.Background(), &ListObjectsV2Input{Bucket: "bucket"})
+	require.Equal(t, OperationListObjects, OperationName(err))
+
+	// error occurs before sending request
+	_, err = client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket"})
+	require.NotNil(t, err)
+	require.Equal(t, "", OperationName(err))
+}
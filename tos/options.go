@@ -5,6 +5,7 @@ import (
 	"time"
 )
 
+// Option customizes a single call, accepted by methods of Bucket and the V2 APIs of ClientV2
 type Option func(*requestBuilder)
 
 // WithContentType set Content-Type header
@@ -256,6 +257,7 @@ func WithPerRequestSigner(signer Signer) Option {
 }
 
 // WithHeader add request http header.
+// It can be used to pass headers the SDK hasn't modeled yet, e.g. new x-tos-* headers.
 //
 // NOTICE: use it carefully.
 func WithHeader(key, value string) Option {
@@ -272,3 +274,21 @@ func WithQuery(key, value string) Option {
 		rb.Query.Set(key, value)
 	}
 }
+
+// WithOperationTimeout set the total time limit of a call, including retries and reading the response body.
+// If ctx has an earlier deadline, the deadline of ctx takes effect.
+//
+// use it to give calls very different budgets, e.g. a long one for uploads and a short one for listing.
+func WithOperationTimeout(timeout time.Duration) Option {
+	return func(rb *requestBuilder) {
+		rb.OperationTimeout = timeout
+	}
+}
+
+// WithResponseHeaderTimeout set the time limit of waiting for response headers of each attempt of a call.
+// It only shortens the ResponseHeaderTimeout of TransportConfig, which still applies.
+func WithResponseHeaderTimeout(timeout time.Duration) Option {
+	return func(rb *requestBuilder) {
+		rb.ResponseHeaderTimeout = timeout
+	}
+}
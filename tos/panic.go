@@ -0,0 +1,32 @@
+package tos
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the Cause of TosClientError returned when a user callback panics, e.g. DataTransferListener,
+// UploadEventListener, RateLimiter and Hooks, so that a buggy callback fails the call instead of
+// crashing the process, e.g. from a worker goroutine of UploadFile.
+type PanicError struct {
+	// Callback name of the callback interface, e.g. "DataTransferListener"
+	Callback string
+	// Value the value passed to panic
+	Value interface{}
+	// Stack the stack trace of the panicking goroutine
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %v", e.Callback, e.Value)
+}
+
+// recoverPanic convert a panic of callback into TosClientError stored in err, it must be deferred directly:
+//
+//	defer recoverPanic("DataTransferListener", &err)
+func recoverPanic(callback string, err *error) {
+	if r := recover(); r != nil {
+		cause := &PanicError{Callback: callback, Value: r, Stack: debug.Stack()}
+		*err = newTosClientError("tos: "+cause.Error(), cause)
+	}
+}
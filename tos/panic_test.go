@@ -0,0 +1,82 @@
+package tos
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+type panicListener struct {
+	on enum.DataTransferType
+}
+
+func (l *panicListener) DataTransferStatusChange(status *DataTransferStatus) {
+	if status.Type == l.on {
+		panic("listener bug")
+	}
+}
+
+func (l *panicListener) internal() {}
+
+type panicLimiter struct{}
+
+func (panicLimiter) Acquire(want int64) (bool, time.Duration) {
+	panic("limiter bug")
+}
+
+func (panicLimiter) internal() {}
+
+func requirePanicError(t *testing.T, err error, callback string) {
+	var perr *PanicError
+	require.True(t, errors.As(err, &perr), "%v", err)
+	require.Equal(t, callback, perr.Callback)
+	require.Contains(t, string(perr.Stack), "panic")
+}
+
+func TestPanicSafeCallbacks(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&bodyTransport{}))
+	require.Nil(t, err)
+	put := func(input *PutObjectV2Input) error {
+		input.Bucket, input.Key, input.Content = "bucket", "key", strings.NewReader("hello")
+		_, err := client.PutObjectV2(context.Background(), input)
+		return err
+	}
+
+	err = put(&PutObjectV2Input{PutObjectBasicInput: PutObjectBasicInput{DataTransferListener: &panicListener{on: enum.DataTransferRW}}})
+	requirePanicError(t, err, "DataTransferListener")
+	require.Equal(t, "tos: DataTransferListener panicked: listener bug", err.Error())
+	err = put(&PutObjectV2Input{PutObjectBasicInput: PutObjectBasicInput{RateLimiter: panicLimiter{}}})
+	requirePanicError(t, err, "RateLimiter")
+
+	client, err = NewClientV2("tos-cn-beijing.volces.com", WithTransport(&bodyTransport{}), WithHooks(Hooks{
+		OnResponse: func(ctx context.Context, event *ResponseEvent) { panic("hook bug") },
+	}))
+	require.Nil(t, err)
+	err = put(&PutObjectV2Input{})
+	requirePanicError(t, err, "Hooks")
+}
+
+func TestUploadFileListenerPanic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-upload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	require.Nil(t, ioutil.WriteFile(file, []byte("hello"), 0666))
+
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&multipartTransport{}))
+	require.Nil(t, err)
+	_, err = client.UploadFile(context.Background(), &UploadFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:                     file,
+		DataTransferListener:         &panicListener{on: enum.DataTransferStarted},
+	})
+	requirePanicError(t, err, "DataTransferListener")
+}
@@ -0,0 +1,132 @@
+package tos
+
+import (
+	"hash"
+	"io"
+)
+
+// names of the stages of the pipeline of GetObjectV2, in the order data flows through them
+const (
+	ReaderStageCRC = "crc" // verify crc64 of the whole object, present only if crc checking is enabled
+	// ReaderStageDecrypt is reserved for client-side decryption, the SDK never adds it as objects encrypted by
+	// SSE-TOS, SSE-KMS or SSE-C are decrypted by the server. Add it with InsertAfter(ReaderStageCRC, ...) or
+	// InsertBefore(ReaderStageDecompress, ...), so that it's ordered as listed here.
+	ReaderStageDecrypt    = "decrypt"
+	ReaderStageDecompress = "decompress" // decompress gzip Content-Encoding, present only if DecompressGzip is set and content is gzip
+	ReaderStageDecode     = "decode"     // decompress content with Codec, present only if DecodeContent is set and object has a codec
+	ReaderStageListener   = "listener"   // report progress of decompressed content to DataTransferListener, present only if it's set
+	ReaderStageLimiter    = "limiter"    // limit read rate with RateLimiter of input and client, present only if any is set
+)
+
+// ReaderStage wraps a stream of object content. Closing the returned ReadCloser must close rc.
+type ReaderStage func(rc io.ReadCloser) io.ReadCloser
+
+type namedReaderStage struct {
+	name  string
+	stage ReaderStage
+}
+
+// ReaderPipeline composes named stages wrapping a download stream.
+// Data read from network flows through stages in their order, and Content is read from the last one.
+//
+// Use GetObjectV2Input.ReaderPipelineHook to insert custom stages, e.g. virus scanning, relative to the SDK ones.
+type ReaderPipeline struct {
+	stages []namedReaderStage
+}
+
+// NewReaderPipeline create an empty ReaderPipeline
+func NewReaderPipeline() *ReaderPipeline {
+	return &ReaderPipeline{}
+}
+
+func (p *ReaderPipeline) index(name string) int {
+	for i, s := range p.stages {
+		if s.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *ReaderPipeline) insert(i int, name string, stage ReaderStage) *ReaderPipeline {
+	if stage == nil {
+		return p
+	}
+	p.stages = append(p.stages, namedReaderStage{})
+	copy(p.stages[i+1:], p.stages[i:])
+	p.stages[i] = namedReaderStage{name: name, stage: stage}
+	return p
+}
+
+// Append add stage at the end of the pipeline
+func (p *ReaderPipeline) Append(name string, stage ReaderStage) *ReaderPipeline {
+	return p.insert(len(p.stages), name, stage)
+}
+
+// InsertBefore add stage before the stage named anchor, or at the end if anchor is absent
+func (p *ReaderPipeline) InsertBefore(anchor, name string, stage ReaderStage) *ReaderPipeline {
+	i := p.index(anchor)
+	if i < 0 {
+		i = len(p.stages)
+	}
+	return p.insert(i, name, stage)
+}
+
+// InsertAfter add stage after the stage named anchor, or at the end if anchor is absent
+func (p *ReaderPipeline) InsertAfter(anchor, name string, stage ReaderStage) *ReaderPipeline {
+	i := p.index(anchor)
+	if i < 0 {
+		i = len(p.stages) - 1
+	}
+	return p.insert(i+1, name, stage)
+}
+
+// Remove remove the stage named name if present
+func (p *ReaderPipeline) Remove(name string) *ReaderPipeline {
+	if i := p.index(name); i >= 0 {
+		p.stages = append(p.stages[:i], p.stages[i+1:]...)
+	}
+	return p
+}
+
+// Names return names of the stages in order
+func (p *ReaderPipeline) Names() []string {
+	names := make([]string, 0, len(p.stages))
+	for _, s := range p.stages {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// Build wrap rc with all stages in order
+func (p *ReaderPipeline) Build(rc io.ReadCloser) io.ReadCloser {
+	for _, s := range p.stages {
+		rc = s.stage(rc)
+	}
+	return rc
+}
+
+// crcStage verify crc64 of content against the one returned by server on EOF
+func crcStage(res *Response, checker hash.Hash64) ReaderStage {
+	return func(rc io.ReadCloser) io.ReadCloser {
+		return &readCloserWithCRCCheck{readCloserWithCRC: readCloserWithCRC{checker: checker, base: rc}, res: res}
+	}
+}
+
+func listenerStage(listener DataTransferListener, totalBytes int64, object *TransferObject) ReaderStage {
+	return func(rc io.ReadCloser) io.ReadCloser {
+		return &readCloserWithListener{listener: listener, base: rc, total: totalBytes, object: object}
+	}
+}
+
+// limiterStage limit read rate of object with all non-nil limiters
+func limiterStage(object *TransferObject, limiters ...RateLimiter) ReaderStage {
+	return func(rc io.ReadCloser) io.ReadCloser {
+		for _, limiter := range limiters {
+			if limiter = limiterFor(limiter, object); limiter != nil {
+				rc = &ReadCloserWithLimiter{limiter: limiter, base: rc}
+			}
+		}
+		return rc
+	}
+}
@@ -0,0 +1,146 @@
+package tos
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+type upperReadCloser struct {
+	io.ReadCloser
+}
+
+func (r upperReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	copy(p, strings.ToUpper(string(p[:n])))
+	return n, err
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+type unlimited struct{}
+
+func (unlimited) Acquire(want int64) (bool, time.Duration) { return true, 0 }
+
+func (unlimited) internal() {}
+
+func TestReaderPipeline(t *testing.T) {
+	nop := func(rc io.ReadCloser) io.ReadCloser { return rc }
+	pipeline := NewReaderPipeline().
+		Append(ReaderStageCRC, nop).
+		Append(ReaderStageLimiter, nop).
+		InsertBefore(ReaderStageLimiter, ReaderStageListener, nop).
+		InsertAfter(ReaderStageCRC, "scan", nop).
+		InsertAfter("absent", "last", nop).
+		Append("ignored", nil)
+	require.Equal(t, []string{ReaderStageCRC, "scan", ReaderStageListener, ReaderStageLimiter, "last"}, pipeline.Names())
+	pipeline.Remove("last").Remove("absent")
+	require.Equal(t, []string{ReaderStageCRC, "scan", ReaderStageListener, ReaderStageLimiter}, pipeline.Names())
+}
+
+func TestGetObjectV2ReaderPipelineHook(t *testing.T) {
+	body := &closeRecorder{Reader: strings.NewReader("hello")}
+	transport := &recordTransport{res: &Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: body}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+
+	var names []string
+	get, err := client.GetObjectV2(context.Background(), &GetObjectV2Input{
+		Bucket:      "bucket",
+		Key:         "key",
+		RateLimiter: unlimited{},
+		ReaderPipelineHook: func(pipeline *ReaderPipeline) {
+			pipeline.InsertBefore(ReaderStageLimiter, "upper", func(rc io.ReadCloser) io.ReadCloser {
+				return upperReadCloser{rc}
+			})
+			names = pipeline.Names()
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"upper", ReaderStageLimiter}, names)
+	data, err := ioutil.ReadAll(get.Content)
+	require.Nil(t, err)
+	require.Equal(t, "HELLO", string(data))
+	require.Nil(t, get.Content.Close())
+	require.True(t, body.closed)
+}
+
+func TestReaderStageCRC(t *testing.T) {
+	res := &Response{Header: make(http.Header)}
+	res.Header.Set(HeaderHashCrc64ecma, "1")
+	content := crcStage(res, NewCRC(DefaultCrcTable(), 0))(ioutil.NopCloser(strings.NewReader("hello")))
+	_, err := ioutil.ReadAll(content)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "crc64 check failed")
+}
+
+func TestGetObjectV2ReaderStageOrder(t *testing.T) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, err := w.Write([]byte("hello world"))
+	require.Nil(t, err)
+	require.Nil(t, w.Close())
+	header := make(http.Header)
+	header.Set(HeaderContentEncoding, "gzip")
+	transport := &recordTransport{res: &Response{StatusCode: http.StatusOK, Header: header,
+		ContentLength: int64(compressed.Len()), Body: ioutil.NopCloser(bytes.NewReader(compressed.Bytes()))}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+
+	nop := func(rc io.ReadCloser) io.ReadCloser { return rc }
+	listener := &statusListener{}
+	var names []string
+	get, err := client.GetObjectV2(context.Background(), &GetObjectV2Input{
+		Bucket:               "bucket",
+		Key:                  "key",
+		DecompressGzip:       true,
+		DataTransferListener: listener,
+		RateLimiter:          unlimited{},
+		ReaderPipelineHook: func(pipeline *ReaderPipeline) {
+			pipeline.InsertBefore(ReaderStageDecompress, ReaderStageDecrypt, nop)
+			names = pipeline.Names()
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{ReaderStageDecrypt, ReaderStageDecompress, ReaderStageListener, ReaderStageLimiter}, names)
+	data, err := ioutil.ReadAll(get.Content)
+	require.Nil(t, err)
+	require.Equal(t, "hello world", string(data))
+	// progress counts decompressed bytes, whose total is unknown
+	last := listener.statuses[len(listener.statuses)-1]
+	require.Equal(t, enum.DataTransferSucceed, last.Type)
+	require.Equal(t, int64(len(data)), last.ConsumedBytes)
+	require.Equal(t, int64(-1), last.TotalBytes)
+
+	transport.res = &Response{StatusCode: http.StatusOK, Header: make(http.Header), ContentLength: 5,
+		Body: ioutil.NopCloser(strings.NewReader("hello"))}
+	client.enableCRC = true
+	_, err = client.GetObjectV2(context.Background(), &GetObjectV2Input{
+		Bucket:               "bucket",
+		Key:                  "key",
+		DataTransferListener: listener,
+		ReaderPipelineHook: func(pipeline *ReaderPipeline) {
+			pipeline.InsertAfter(ReaderStageCRC, ReaderStageDecrypt, nop)
+			names = pipeline.Names()
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{ReaderStageCRC, ReaderStageDecrypt, ReaderStageListener}, names)
+}
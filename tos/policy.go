@@ -2,9 +2,13 @@ package tos
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
 )
 
 type BucketPolicy struct {
@@ -31,6 +35,7 @@ func (cli *Client) GetBucketPolicy(ctx context.Context, bucket string) (*GetBuck
 	}
 
 	res, err := cli.newBuilder(bucket, "").
+		WithOperation(OperationGetBucketPolicy).
 		WithQuery("policy", "").
 		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
@@ -55,6 +60,7 @@ func (cli *Client) PutBucketPolicy(ctx context.Context, bucket string, policy *B
 		return nil, err
 	}
 	res, err := cli.newBuilder(bucket, "").
+		WithOperation(OperationPutBucketPolicy).
 		WithQuery("policy", "").
 		Request(ctx, http.MethodPut, strings.NewReader(policy.Policy), cli.roundTripper(http.StatusNoContent))
 	if err != nil {
@@ -71,6 +77,7 @@ func (cli *Client) DeleteBucketPolicy(ctx context.Context, bucket string) (*Dele
 	}
 
 	res, err := cli.newBuilder(bucket, "").
+		WithOperation(OperationDeleteBucketPolicy).
 		WithQuery("policy", "").
 		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
 	if err != nil {
@@ -80,3 +87,342 @@ func (cli *Client) DeleteBucketPolicy(ctx context.Context, bucket string) (*Dele
 
 	return &DeleteBucketPolicyOutput{RequestInfo: res.RequestInfo()}, nil
 }
+
+// PolicyDocument a bucket policy, see PolicyStatementBuilder
+type PolicyDocument struct {
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement allow or deny Principal to perform Action on Resource, when Condition is satisfied
+type PolicyStatement struct {
+	Sid          string                `json:"Sid,omitempty"`
+	Effect       enum.PolicyEffectType `json:"Effect"`
+	Principal    *PolicyPrincipal      `json:"Principal,omitempty"`
+	NotPrincipal *PolicyPrincipal      `json:"NotPrincipal,omitempty"`
+	Action       StringList            `json:"Action,omitempty"`
+	NotAction    StringList            `json:"NotAction,omitempty"`
+	Resource     StringList            `json:"Resource,omitempty"`
+	NotResource  StringList            `json:"NotResource,omitempty"`
+	// Condition operator, e.g. "StringEquals", to condition keys, e.g. "tos:prefix", and their values
+	Condition map[string]map[string]StringList `json:"Condition,omitempty"`
+}
+
+// PolicyPrincipal everyone if Any is true, otherwise the accounts or users of TOS, e.g. "2100000001" or
+// "2100000001/user"
+type PolicyPrincipal struct {
+	Any bool
+	TOS StringList
+}
+
+func (p PolicyPrincipal) MarshalJSON() ([]byte, error) {
+	if p.Any {
+		return json.Marshal("*")
+	}
+	return json.Marshal(struct {
+		TOS StringList `json:"TOS"`
+	}{p.TOS})
+}
+
+func (p *PolicyPrincipal) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*p = PolicyPrincipal{Any: value == "*"}
+		return nil
+	}
+	var principal struct {
+		TOS StringList `json:"TOS"`
+	}
+	if err := json.Unmarshal(data, &principal); err != nil {
+		return err
+	}
+	*p = PolicyPrincipal{TOS: principal.TOS}
+	return nil
+}
+
+// StringList strings of a policy, which is a single string or an array of strings in JSON
+type StringList []string
+
+func (s *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = StringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// ParsePolicyDocument parse a policy, e.g. Policy of GetBucketPolicyV2Output
+func ParsePolicyDocument(policy string) (*PolicyDocument, error) {
+	var document PolicyDocument
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return nil, newTosClientError("tos: unmarshal policy failed", err)
+	}
+	return &document, nil
+}
+
+// PutBucketPolicyV2Input one of Policy and Document is set
+type PutBucketPolicyV2Input struct {
+	Bucket   string
+	Policy   string          // the raw JSON policy
+	Document *PolicyDocument // validated before sending, see PolicyStatementBuilder
+}
+
+type PutBucketPolicyV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketPolicyV2Input struct {
+	Bucket string
+}
+
+type GetBucketPolicyV2Output struct {
+	RequestInfo `json:"-"`
+	Policy      string // the raw JSON policy, see ParsePolicyDocument
+}
+
+type DeleteBucketPolicyV2Input struct {
+	Bucket string
+}
+
+type DeleteBucketPolicyV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+// PutBucketPolicyV2 replace the policy of a bucket
+func (cli *ClientV2) PutBucketPolicyV2(ctx context.Context, input *PutBucketPolicyV2Input, options ...Option) (*PutBucketPolicyV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	policy := input.Policy
+	if input.Document != nil {
+		if len(policy) > 0 {
+			return nil, newTosClientError("tos: only one of Policy and Document can be set", nil)
+		}
+		if err := validatePolicyDocument(input.Bucket, input.Document); err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(input.Document)
+		if err != nil {
+			return nil, newTosClientError("tos: marshal Document failed", err)
+		}
+		policy = string(data)
+	}
+	if len(policy) == 0 {
+		return nil, newTosClientError("tos: empty policy", nil)
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketPolicy).
+		WithQuery("policy", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, strings.NewReader(policy), cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketPolicyV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketPolicyV2 get the policy of a bucket, it fails with code NoSuchBucketPolicy if there's none
+func (cli *ClientV2) GetBucketPolicyV2(ctx context.Context, input *GetBucketPolicyV2Input, options ...Option) (*GetBucketPolicyV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketPolicy).
+		WithQuery("policy", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, newTosClientError("tos: read policy failed", err)
+	}
+	return &GetBucketPolicyV2Output{RequestInfo: res.RequestInfo(), Policy: string(data)}, nil
+}
+
+// DeleteBucketPolicyV2 delete the policy of a bucket
+func (cli *ClientV2) DeleteBucketPolicyV2(ctx context.Context, input *DeleteBucketPolicyV2Input, options ...Option) (*DeleteBucketPolicyV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketPolicy).
+		WithQuery("policy", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketPolicyV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// PolicyStatementBuilder build a PolicyStatement, which is validated by Build, e.g.
+//
+//	statement, err := NewPolicyStatementBuilder(enum.PolicyEffectAllow).AnyPrincipal().
+//		Actions("tos:GetObject").Resources("trn:tos:::bucket/public/*").Build()
+type PolicyStatementBuilder struct {
+	statement PolicyStatement
+}
+
+func NewPolicyStatementBuilder(effect enum.PolicyEffectType) *PolicyStatementBuilder {
+	return &PolicyStatementBuilder{statement: PolicyStatement{Effect: effect}}
+}
+
+func (b *PolicyStatementBuilder) Sid(sid string) *PolicyStatementBuilder {
+	b.statement.Sid = sid
+	return b
+}
+
+// AnyPrincipal apply the statement to everyone, including anonymous users
+func (b *PolicyStatementBuilder) AnyPrincipal() *PolicyStatementBuilder {
+	b.statement.Principal = &PolicyPrincipal{Any: true}
+	return b
+}
+
+// Principals apply the statement to accounts or users, e.g. "2100000001" or "2100000001/user"
+func (b *PolicyStatementBuilder) Principals(principals ...string) *PolicyStatementBuilder {
+	if b.statement.Principal == nil || b.statement.Principal.Any {
+		b.statement.Principal = &PolicyPrincipal{}
+	}
+	b.statement.Principal.TOS = append(b.statement.Principal.TOS, principals...)
+	return b
+}
+
+// Actions e.g. "tos:GetObject", "tos:List*" or "tos:*"
+func (b *PolicyStatementBuilder) Actions(actions ...string) *PolicyStatementBuilder {
+	b.statement.Action = append(b.statement.Action, actions...)
+	return b
+}
+
+// Resources TRNs of the bucket or objects, e.g. "trn:tos:::bucket" or "trn:tos:::bucket/prefix/*"
+func (b *PolicyStatementBuilder) Resources(resources ...string) *PolicyStatementBuilder {
+	b.statement.Resource = append(b.statement.Resource, resources...)
+	return b
+}
+
+// Condition add a condition, e.g. Condition("IpAddress", "tos:SourceIp", "10.0.0.0/8")
+func (b *PolicyStatementBuilder) Condition(operator, key string, values ...string) *PolicyStatementBuilder {
+	if b.statement.Condition == nil {
+		b.statement.Condition = make(map[string]map[string]StringList)
+	}
+	if b.statement.Condition[operator] == nil {
+		b.statement.Condition[operator] = make(map[string]StringList)
+	}
+	b.statement.Condition[operator][key] = append(b.statement.Condition[operator][key], values...)
+	return b
+}
+
+// Build validate and return the statement
+func (b *PolicyStatementBuilder) Build() (PolicyStatement, error) {
+	statement := b.statement
+	statement.Principal = copyPolicyPrincipal(statement.Principal)
+	statement.NotPrincipal = copyPolicyPrincipal(statement.NotPrincipal)
+	statement.Action = append(StringList(nil), statement.Action...)
+	statement.NotAction = append(StringList(nil), statement.NotAction...)
+	statement.Resource = append(StringList(nil), statement.Resource...)
+	statement.NotResource = append(StringList(nil), statement.NotResource...)
+	if statement.Condition != nil {
+		statement.Condition = make(map[string]map[string]StringList, len(b.statement.Condition))
+		for operator, keys := range b.statement.Condition {
+			copied := make(map[string]StringList, len(keys))
+			for key, values := range keys {
+				copied[key] = append(StringList(nil), values...)
+			}
+			statement.Condition[operator] = copied
+		}
+	}
+	if err := validatePolicyStatement("", &statement); err != nil {
+		return PolicyStatement{}, err
+	}
+	return statement, nil
+}
+
+func copyPolicyPrincipal(principal *PolicyPrincipal) *PolicyPrincipal {
+	if principal == nil {
+		return nil
+	}
+	return &PolicyPrincipal{Any: principal.Any, TOS: append(StringList(nil), principal.TOS...)}
+}
+
+const policyResourcePrefix = "trn:tos:::"
+
+// validatePolicyDocument validate statements, and resources of them are in bucket if bucket isn't empty
+func validatePolicyDocument(bucket string, document *PolicyDocument) error {
+	if len(document.Statement) == 0 {
+		return newTosClientError("tos: no statement in policy", nil)
+	}
+	for i := range document.Statement {
+		if err := validatePolicyStatement(bucket, &document.Statement[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePolicyStatement(bucket string, statement *PolicyStatement) error {
+	invalid := func(reason string) error {
+		return newTosClientError(fmt.Sprintf("tos: invalid policy statement %q, %s", statement.Sid, reason), nil)
+	}
+	if statement.Effect != enum.PolicyEffectAllow && statement.Effect != enum.PolicyEffectDeny {
+		return invalid("effect must be Allow or Deny")
+	}
+	if statement.Principal == nil && statement.NotPrincipal == nil {
+		return invalid("principal is required")
+	}
+	if len(statement.Action) == 0 && len(statement.NotAction) == 0 {
+		return invalid("action is required")
+	}
+	for _, actions := range []StringList{statement.Action, statement.NotAction} {
+		for _, action := range actions {
+			if !isValidPolicyAction(action) {
+				return invalid("invalid action " + action)
+			}
+		}
+	}
+	if len(statement.Resource) == 0 && len(statement.NotResource) == 0 {
+		return invalid("resource is required")
+	}
+	for _, resources := range []StringList{statement.Resource, statement.NotResource} {
+		for _, resource := range resources {
+			if !strings.HasPrefix(resource, policyResourcePrefix) {
+				return invalid("resource " + resource + " must start with " + policyResourcePrefix)
+			}
+			name := strings.TrimPrefix(resource, policyResourcePrefix)
+			if slash := strings.IndexByte(name, '/'); slash >= 0 {
+				name = name[:slash]
+			}
+			if len(name) == 0 {
+				return invalid("resource " + resource + " has no bucket")
+			}
+			if len(bucket) > 0 && name != bucket && name != "*" {
+				return invalid("resource " + resource + " is not in bucket " + bucket)
+			}
+		}
+	}
+	return nil
+}
+
+// isValidPolicyAction e.g. "tos:GetObject", "tos:List*" or "*"
+func isValidPolicyAction(action string) bool {
+	if action == "*" {
+		return true
+	}
+	if !strings.HasPrefix(action, "tos:") || len(action) == len("tos:") {
+		return false
+	}
+	for _, c := range action[len("tos:"):] {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '*') {
+			return false
+		}
+	}
+	return true
+}
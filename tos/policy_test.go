@@ -0,0 +1,101 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketPolicyV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusNoContent, "")
+
+	statement, err := NewPolicyStatementBuilder(enum.PolicyEffectAllow).Sid("public").AnyPrincipal().
+		Actions("tos:GetObject", "tos:List*").Resources("trn:tos:::bucket", "trn:tos:::bucket/public/*").
+		Condition("IpAddress", "tos:SourceIp", "10.0.0.0/8").Build()
+	require.Nil(t, err)
+	_, err = client.PutBucketPolicyV2(context.Background(), &PutBucketPolicyV2Input{Bucket: "bucket",
+		Document: &PolicyDocument{Statement: []PolicyStatement{statement}}})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "policy")
+	policy, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Statement":[{"Sid":"public","Effect":"Allow","Principal":"*",
+"Action":["tos:GetObject","tos:List*"],"Resource":["trn:tos:::bucket","trn:tos:::bucket/public/*"],
+"Condition":{"IpAddress":{"tos:SourceIp":["10.0.0.0/8"]}}}]}`, string(policy))
+
+	// the policy is returned as is
+	raw := `{"Statement":[{"Effect":"Deny","Principal":{"TOS":["2100000001"]},"Action":"tos:DeleteObject",
+"Resource":"trn:tos:::bucket/*"}]}`
+	transport.respond(http.StatusOK, raw)
+	out, err := client.GetBucketPolicyV2(context.Background(), &GetBucketPolicyV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, raw, out.Policy)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	document, err := ParsePolicyDocument(out.Policy)
+	require.Nil(t, err)
+	require.Equal(t, []PolicyStatement{{
+		Effect:    enum.PolicyEffectDeny,
+		Principal: &PolicyPrincipal{TOS: StringList{"2100000001"}},
+		Action:    StringList{"tos:DeleteObject"},
+		Resource:  StringList{"trn:tos:::bucket/*"},
+	}}, document.Statement)
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketPolicyV2(context.Background(), &DeleteBucketPolicyV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+
+	// resources of another bucket
+	_, err = client.PutBucketPolicyV2(context.Background(), &PutBucketPolicyV2Input{Bucket: "other",
+		Document: &PolicyDocument{Statement: []PolicyStatement{statement}}})
+	require.NotNil(t, err)
+	_, err = client.PutBucketPolicyV2(context.Background(), &PutBucketPolicyV2Input{Bucket: "bucket",
+		Policy: string(policy), Document: &PolicyDocument{Statement: []PolicyStatement{statement}}})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 3)
+}
+
+func TestPolicyStatementBuilder(t *testing.T) {
+	invalid := []*PolicyStatementBuilder{
+		NewPolicyStatementBuilder("Maybe").AnyPrincipal().Actions("tos:GetObject").Resources("trn:tos:::bucket/*"),
+		NewPolicyStatementBuilder(enum.PolicyEffectAllow).Actions("tos:GetObject").Resources("trn:tos:::bucket/*"),
+		NewPolicyStatementBuilder(enum.PolicyEffectAllow).AnyPrincipal().Resources("trn:tos:::bucket/*"),
+		NewPolicyStatementBuilder(enum.PolicyEffectAllow).AnyPrincipal().Actions("s3:GetObject").
+			Resources("trn:tos:::bucket/*"),
+		NewPolicyStatementBuilder(enum.PolicyEffectAllow).AnyPrincipal().Actions("tos:GetObject"),
+		NewPolicyStatementBuilder(enum.PolicyEffectDeny).AnyPrincipal().Actions("tos:GetObject").
+			Resources("arn:aws:s3:::bucket/*"),
+		NewPolicyStatementBuilder(enum.PolicyEffectDeny).AnyPrincipal().Actions("tos:GetObject").
+			Resources("trn:tos:::/key"),
+	}
+	for _, builder := range invalid {
+		_, err := builder.Build()
+		require.NotNil(t, err)
+	}
+
+	builder := NewPolicyStatementBuilder(enum.PolicyEffectDeny).Principals("2100000001", "2100000001/user").
+		Actions("tos:*").Resources("trn:tos:::bucket/*").Condition("IpAddress", "tos:SourceIp", "10.0.0.0/8")
+	statement, err := builder.Build()
+	require.Nil(t, err)
+	require.Equal(t, StringList{"2100000001", "2100000001/user"}, statement.Principal.TOS)
+	// the builder can be reused without affecting statements built
+	other, err := builder.Principals("2100000002").Resources("trn:tos:::bucket/other/*").
+		Condition("IpAddress", "tos:SourceIp", "192.168.0.0/16").Build()
+	require.Nil(t, err)
+	require.Equal(t, StringList{"2100000001", "2100000001/user"}, statement.Principal.TOS)
+	require.Equal(t, StringList{"trn:tos:::bucket/*"}, statement.Resource)
+	require.Equal(t, StringList{"10.0.0.0/8"}, statement.Condition["IpAddress"]["tos:SourceIp"])
+	require.Len(t, other.Principal.TOS, 3)
+
+	document, err := ParsePolicyDocument(`{"Statement":[{"Effect":"Allow","Principal":{"TOS":"2100000001"},
+"Action":"tos:GetObject","Resource":["trn:tos:::bucket/*"]}]}`)
+	require.Nil(t, err)
+	require.Equal(t, StringList{"2100000001"}, document.Statement[0].Principal.TOS)
+	require.Equal(t, StringList{"tos:GetObject"}, document.Statement[0].Action)
+}
@@ -0,0 +1,87 @@
+package tos
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/codes"
+)
+
+// Predicates below check err returned by operations, err may be wrapped, e.g. by fmt.Errorf with %w.
+// Responses of HEAD requests have no body, so predicates checking error codes are false for them,
+// use predicates checking status codes instead, e.g. IsNotFound.
+
+// IsNotFound report whether err is a TosServerError with status code 404, e.g. NoSuchKey or NoSuchBucket
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsNoSuchBucket report whether err is a TosServerError with code NoSuchBucket
+func IsNoSuchBucket(err error) bool {
+	return hasCode(err, codes.NoSuchBucket)
+}
+
+// IsNoSuchKey report whether err is a TosServerError with code NoSuchKey
+func IsNoSuchKey(err error) bool {
+	return hasCode(err, codes.NoSuchKey)
+}
+
+// IsAccessDenied report whether err is a TosServerError with status code 403
+func IsAccessDenied(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsPreconditionFailed report whether err is a TosServerError with status code 412,
+// e.g. conditions set by WithIfMatch are not met
+func IsPreconditionFailed(err error) bool {
+	return hasStatusCode(err, http.StatusPreconditionFailed)
+}
+
+// IsThrottled report whether err is a TosServerError with status code 429
+func IsThrottled(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// IsNotAppendable report whether err is a TosServerError with code NotAppendable, returned by AppendObjectV2
+// when the object is not appendable, or the bucket doesn't support appending
+func IsNotAppendable(err error) bool {
+	return hasCode(err, codes.NotAppendable)
+}
+
+// IsObjectAlreadyExists report whether err is a TosServerError with code ObjectAlreadyExists, returned by writes
+// with ForbidOverwrite set when the key exists, e.g. PutObjectV2, CompleteMultipartUploadV2 and RenameObjectV2
+func IsObjectAlreadyExists(err error) bool {
+	return hasCode(err, codes.ObjectAlreadyExists)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var se *TosServerError
+	return errors.As(err, &se) && se.StatusCode == statusCode
+}
+
+func hasCode(err error, code string) bool {
+	var se *TosServerError
+	return errors.As(err, &se) && se.Code == code
+}
+
+// Unwrap return Cause, so that errors.Is and errors.As see through TosClientError, e.g.
+//
+//	errors.Is(err, context.DeadlineExceeded)
+//	errors.Is(err, ErrCircuitBreakerOpen)
+func (e *TosClientError) Unwrap() error {
+	return e.Cause
+}
+
+// Is report whether target is a TosServerError matching e, zero fields of target match any value, e.g.
+//
+//	errors.Is(err, &tos.TosServerError{Code: codes.NoSuchKey})
+//	errors.Is(err, &tos.TosServerError{RequestInfo: tos.RequestInfo{StatusCode: 404}})
+func (e *TosServerError) Is(target error) bool {
+	t, ok := target.(*TosServerError)
+	if !ok {
+		return false
+	}
+	return (t.StatusCode == 0 || t.StatusCode == e.StatusCode) &&
+		(len(t.Code) == 0 || t.Code == e.Code) &&
+		(len(t.EC) == 0 || t.EC == e.EC)
+}
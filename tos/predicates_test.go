@@ -0,0 +1,46 @@
+package tos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/codes"
+)
+
+func TestErrorPredicates(t *testing.T) {
+	client, _ := newRecordClient(t, http.StatusNotFound, `{"Code":"NoSuchKey"}`)
+	_, err := client.GetObjectV2(context.Background(), &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.NotNil(t, err)
+
+	wrapped := fmt.Errorf("get object: %w", err)
+	require.True(t, IsNotFound(wrapped))
+	require.True(t, IsNoSuchKey(wrapped))
+	require.False(t, IsNoSuchBucket(wrapped))
+	require.False(t, IsAccessDenied(wrapped))
+	require.False(t, IsPreconditionFailed(wrapped))
+	require.False(t, IsThrottled(wrapped))
+	require.True(t, errors.Is(wrapped, &TosServerError{Code: codes.NoSuchKey}))
+	require.True(t, errors.Is(wrapped, &TosServerError{RequestInfo: RequestInfo{StatusCode: http.StatusNotFound}}))
+	require.False(t, errors.Is(wrapped, &TosServerError{Code: codes.NoSuchBucket}))
+	var se *TosServerError
+	require.True(t, errors.As(wrapped, &se))
+	require.Equal(t, OperationGetObject, se.OperationName)
+
+	require.False(t, IsNotFound(nil))
+	require.False(t, IsNotFound(errors.New("not found")))
+}
+
+func TestTosClientErrorUnwrap(t *testing.T) {
+	err := fmt.Errorf("put object: %w", newTosClientError("tos: request canceled", context.Canceled))
+	require.True(t, errors.Is(err, context.Canceled))
+	var ce *TosClientError
+	require.True(t, errors.As(err, &ce))
+
+	// server errors wrapped by client errors
+	err = newTosClientError("tos: upload stopped", &TosServerError{RequestInfo: RequestInfo{StatusCode: 412}})
+	require.True(t, IsPreconditionFailed(err))
+}
@@ -0,0 +1,47 @@
+package tos
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ProcessObject apply image or video processing input.Process to an object, and save the result to SaveAsKey of
+// SaveAsBucket on the server side, so the result isn't downloaded by the client.
+func (cli *ClientV2) ProcessObject(ctx context.Context, input *ProcessObjectInput, options ...Option) (*ProcessObjectOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key, input.SaveAsKey); err != nil {
+		return nil, err
+	}
+	if len(input.Process) == 0 {
+		return nil, newTosClientError("tos: empty process of ProcessObject", nil)
+	}
+	saveAsBucket := input.SaveAsBucket
+	if len(saveAsBucket) == 0 {
+		saveAsBucket = input.Bucket
+	} else if err := IsValidBucketName(saveAsBucket); err != nil {
+		return nil, err
+	}
+	// the key and bucket of result are URL-safe base64 encoded
+	process := input.Process + "|sys/saveas" +
+		",o_" + base64.URLEncoding.EncodeToString([]byte(input.SaveAsKey)) +
+		",b_" + base64.URLEncoding.EncodeToString([]byte(saveAsBucket))
+	body := url.Values{QueryProcess: []string{process}}.Encode()
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationProcessObject).
+		WithQuery(QueryProcess, "").
+		WithParams(*input).
+		WithHeader(HeaderContentType, "application/x-www-form-urlencoded").
+		WithRetry(nil, ServerErrorClassifier{}).
+		Request(ctx, http.MethodPost, strings.NewReader(body), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := ProcessObjectOutput{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
@@ -0,0 +1,58 @@
+package tos
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetObjectV2Process(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderHashCrc64ecma, "123") // CRC of the original object
+	client, transport := newRecordClient(t, http.StatusOK, "thumbnail")
+	transport.res.Header = header
+	client.enableCRC = true
+
+	output, err := client.GetObjectV2(context.Background(), &GetObjectV2Input{Bucket: "bucket", Key: "a.png",
+		Process: "image/resize,w_100"})
+	require.Nil(t, err)
+	content, err := ioutil.ReadAll(output.Content)
+	require.Nil(t, err)
+	require.Equal(t, "thumbnail", string(content))
+	require.Equal(t, "image/resize,w_100", transport.requests[0].Query.Get(QueryProcess))
+
+	transport.respond(http.StatusOK, "")
+	_, err = client.GetObjectV2(context.Background(), &GetObjectV2Input{Bucket: "bucket", Key: "a.png"})
+	require.Nil(t, err)
+	require.NotContains(t, transport.requests[1].Query, QueryProcess)
+}
+
+func TestProcessObject(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"bucket":"other","object":"thumb/a.png","fileSize":9,"status":"OK"}`)
+
+	output, err := client.ProcessObject(context.Background(), &ProcessObjectInput{Bucket: "bucket", Key: "a.png",
+		Process: "image/resize,w_100", SaveAsBucket: "other", SaveAsKey: "thumb/a.png"})
+	require.Nil(t, err)
+	require.Equal(t, ProcessObjectOutput{RequestInfo: output.RequestInfo, Bucket: "other", Key: "thumb/a.png",
+		FileSize: 9, Status: "OK"}, *output)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, OperationProcessObject, req.OperationName)
+	require.Contains(t, req.Query, QueryProcess)
+	body, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	values, err := url.ParseQuery(string(body))
+	require.Nil(t, err)
+	require.Equal(t, "image/resize,w_100|sys/saveas,o_"+base64.URLEncoding.EncodeToString([]byte("thumb/a.png"))+
+		",b_"+base64.URLEncoding.EncodeToString([]byte("other")), values.Get(QueryProcess))
+
+	_, err = client.ProcessObject(context.Background(), &ProcessObjectInput{Bucket: "bucket", Key: "a.png",
+		Process: "image/resize,w_100"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 1)
+}
@@ -0,0 +1,121 @@
+// Package prometheus adapts tos.MetricsCollector to Prometheus, it's a separate module
+// so that the SDK does not depend on Prometheus.
+//
+// example:
+//
+//	collector := prometheus.NewCollector("myapp")
+//	promclient.MustRegister(collector)
+//	client, err := tos.NewClientV2(endpoint, tos.WithMetricsCollector(collector))
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos"
+)
+
+// Collector records metrics of requests of TOS clients, it implements both tos.MetricsCollector and prometheus.Collector:
+//
+//	<namespace>_tos_requests_total{operation, code}: number of operations, code is "0" if no response is received
+//	<namespace>_tos_request_duration_seconds{operation}: histogram of latency of operations
+//	<namespace>_tos_transferred_bytes_total{operation}: bytes uploaded or downloaded
+//	<namespace>_tos_retries_total{operation}: number of retries
+//	<namespace>_tos_part_queue_wait_seconds{operation}: histogram of time parts of transfers wait for a worker
+//	<namespace>_tos_part_queue_depth{operation}: number of parts of transfers waiting for a worker
+type Collector struct {
+	requests   *prometheus.CounterVec
+	latency    *prometheus.HistogramVec
+	bytes      *prometheus.CounterVec
+	retries    *prometheus.CounterVec
+	queueWait  *prometheus.HistogramVec
+	queueDepth *prometheus.GaugeVec
+}
+
+var _ tos.MetricsCollector = (*Collector)(nil)
+var _ tos.TransferMetricsCollector = (*Collector)(nil)
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector create Collector with metrics in namespace, namespace can be empty
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tos",
+			Name:      "requests_total",
+			Help:      "Number of TOS operations.",
+		}, []string{"operation", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "tos",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of TOS operations including retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tos",
+			Name:      "transferred_bytes_total",
+			Help:      "Bytes uploaded or downloaded by TOS operations.",
+		}, []string{"operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tos",
+			Name:      "retries_total",
+			Help:      "Number of retries of TOS operations.",
+		}, []string{"operation"}),
+		queueWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "tos",
+			Name:      "part_queue_wait_seconds",
+			Help:      "Time parts of TOS transfers wait in the task queue.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "tos",
+			Name:      "part_queue_depth",
+			Help:      "Number of parts of TOS transfers waiting in the task queue.",
+		}, []string{"operation"}),
+	}
+}
+
+// RecordRequest implements tos.MetricsCollector
+func (c *Collector) RecordRequest(operation string, statusCode int, latency time.Duration, bytes int64, retries int) {
+	c.requests.WithLabelValues(operation, strconv.Itoa(statusCode)).Inc()
+	c.latency.WithLabelValues(operation).Observe(latency.Seconds())
+	if bytes > 0 {
+		c.bytes.WithLabelValues(operation).Add(float64(bytes))
+	}
+	if retries > 0 {
+		c.retries.WithLabelValues(operation).Add(float64(retries))
+	}
+}
+
+// RecordPartScheduled implements tos.TransferMetricsCollector, the depth gauge is shared by concurrent transfers
+// of the same operation and reflects the latest one reported
+func (c *Collector) RecordPartScheduled(operation string, wait time.Duration, queueDepth int) {
+	c.queueWait.WithLabelValues(operation).Observe(wait.Seconds())
+	c.queueDepth.WithLabelValues(operation).Set(float64(queueDepth))
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.latency.Describe(ch)
+	c.bytes.Describe(ch)
+	c.retries.Describe(ch)
+	c.queueWait.Describe(ch)
+	c.queueDepth.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.latency.Collect(ch)
+	c.bytes.Collect(ch)
+	c.retries.Collect(ch)
+	c.queueWait.Collect(ch)
+	c.queueDepth.Collect(ch)
+}
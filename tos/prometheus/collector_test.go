@@ -0,0 +1,41 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector("test")
+	registry := prometheus.NewRegistry()
+	require.Nil(t, registry.Register(collector))
+
+	collector.RecordRequest("PutObject", 200, time.Second, 1024, 0)
+	collector.RecordRequest("PutObject", 503, time.Second, 1024, 2)
+	collector.RecordPartScheduled("UploadFile", time.Second, 3)
+
+	families, err := registry.Gather()
+	require.Nil(t, err)
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			switch {
+			case metric.GetGauge() != nil:
+				values[family.GetName()] += metric.GetGauge().GetValue()
+			case metric.GetCounter() != nil:
+				values[family.GetName()] += metric.GetCounter().GetValue()
+			case metric.GetHistogram() != nil:
+				values[family.GetName()] += float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	require.Equal(t, 2.0, values["test_tos_requests_total"])
+	require.Equal(t, 2.0, values["test_tos_request_duration_seconds"])
+	require.Equal(t, 2048.0, values["test_tos_transferred_bytes_total"])
+	require.Equal(t, 2.0, values["test_tos_retries_total"])
+	require.Equal(t, 1.0, values["test_tos_part_queue_wait_seconds"])
+	require.Equal(t, 3.0, values["test_tos_part_queue_depth"])
+}
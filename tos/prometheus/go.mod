@@ -0,0 +1,11 @@
+module github.com/volcengine/ve-tos-golang-sdk/v2/tos/prometheus
+
+go 1.13
+
+require (
+	github.com/prometheus/client_golang v1.12.2
+	github.com/stretchr/testify v1.7.0
+	github.com/volcengine/ve-tos-golang-sdk/v2 v2.0.0-00010101000000-000000000000
+)
+
+replace github.com/volcengine/ve-tos-golang-sdk/v2 => ../..
@@ -0,0 +1,85 @@
+package tos
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// tokenBucketRateLimiter a token bucket, tokens are bytes
+type tokenBucketRateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens added per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// NewDefaultRateLimiter create a token bucket RateLimiter, which allows rate bytes per second on average
+// and bursts up to capacity bytes. capacity less than rate is set to rate.
+// It's safe to be shared by concurrent transfers, e.g. set by WithRateLimiter to limit the bandwidth of a client.
+func NewDefaultRateLimiter(rate int64, capacity int64) RateLimiter {
+	if capacity < rate {
+		capacity = rate
+	}
+	return &tokenBucketRateLimiter{
+		rate:     float64(rate),
+		capacity: float64(capacity),
+		tokens:   float64(capacity),
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+// Acquire implements RateLimiter, want larger than capacity is acquired as capacity
+func (l *tokenBucketRateLimiter) Acquire(want int64) (ok bool, timeToWait time.Duration) {
+	if l.rate <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+	l.last = now
+	need := float64(want)
+	if need > l.capacity {
+		need = l.capacity
+	}
+	if l.tokens >= need {
+		l.tokens -= need
+		return true, 0
+	}
+	return false, time.Duration((need - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *tokenBucketRateLimiter) internal() {}
+
+// ObjectRateLimiter optional interface of RateLimiter.
+// At the start of each transfer, the SDK calls ForObject and limits the transfer with the returned RateLimiter,
+// so one limiter can apply different rates to different objects, e.g. by Size or StorageClass of object.
+type ObjectRateLimiter interface {
+	RateLimiter
+	// ForObject return the RateLimiter of the transfer of object, nil means no limit
+	ForObject(object *TransferObject) RateLimiter
+}
+
+// limiterFor return the RateLimiter limiting the transfer of object
+func limiterFor(limiter RateLimiter, object *TransferObject) RateLimiter {
+	if selector, ok := limiter.(ObjectRateLimiter); ok {
+		return selector.ForObject(object)
+	}
+	return limiter
+}
+
+// limitReader wrap rc with the RateLimiter set by WithRateLimiter if it's set
+func (cli *Client) limitReader(rc io.ReadCloser, object *TransferObject) io.ReadCloser {
+	limiter := limiterFor(cli.rateLimiter, object)
+	if limiter == nil {
+		return rc
+	}
+	return &ReadCloserWithLimiter{limiter: limiter, base: rc}
+}
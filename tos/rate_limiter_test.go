@@ -0,0 +1,153 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestTokenBucketRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewDefaultRateLimiter(100, 200).(*tokenBucketRateLimiter)
+	limiter.last = now
+	limiter.now = func() time.Time { return now }
+
+	ok, _ := limiter.Acquire(150)
+	require.True(t, ok)
+	ok, wait := limiter.Acquire(100)
+	require.False(t, ok)
+	require.Equal(t, 500*time.Millisecond, wait)
+
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = limiter.Acquire(100)
+	require.True(t, ok)
+
+	// want larger than capacity is acquired as capacity
+	now = now.Add(time.Hour)
+	ok, _ = limiter.Acquire(1000)
+	require.True(t, ok)
+	require.Equal(t, float64(0), limiter.tokens)
+}
+
+type countingLimiter struct {
+	mu       sync.Mutex
+	acquired int64
+}
+
+func (l *countingLimiter) Acquire(want int64) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.acquired += want
+	return true, 0
+}
+
+func (l *countingLimiter) internal() {}
+
+// readingTransport reads request body like a real transport
+type readingTransport struct {
+	body string
+}
+
+func (rt *readingTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	if req.Content != nil {
+		_, _ = ioutil.ReadAll(req.Content)
+	}
+	return &Response{StatusCode: http.StatusOK, Header: make(http.Header),
+		Body: ioutil.NopCloser(strings.NewReader(rt.body))}, nil
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	limiter := &countingLimiter{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&readingTransport{body: "world"}),
+		WithRateLimiter(limiter))
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"},
+		Content:             bytes.NewReader([]byte("hello")),
+	})
+	require.Nil(t, err)
+	require.True(t, limiter.acquired > 0)
+
+	acquired := limiter.acquired
+	get, err := client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	_, err = ioutil.ReadAll(get.Content)
+	require.Nil(t, err)
+	require.True(t, limiter.acquired > acquired)
+}
+
+// sizeLimiter limits objects larger than size with limiter
+type sizeLimiter struct {
+	size    int64
+	limiter RateLimiter
+	objects []*TransferObject
+}
+
+func (l *sizeLimiter) Acquire(want int64) (bool, time.Duration) { return true, 0 }
+
+func (l *sizeLimiter) internal() {}
+
+func (l *sizeLimiter) ForObject(object *TransferObject) RateLimiter {
+	l.objects = append(l.objects, object)
+	if object.Size > l.size {
+		return l.limiter
+	}
+	return nil
+}
+
+type startedListener struct {
+	objects []*TransferObject
+}
+
+func (l *startedListener) DataTransferStatusChange(status *DataTransferStatus) {
+	if status.Type == enum.DataTransferStarted {
+		l.objects = append(l.objects, status.Object)
+	}
+}
+
+func (l *startedListener) internal() {}
+
+func TestObjectRateLimiter(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderStorageClass, string(enum.StorageClassIa))
+	header.Set(HeaderContentRange, "bytes 0-4/1000")
+	transport := &recordTransport{res: &Response{StatusCode: http.StatusPartialContent, Header: header, ContentLength: 5,
+		Body: ioutil.NopCloser(strings.NewReader("hello"))}}
+	counting := &countingLimiter{}
+	limiter := &sizeLimiter{size: 100, limiter: counting}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithRateLimiter(limiter))
+	require.Nil(t, err)
+	listener := &startedListener{}
+	ctx := context.Background()
+
+	get, err := client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key", RangeStart: 0, RangeEnd: 4,
+		DataTransferListener: listener})
+	require.Nil(t, err)
+	_, err = ioutil.ReadAll(get.Content)
+	require.Nil(t, err)
+	expected := &TransferObject{Bucket: "bucket", Key: "key", Size: 1000, StorageClass: enum.StorageClassIa}
+	require.Equal(t, []*TransferObject{expected}, limiter.objects)
+	require.Equal(t, expected, listener.objects[0])
+	acquired := counting.acquired
+	require.True(t, acquired > 0)
+
+	// small objects are not limited
+	transport.respond(http.StatusOK, "")
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "small"},
+		Content:             strings.NewReader("hello"),
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(5), limiter.objects[1].Size)
+	require.Equal(t, acquired, counting.acquired)
+}
@@ -0,0 +1,82 @@
+package tos
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// max buckets whose last write time is kept before expired ones are swept
+const readRouterSweepSize = 1024
+
+// readRouter send reads of buckets to the read endpoint set by WithReadEndpoint,
+// except the buckets written via the client within window, whose reads stay on the write endpoint
+type readRouter struct {
+	endpoint  *ResolvedEndpoint
+	writeHost string // host of client, requests routed elsewhere are not affected
+	region    string
+	window    time.Duration
+	now       func() time.Time
+
+	mu     sync.Mutex
+	writes map[string]time.Time // bucket -> last write
+}
+
+func newReadRouter(endpoint, writeHost, region string, window time.Duration) *readRouter {
+	return &readRouter{
+		endpoint:  ParseEndpoint(endpoint),
+		writeHost: writeHost,
+		region:    region,
+		window:    window,
+		now:       time.Now,
+		writes:    make(map[string]time.Time),
+	}
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// pinned return true if bucket is written within window
+func (r *readRouter) pinned(bucket string) bool {
+	if r.window <= 0 {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last, ok := r.writes[bucket]
+	if !ok {
+		return false
+	}
+	if r.now().Sub(last) >= r.window {
+		delete(r.writes, bucket)
+		return false
+	}
+	return true
+}
+
+// route send rb to the read endpoint if it's a read not pinned to the write endpoint
+func (r *readRouter) route(rb *requestBuilder, method string) {
+	if len(rb.Bucket) == 0 || rb.Host != r.writeHost || !isReadMethod(method) || r.pinned(rb.Bucket) {
+		return
+	}
+	route(rb, r.endpoint, r.region)
+}
+
+// written record a successful request, reads of the bucket are pinned for window if it's a write
+func (r *readRouter) written(bucket, method string) {
+	if r.window <= 0 || len(bucket) == 0 || isReadMethod(method) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if len(r.writes) >= readRouterSweepSize {
+		for b, last := range r.writes {
+			if now.Sub(last) >= r.window {
+				delete(r.writes, b)
+			}
+		}
+	}
+	r.writes[bucket] = now
+}
@@ -0,0 +1,45 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "", WithReadEndpoint("https://replica.example.com"), WithReadYourWrites(time.Minute))
+	now := time.Now()
+	client.readRouter.now = func() time.Time { return now }
+	ctx := context.Background()
+	lastHost := func() string {
+		return transport.requests[len(transport.requests)-1].Host
+	}
+
+	_, err := client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, "bucket.replica.example.com", lastHost())
+
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"},
+		Content:             strings.NewReader("hello"),
+	})
+	require.Nil(t, err)
+	require.Equal(t, "bucket.tos-cn-beijing.volces.com", lastHost())
+
+	// pinned to the write endpoint within window
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, "bucket.tos-cn-beijing.volces.com", lastHost())
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "other", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, "other.replica.example.com", lastHost())
+
+	now = now.Add(time.Minute)
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, "bucket.replica.example.com", lastHost())
+}
@@ -0,0 +1,77 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+)
+
+// PutBucketRealTimeLogV2 enable real-time access logging of a bucket to TLS, or change its configuration
+func (cli *ClientV2) PutBucketRealTimeLogV2(ctx context.Context, input *PutBucketRealTimeLogV2Input, options ...Option) (*PutBucketRealTimeLogV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	config := input.Configuration
+	if len(config.Role) == 0 {
+		return nil, newTosClientError("tos: Role of real-time log configuration is required", nil)
+	}
+	if !config.Configuration.UseServiceTopic &&
+		(len(config.Configuration.TLSProjectID) == 0 || len(config.Configuration.TLSTopicID) == 0) {
+		return nil, newTosClientError("tos: TLSProjectID and TLSTopicID are required if UseServiceTopic is false", nil)
+	}
+	in, contentMD5, err := marshalInput("PutBucketRealTimeLogV2Input", struct {
+		RealTimeLogConfiguration RealTimeLogConfiguration `json:"RealTimeLogConfiguration"`
+	}{config})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketRealTimeLog).
+		WithQuery("realtimeLog", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketRealTimeLogV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketRealTimeLogV2 get real-time log configuration of a bucket
+func (cli *ClientV2) GetBucketRealTimeLogV2(ctx context.Context, input *GetBucketRealTimeLogV2Input, options ...Option) (*GetBucketRealTimeLogV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketRealTimeLog).
+		WithQuery("realtimeLog", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketRealTimeLogV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketRealTimeLogV2 disable real-time access logging of a bucket
+func (cli *ClientV2) DeleteBucketRealTimeLogV2(ctx context.Context, input *DeleteBucketRealTimeLogV2Input, options ...Option) (*DeleteBucketRealTimeLogV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketRealTimeLog).
+		WithQuery("realtimeLog", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketRealTimeLogV2Output{RequestInfo: res.RequestInfo()}, nil
+}
@@ -0,0 +1,52 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketRealTimeLogV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	config := RealTimeLogConfiguration{Role: "TOSLogArchiveTLSRole",
+		Configuration: AccessLogConfiguration{TLSProjectID: "project", TLSTopicID: "topic"}}
+	_, err := client.PutBucketRealTimeLogV2(context.Background(), &PutBucketRealTimeLogV2Input{Bucket: "bucket",
+		Configuration: config})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "realtimeLog")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"RealTimeLogConfiguration":{"Role":"TOSLogArchiveTLSRole",
+"Configuration":{"UseServiceTopic":false,"TLSProjectID":"project","TLSTopicID":"topic"}}}`, string(data))
+
+	// logs are delivered to the topic created by the service
+	transport.respond(http.StatusOK, `{"RealTimeLogConfiguration":{"Role":"TOSLogArchiveTLSRole",
+"Configuration":{"UseServiceTopic":true,"TLSProjectID":"service-project","TLSTopicID":"service-topic"}}}`)
+	out, err := client.GetBucketRealTimeLogV2(context.Background(), &GetBucketRealTimeLogV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, RealTimeLogConfiguration{Role: "TOSLogArchiveTLSRole", Configuration: AccessLogConfiguration{
+		UseServiceTopic: true, TLSProjectID: "service-project", TLSTopicID: "service-topic"}}, out.Configuration)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "realtimeLog")
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketRealTimeLogV2(context.Background(), &DeleteBucketRealTimeLogV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+
+	for _, config := range []RealTimeLogConfiguration{
+		{Configuration: AccessLogConfiguration{UseServiceTopic: true}},
+		{Role: "TOSLogArchiveTLSRole", Configuration: AccessLogConfiguration{TLSProjectID: "project"}},
+	} {
+		_, err = client.PutBucketRealTimeLogV2(context.Background(), &PutBucketRealTimeLogV2Input{Bucket: "bucket",
+			Configuration: config})
+		require.NotNil(t, err)
+	}
+	require.Len(t, transport.requests, 3)
+}
@@ -0,0 +1,59 @@
+package tos
+
+import (
+	"net/http"
+	"sync"
+)
+
+// redirectCache caches endpoints of buckets learned from 301 responses
+type redirectCache struct {
+	mu        sync.RWMutex
+	endpoints map[string]*ResolvedEndpoint
+}
+
+func newRedirectCache() *redirectCache {
+	return &redirectCache{endpoints: make(map[string]*ResolvedEndpoint)}
+}
+
+func (c *redirectCache) get(bucket string) *ResolvedEndpoint {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.endpoints[bucket]
+}
+
+func (c *redirectCache) set(bucket string, endpoint *ResolvedEndpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.endpoints[bucket] = endpoint
+}
+
+// redirectEndpoint return the endpoint indicated by a 301 response, nil if absent
+func redirectEndpoint(scheme string, header http.Header) *ResolvedEndpoint {
+	region := header.Get(HeaderBucketRegion)
+	if len(region) == 0 {
+		return nil
+	}
+	endpoint, ok := SupportedRegion()[region]
+	if !ok {
+		endpoint = scheme + "://tos-" + region + ".volces.com"
+	}
+	resolved := ParseEndpoint(endpoint)
+	resolved.SigningRegion = region
+	return resolved
+}
+
+// onRedirect re-route rb if err is a 301 response of a bucket in another region,
+// return true if the request should be sent again
+func (cli *Client) onRedirect(rb *requestBuilder, err error) bool {
+	se, ok := err.(*TosServerError)
+	if !ok || se.StatusCode != http.StatusMovedPermanently || len(rb.Bucket) == 0 {
+		return false
+	}
+	endpoint := redirectEndpoint(rb.Scheme, se.Header)
+	if endpoint == nil || endpoint.Host == rb.Host {
+		return false
+	}
+	cli.redirects.set(rb.Bucket, endpoint)
+	route(rb, endpoint, cli.config.Region)
+	return true
+}
@@ -0,0 +1,74 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// regionTransport returns 301 for requests not sent to the host of bucket region
+type regionTransport struct {
+	host     string
+	requests []*Request
+}
+
+func (rt *regionTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.requests = append(rt.requests, req)
+	if req.Content != nil {
+		_, _ = ioutil.ReadAll(req.Content)
+	}
+	header := make(http.Header)
+	if !strings.HasSuffix(req.Host, rt.host) {
+		header.Set(HeaderBucketRegion, "cn-guangzhou")
+		return &Response{StatusCode: http.StatusMovedPermanently, Header: header,
+			Body: ioutil.NopCloser(strings.NewReader(`{"Code":"PermanentRedirect"}`))}, nil
+	}
+	return &Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestAutoRedirect(t *testing.T) {
+	transport := &regionTransport{host: "tos-cn-guangzhou.volces.com"}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithRegion("test-region"), WithTransport(transport),
+		WithCredentials(NewStaticCredentials("ak", "sk")), WithAutoRedirect(true))
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Len(t, transport.requests, 2)
+	require.Equal(t, "bucket.tos-cn-beijing.volces.com", transport.requests[0].Host)
+	require.Equal(t, "bucket.tos-cn-guangzhou.volces.com", transport.requests[1].Host)
+	require.Contains(t, transport.requests[1].Header.Get(authorization), "/cn-guangzhou/")
+
+	// the endpoint of bucket is cached
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "other"})
+	require.Nil(t, err)
+	require.Len(t, transport.requests, 3)
+	require.Equal(t, "bucket.tos-cn-guangzhou.volces.com", transport.requests[2].Host)
+
+	// redirection is disabled by default
+	client, err = NewClientV2("tos-cn-beijing.volces.com", WithRegion("test-region"), WithTransport(transport))
+	require.Nil(t, err)
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Equal(t, http.StatusMovedPermanently, StatusCode(err))
+}
+
+func TestAutoRedirectRewind(t *testing.T) {
+	transport := &regionTransport{host: "tos-cn-guangzhou.volces.com"}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithRegion("test-region"), WithTransport(transport),
+		WithAutoRedirect(true))
+	require.Nil(t, err)
+
+	body := strings.NewReader("hello")
+	res, err := client.newBuilder("bucket", "key").
+		Request(context.Background(), http.MethodPut, body, client.roundTripper(http.StatusOK))
+	require.Nil(t, err)
+	defer res.Close()
+	require.Len(t, transport.requests, 2)
+	require.Equal(t, int64(5), *transport.requests[1].ContentLength)
+	require.Equal(t, 0, body.Len())
+}
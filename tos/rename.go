@@ -0,0 +1,28 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+)
+
+// RenameObjectV2 rename Key to NewKey on the server side, which is atomic and doesn't copy data.
+// It's only supported by buckets with hierarchical namespace enabled, other buckets should copy and delete the object.
+// A directory is renamed with all objects in it if Key and NewKey end with '/'.
+//
+// Renaming is not retried, since a retry of a rename which succeeded fails as the object doesn't exist any more.
+func (cli *ClientV2) RenameObjectV2(ctx context.Context, input *RenameObjectV2Input, options ...Option) (*RenameObjectV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key, input.NewKey); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationRenameObject).
+		WithQuery("rename", "").
+		WithParams(*input).
+		WithRetry(nil, NoRetryClassifier{}).
+		Request(ctx, http.MethodPut, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &RenameObjectV2Output{RequestInfo: res.RequestInfo()}, nil
+}
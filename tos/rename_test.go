@@ -0,0 +1,32 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameObjectV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusNoContent, "")
+
+	_, err := client.RenameObjectV2(context.Background(), &RenameObjectV2Input{Bucket: "bucket", Key: "dir/old",
+		NewKey: "dir/new", ForbidOverwrite: true})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Equal(t, OperationRenameObject, req.OperationName)
+	require.Contains(t, req.Query, "rename")
+	require.Equal(t, "dir/new", req.Query.Get("name"))
+	require.Equal(t, "true", req.Header.Get(HeaderForbidOverwrite))
+
+	_, err = client.RenameObjectV2(context.Background(), &RenameObjectV2Input{Bucket: "bucket", Key: "dir/old"})
+	require.NotNil(t, err)
+
+	transport.respond(http.StatusConflict, `{"Code":"ObjectAlreadyExists"}`)
+	_, err = client.RenameObjectV2(context.Background(), &RenameObjectV2Input{Bucket: "bucket", Key: "dir/old",
+		NewKey: "dir/new", ForbidOverwrite: true})
+	require.Equal(t, http.StatusConflict, StatusCode(err))
+	require.Len(t, transport.requests, 2)
+}
@@ -27,9 +27,12 @@ const (
 	urlModeDefault = 0
 	// urlModePath url pattern is http(s)://domain/{bucket}/{object}
 	urlModePath = 1
+	// urlModeCustomDomain url pattern is http(s)://domain/{object}, the domain is bound to a bucket
+	urlModeCustomDomain = 2
 )
 
 type Request struct {
+	OperationName string // e.g. PutObject, see Operation* constants
 	Scheme        string
 	Method        string
 	Host          string
@@ -40,12 +43,14 @@ type Request struct {
 	Header        http.Header
 }
 
+// URL return the URL of request, path and query are escaped the same way as signing does, see EscapeKey
 func (req *Request) URL() string {
 	u := url.URL{
 		Scheme:   req.Scheme,
 		Host:     req.Host,
 		Path:     req.Path,
-		RawQuery: req.Query.Encode(),
+		RawPath:  escapePath(req.Path),
+		RawQuery: escapeQuery(req.Query),
 	}
 	return u.String()
 }
@@ -79,23 +84,66 @@ type requestBuilder struct {
 	Header        http.Header
 	Retry         *retryer
 	OnRetry       func(req *Request)
-	Classifier    classifier
-	CopySource    *CopySource
+	// reopened the content is reopened by OnRetry, so it can be sent again even if it's not seekable
+	reopened   bool
+	Classifier Classifier
+	// UserClassifier nullable, set by WithRetryClassifier, it takes precedence over the default Classifier
+	UserClassifier Classifier
+	OperationName  string
+	CopySource     *CopySource
+	// OperationTimeout the total time limit of the request including retries and reading response body, 0 means no limit
+	OperationTimeout time.Duration
+	// ResponseHeaderTimeout the time limit of waiting for response headers of each attempt, 0 means the client-level setting
+	ResponseHeaderTimeout time.Duration
+	// Middlewares wrap signing and sending of each attempt, the first one is the outermost, set by WithMiddleware
+	Middlewares []Middleware
+	// OnRedirect nullable, re-route the request if err is a redirection, return true if it should be sent again
+	OnRedirect func(rb *requestBuilder, err error) bool
+	// logger nullable, log completed requests
+	logger Logger
+	// tracer nullable, emit a span for the request, set by WithTracerProvider
+	tracer Tracer
+	// hooks nullable, called around each attempt, set by WithHooks
+	hooks *Hooks
+	// metrics nullable, record metrics of the request, set by WithMetricsCollector
+	metrics MetricsCollector
+	// idempotency add an idempotency token to mutating requests, set by WithIdempotencyToken
+	idempotency bool
+	// stats nullable, statistics of operations of the client, see Client.Stats
+	stats *clientStats
+	// limits nullable, limit concurrent requests of each operation class, set by WithOperationConcurrency
+	limits *operationLimits
+	// attempts number of times the request is sent, including retries and redirects
+	attempts int
+	// readRouter nullable, send reads to the read endpoint and record writes, set by WithReadEndpoint
+	readRouter *readRouter
+	// err occurs when building the request, e.g. failed to resolve endpoint, returned by Request and PreSignedURL
+	err error
 	// CheckETag  bool
 	// CheckCRC32 bool
 }
 
-func (rb *requestBuilder) WithRetry(onRetry func(req *Request), classifier classifier) *requestBuilder {
+// WithOperation set operation name of the request, see Operation* constants
+func (rb *requestBuilder) WithOperation(name string) *requestBuilder {
+	rb.OperationName = name
+	return rb
+}
+
+func (rb *requestBuilder) WithRetry(onRetry func(req *Request), classifier Classifier) *requestBuilder {
 	if onRetry == nil {
 		rb.OnRetry = func(req *Request) {}
 	} else {
 		rb.OnRetry = onRetry
 	}
+	rb.reopened = onRetry != nil
 	if classifier == nil {
-		rb.Classifier = NoRetryClassifier{}
-	} else {
-		rb.Classifier = classifier
+		classifier = NoRetryClassifier{}
+	}
+	// NoRetryClassifier means the request can never be retried safely, don't override it
+	if _, noRetry := classifier.(NoRetryClassifier); !noRetry && rb.UserClassifier != nil {
+		classifier = rb.UserClassifier
 	}
+	rb.Classifier = classifier
 	return rb
 }
 
@@ -190,6 +238,9 @@ func (rb *requestBuilder) WithContentLength(length int64) *requestBuilder {
 }
 
 func (rb *requestBuilder) hostPath() (string, string) {
+	if rb.URLMode == urlModeCustomDomain {
+		return rb.Host, "/" + rb.Object
+	}
 	if rb.URLMode == urlModePath {
 		if len(rb.Object) > 0 {
 			return rb.Host, "/" + rb.Bucket + "/" + rb.Object
@@ -207,13 +258,14 @@ func (rb *requestBuilder) hostPath() (string, string) {
 func (rb *requestBuilder) build(method string, content io.Reader) *Request {
 	host, path := rb.hostPath()
 	req := &Request{
-		Scheme:  rb.Scheme,
-		Method:  method,
-		Host:    host,
-		Path:    path,
-		Content: content,
-		Query:   rb.Query,
-		Header:  rb.Header,
+		OperationName: rb.OperationName,
+		Scheme:        rb.Scheme,
+		Method:        method,
+		Host:          host,
+		Path:          path,
+		Content:       content,
+		Query:         rb.Query,
+		Header:        rb.Header,
 	}
 
 	if content != nil {
@@ -227,53 +279,325 @@ func (rb *requestBuilder) build(method string, content io.Reader) *Request {
 }
 
 func (rb *requestBuilder) Build(method string, content io.Reader) *Request {
+	req := rb.prepare(method, content)
+	rb.sign(req)
+	return req
+}
+
+// prepare build the request without signing it
+func (rb *requestBuilder) prepare(method string, content io.Reader) *Request {
 	req := rb.build(method, content)
 	if rb.CopySource != nil {
 		versionID := req.Query.Get("versionId")
 		req.Query.Del("versionId")
-		req.Header.Add(HeaderCopySource, copySource(rb.CopySource.srcBucket, rb.CopySource.srcObjectKey, versionID))
+		req.Header.Set(HeaderCopySource, copySource(rb.CopySource.srcBucket, rb.CopySource.srcObjectKey, versionID))
 	}
+	return req
+}
+
+func (rb *requestBuilder) sign(req *Request) {
 	if rb.Signer != nil {
 		signed := rb.Signer.SignHeader(req)
 		for key, values := range signed {
 			req.Header[key] = values
 		}
 	}
-	return req
+}
+
+// newRequest build the request to send with roundTripper.
+// With middlewares, the request is signed after all middlewares, so they can change it before signing.
+func (rb *requestBuilder) newRequest(method string, content io.Reader, roundTripper roundTripper) (*Request, roundTripper) {
+	if len(rb.Middlewares) == 0 {
+		return rb.Build(method, content), roundTripper
+	}
+	next := func(ctx context.Context, req *Request) (*Response, error) {
+		rb.sign(req)
+		return roundTripper(ctx, req)
+	}
+	return rb.prepare(method, content), chainMiddlewares(rb.Middlewares, next)
 }
 
 type roundTripper func(ctx context.Context, req *Request) (*Response, error)
 
 func (rb *requestBuilder) Request(ctx context.Context, method string,
 	content io.Reader, roundTripper roundTripper) (*Response, error) {
+	if rb.err != nil {
+		return nil, rb.err
+	}
+	if rb.idempotency {
+		rb.setIdempotencyToken(method)
+	}
+	var sem semaphore
+	if rb.limits != nil {
+		sem = rb.limits.semaphore(rb.OperationName)
+	}
+	if sem == nil {
+		return rb.observe(ctx, method, content, roundTripper)
+	}
+	if err := sem.acquire(ctx); err != nil {
+		return nil, withOperationName(err, rb.OperationName)
+	}
+	res, err := rb.observe(ctx, method, content, roundTripper)
+	// downloads hold the slot while reading body
+	if err == nil && sem == rb.limits.get && res.Body != nil {
+		res.Body = &releaseOnClose{ReadCloser: res.Body, release: sem.release}
+	} else {
+		sem.release()
+	}
+	return res, err
+}
 
+// observe send the request with tracing, metrics and stats
+func (rb *requestBuilder) observe(ctx context.Context, method string,
+	content io.Reader, roundTripper roundTripper) (*Response, error) {
+	if rb.tracer == nil && rb.metrics == nil && rb.stats == nil {
+		return rb.do(ctx, method, content, roundTripper)
+	}
 	var (
-		req *Request
-		res *Response
-		err error
+		start = time.Now()
+		span  Span
+		stats *operationStats
 	)
+	if rb.tracer != nil {
+		ctx, span = rb.startSpan(ctx)
+	}
+	if rb.stats != nil {
+		stats = rb.stats.operation(rb.OperationName)
+		stats.start()
+	}
+	res, err := rb.do(ctx, method, content, roundTripper)
+	if span != nil {
+		rb.endSpan(span, res, err)
+	}
+	if rb.metrics != nil {
+		rb.recordMetrics(start, res, err)
+	}
+	if stats != nil {
+		rb.recordStats(stats, start, res, err)
+	}
+	return res, err
+}
+
+func (rb *requestBuilder) do(ctx context.Context, method string,
+	content io.Reader, roundTripper roundTripper) (*Response, error) {
+	if rb.readRouter != nil {
+		rb.readRouter.route(rb, method)
+	}
+
+	if rb.ResponseHeaderTimeout > 0 {
+		roundTripper = withResponseHeaderTimeout(roundTripper, rb.ResponseHeaderTimeout)
+	}
+	if rb.OperationTimeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, rb.OperationTimeout)
+		res, err := rb.send(ctx, method, content, roundTripper)
+		if err != nil || res.Body == nil {
+			cancel()
+			return res, err
+		}
+		res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+		return res, nil
+	}
+	return rb.send(ctx, method, content, roundTripper)
+}
+
+// send the request, and send it again if it's redirected to another endpoint by OnRedirect
+func (rb *requestBuilder) send(ctx context.Context, method string,
+	content io.Reader, roundTripper roundTripper) (res *Response, err error) {
+	if rb.readRouter != nil {
+		defer func() {
+			if err == nil {
+				rb.readRouter.written(rb.Bucket, method)
+			}
+		}()
+	}
+	var start int64 = -1
+	seeker, seekable := content.(io.Seeker)
+	if rb.OnRedirect != nil && seekable {
+		if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			start = offset
+		}
+	}
+	req, rt := rb.newRequest(method, content, roundTripper)
+	res, err = rb.request(ctx, req, rt)
+	if err == nil || rb.OnRedirect == nil || !rb.OnRedirect(rb, err) {
+		return res, err
+	}
+	// content can't be sent again
+	if content != nil && (start < 0 || !rewind(seeker, start)) {
+		return res, err
+	}
+	req, rt = rb.newRequest(method, content, roundTripper)
+	return rb.request(ctx, req, rt)
+}
+
+func rewind(seeker io.Seeker, offset int64) bool {
+	_, err := seeker.Seek(offset, io.SeekStart)
+	return err == nil
+}
 
-	req = rb.Build(method, content)
+// rewindOnRetry return a function rewinding content of req to where it starts, so that a retry sends the whole
+// content instead of what's left by the failed attempt, and whether content can be rewound. It does nothing if
+// content is not seekable, then onRetry of WithRetry should replace content of req, or the request is not retried.
+func rewindOnRetry(req *Request) (func() error, bool) {
+	if req.Content == nil {
+		return func() error { return nil }, true
+	}
+	seeker, ok := req.Content.(io.Seeker)
+	if !ok {
+		return func() error { return nil }, false
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return func() error { return nil }, false
+	}
+	return func() error {
+		if !rewind(seeker, start) {
+			return newTosClientError("tos: rewind content failed", nil)
+		}
+		return nil
+	}, true
+}
 
+func (rb *requestBuilder) request(ctx context.Context, req *Request, roundTripper roundTripper) (res *Response, err error) {
+	if rb.logger != nil {
+		start := time.Now()
+		defer func() {
+			if err != nil {
+				rb.logger.Debug("tos: request failed", "operation", rb.OperationName, "method", req.Method,
+					"host", req.Host, "path", req.Path, "statusCode", StatusCode(err), "requestID", RequestID(err),
+					"elapsed", time.Since(start), "error", err)
+				return
+			}
+			rb.logger.Debug("tos: request", "operation", rb.OperationName, "method", req.Method,
+				"host", req.Host, "path", req.Path, "statusCode", res.StatusCode, "requestID", res.RequestInfo().RequestID,
+				"elapsed", time.Since(start))
+		}()
+	}
 	if rb.Retry != nil {
+		var (
+			tries   int
+			lastErr error
+		)
+		rewind, rewindable := rewindOnRetry(req)
+		classifier := rb.Classifier
+		if !rewindable && !rb.reopened {
+			// the content can't be sent again, whatever the classifier is, e.g. the one set by WithRetryClassifier
+			classifier = NoRetryClassifier{}
+		}
 		work := func() (err error) {
+			if tries > 0 && rb.hooks != nil && rb.hooks.OnRetry != nil {
+				if err = callHook(func() {
+					rb.hooks.OnRetry(ctx, &RetryEvent{OperationName: rb.OperationName, Attempt: rb.attempts + 1, Err: lastErr})
+				}); err != nil {
+					return err
+				}
+			}
+			if tries > 0 {
+				if err = rewind(); err != nil {
+					return err
+				}
+			}
+			tries++
 			rb.OnRetry(req)
-			res, err = roundTripper(ctx, req)
+			res, err = rb.attempt(ctx, req, roundTripper)
+			lastErr = err
 			return err
 		}
-		err = rb.Retry.Run(ctx, work, rb.Classifier)
+		err = rb.Retry.Run(ctx, work, classifier)
 		if err != nil {
-			return nil, err
+			return nil, withOperationName(err, rb.OperationName)
 		}
 		return res, err
 	}
 
-	res, err = roundTripper(ctx, req)
+	res, err = rb.attempt(ctx, req, roundTripper)
+	if err != nil {
+		return nil, withOperationName(err, rb.OperationName)
+	}
+	return res, err
+}
 
+// attempt send req once and call hooks around it
+func (rb *requestBuilder) attempt(ctx context.Context, req *Request, roundTripper roundTripper) (*Response, error) {
+	rb.attempts++
+	if rb.hooks == nil {
+		return roundTripper(ctx, req)
+	}
+	attempt := rb.attempts
+	if rb.hooks.OnRequest != nil {
+		if err := callHook(func() {
+			rb.hooks.OnRequest(ctx, &RequestEvent{OperationName: rb.OperationName, Attempt: attempt, Request: req})
+		}); err != nil {
+			return nil, err
+		}
+	}
+	start := time.Now()
+	res, err := roundTripper(ctx, req)
+	if rb.hooks.OnResponse != nil {
+		if herr := callHook(func() {
+			rb.hooks.OnResponse(ctx, &ResponseEvent{OperationName: rb.OperationName, Attempt: attempt, Request: req,
+				Response: res, Err: err, Latency: time.Since(start)})
+		}); herr != nil {
+			if err == nil {
+				_ = res.Close()
+			}
+			return nil, herr
+		}
+	}
 	return res, err
 }
 
+// cancelOnClose calls cancel once the response body is closed,
+// so the context of the request stays alive while reading the body
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+type responseHeaderTimeoutError struct{}
+
+func (responseHeaderTimeoutError) Error() string {
+	return "tos: timeout awaiting response headers"
+}
+
+func (responseHeaderTimeoutError) Timeout() bool { return true }
+
+func (responseHeaderTimeoutError) Temporary() bool { return true }
+
+// withResponseHeaderTimeout cancels an attempt if its response headers are not received within timeout
+func withResponseHeaderTimeout(rt roundTripper, timeout time.Duration) roundTripper {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		timer := time.AfterFunc(timeout, cancel)
+		res, err := rt(ctx, req)
+		if !timer.Stop() {
+			// the attempt is canceled by timer, the body of res is unreadable
+			if err == nil {
+				_ = res.Close()
+			}
+			cancel()
+			terr := responseHeaderTimeoutError{}
+			return nil, newTosClientError(terr.Error(), terr)
+		}
+		if err != nil || res.Body == nil {
+			cancel()
+			return res, err
+		}
+		res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+		return res, nil
+	}
+}
+
 func (rb *requestBuilder) PreSignedURL(method string, ttl time.Duration) (string, error) {
+	if rb.err != nil {
+		return "", rb.err
+	}
 	req := rb.build(method, nil)
 	if rb.Signer == nil {
 		return "", errors.New("tos: credentials is not set when the tos.Client was created")
@@ -286,9 +610,12 @@ func (rb *requestBuilder) PreSignedURL(method string, ttl time.Duration) (string
 	return req.URL(), nil
 }
 
+// RequestInfo is embedded by every output, and by TosServerError, to correlate calls with server logs
 type RequestInfo struct {
-	RequestID  string
-	ID2        string
+	RequestID string
+	ID2       string
+	// EC detailed error code of the server, e.g. "0006-00000001", usually set on errors only
+	EC         string
 	StatusCode int
 	Header     http.Header
 }
@@ -304,6 +631,7 @@ func (r *Response) RequestInfo() RequestInfo {
 	return RequestInfo{
 		RequestID:  r.Header.Get(HeaderRequestID),
 		ID2:        r.Header.Get(HeaderID2),
+		EC:         r.Header.Get(HeaderEC),
 		StatusCode: r.StatusCode,
 		Header:     r.Header,
 	}
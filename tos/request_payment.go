@@ -0,0 +1,58 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// PutBucketRequestPaymentV2 set who pays for requests and traffic of a bucket. Requests to a requester-pays
+// bucket from others fail with 403 unless RequestPayer of inputs is "requester".
+func (cli *ClientV2) PutBucketRequestPaymentV2(ctx context.Context, input *PutBucketRequestPaymentV2Input, options ...Option) (*PutBucketRequestPaymentV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if input.Payer != enum.PayerBucketOwner && input.Payer != enum.PayerRequester {
+		return nil, newTosClientError("tos: Payer must be BucketOwner or Requester", nil)
+	}
+	in, contentMD5, err := marshalInput("PutBucketRequestPaymentV2Input", struct {
+		Payer enum.PayerType `json:"Payer"`
+	}{input.Payer})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketRequestPayment).
+		WithQuery("requestPayment", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketRequestPaymentV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketRequestPaymentV2 get who pays for requests and traffic of a bucket
+func (cli *ClientV2) GetBucketRequestPaymentV2(ctx context.Context, input *GetBucketRequestPaymentV2Input, options ...Option) (*GetBucketRequestPaymentV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketRequestPayment).
+		WithQuery("requestPayment", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketRequestPaymentV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
@@ -0,0 +1,116 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestBucketRequestPaymentV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	_, err := client.PutBucketRequestPaymentV2(context.Background(), &PutBucketRequestPaymentV2Input{Bucket: "bucket",
+		Payer: enum.PayerRequester})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "requestPayment")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"Payer":"Requester"}`, string(data))
+
+	transport.respond(http.StatusOK, `{"Payer":"BucketOwner"}`)
+	out, err := client.GetBucketRequestPaymentV2(context.Background(), &GetBucketRequestPaymentV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, enum.PayerBucketOwner, out.Payer)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "requestPayment")
+
+	_, err = client.PutBucketRequestPaymentV2(context.Background(), &PutBucketRequestPaymentV2Input{Bucket: "bucket"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 2)
+}
+
+func TestRequestPayer(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	ctx := context.Background()
+
+	_, err := client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key", RequestPayer: "requester"})
+	require.Nil(t, err)
+	transport.respond(http.StatusOK, "{}")
+	_, err = client.ListObjectsV2(ctx, &ListObjectsV2Input{Bucket: "bucket", RequestPayer: "requester"})
+	require.Nil(t, err)
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.AbortMultipartUpload(ctx, &AbortMultipartUploadInput{Bucket: "bucket", Key: "key",
+		UploadID: "upload", RequestPayer: "requester"})
+	require.Nil(t, err)
+	transport.respond(http.StatusOK, "")
+	_, err = client.SetObjectMetaV2(ctx, &SetObjectMetaV2Input{Bucket: "bucket", Key: "key",
+		RequestPayer: "requester", Meta: map[string]string{"k": "v"}})
+	require.Nil(t, err)
+	require.Equal(t, "v", transport.requests[3].Header.Get(HeaderMetaPrefix+"k"))
+	_, err = client.PutObjectACLV2(ctx, &PutObjectACLV2Input{Bucket: "bucket", Key: "key", ACL: enum.ACLPrivate,
+		RequestPayer: "requester"})
+	require.Nil(t, err)
+	transport.respond(http.StatusOK, "{}")
+	_, err = client.GetObjectACLV2(ctx, &GetObjectACLV2Input{Bucket: "bucket", Key: "key", RequestPayer: "requester"})
+	require.Nil(t, err)
+	transport.respond(http.StatusOK, "{}")
+	_, err = client.DeleteMultiObjects(ctx, &DeleteMultiObjectsInput{Bucket: "bucket",
+		Objects: []ObjectTobeDeleted{{Key: "key"}}, RequestPayer: "requester"})
+	require.Nil(t, err)
+	require.Len(t, transport.requests, 7)
+	for _, req := range transport.requests {
+		require.Equal(t, "requester", req.Header.Get(HeaderRequestPayer))
+	}
+}
+
+// payerTransport record X-Tos-Request-Payer of requests served by multipartTransport
+type payerTransport struct {
+	multipartTransport
+	payers []string
+}
+
+func (rt *payerTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.mu.Lock()
+	rt.payers = append(rt.payers, req.Header.Get(HeaderRequestPayer))
+	rt.mu.Unlock()
+	return rt.multipartTransport.RoundTrip(ctx, req)
+}
+
+func TestUploadFileRequestPayer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-upload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	require.Nil(t, ioutil.WriteFile(file, []byte("hello"), 0666))
+
+	transport := &payerTransport{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+	_, err = client.UploadFile(context.Background(), &UploadFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key",
+			RequestPayer: "requester"},
+		FilePath: file,
+	})
+	require.Nil(t, err)
+	// create, upload part and complete
+	require.Equal(t, []string{"requester", "requester", "requester"}, transport.payers)
+}
+
+func TestDeletePrefixRequestPayer(t *testing.T) {
+	transport := &prefixTransport{deletes: &deleteTransport{}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+	_, err = client.DeletePrefix(context.Background(), &DeletePrefixInput{Bucket: "bucket", Prefix: "dir/",
+		RequestPayer: "requester"})
+	require.Nil(t, err)
+	// list 2 pages and delete 2 batches
+	require.Equal(t, []string{"requester", "requester", "requester", "requester"}, transport.payers)
+}
@@ -1,13 +1,21 @@
 package tos
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -122,3 +130,350 @@ func TestFileUnreadSize(t *testing.T) {
 //	}
 //
 // }
+
+type conflictClassifier struct{}
+
+func (conflictClassifier) Classify(err error) RetryAction {
+	if StatusCode(err) == http.StatusConflict {
+		return Retry
+	}
+	return NoRetry
+}
+
+func TestWithRetryClassifier(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithRetryClassifier(conflictClassifier{}))
+	require.Nil(t, err)
+
+	rb := client.newBuilder("bucket", "key")
+	require.Equal(t, conflictClassifier{}, rb.Classifier)
+	rb.WithRetry(nil, ServerErrorClassifier{})
+	require.Equal(t, conflictClassifier{}, rb.Classifier)
+	// requests never retried are not affected
+	rb.WithRetry(nil, NoRetryClassifier{})
+	require.Equal(t, NoRetryClassifier{}, rb.Classifier)
+
+	client, err = NewClientV2("tos-cn-beijing.volces.com")
+	require.Nil(t, err)
+	rb = client.newBuilder("bucket", "key").WithRetry(nil, ServerErrorClassifier{})
+	require.Equal(t, ServerErrorClassifier{}, rb.Classifier)
+
+	// content which can't be rewound is never sent again
+	transport := &flakyBodyTransport{}
+	client, err = NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(1),
+		WithRetryBackoff(time.Millisecond, time.Millisecond), WithRetryClassifier(StatusCodeClassifier{}))
+	require.Nil(t, err)
+	_, err = client.newBuilder("bucket", "key").WithRetry(nil, StatusCodeClassifier{}).
+		Request(context.Background(), http.MethodPut, ioutil.NopCloser(strings.NewReader("hello")), client.roundTripper(http.StatusOK))
+	require.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+	require.Equal(t, []string{"hello"}, transport.bodies)
+
+	transport.bodies = nil
+	_, err = client.newBuilder("bucket", "key").WithRetry(nil, StatusCodeClassifier{}).
+		Request(context.Background(), http.MethodPut, strings.NewReader("hello"), client.roundTripper(http.StatusOK))
+	require.Nil(t, err)
+	require.Equal(t, []string{"hello", "hello"}, transport.bodies)
+}
+
+// flakyBodyTransport fail the first attempt with 503 after reading its body, and record bodies of all attempts
+type flakyBodyTransport struct {
+	bodies []string
+	md5s   []string
+}
+
+func (rt *flakyBodyTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	body, _ := ioutil.ReadAll(req.Content)
+	rt.bodies = append(rt.bodies, string(body))
+	rt.md5s = append(rt.md5s, req.Header.Get(HeaderContentMD5))
+	status := http.StatusOK
+	if len(rt.bodies) == 1 {
+		status = http.StatusServiceUnavailable
+	}
+	return &Response{StatusCode: status, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(`{}`))}, nil
+}
+
+func TestRetryRewindsContent(t *testing.T) {
+	transport := &flakyBodyTransport{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(1),
+		WithRetryBackoff(time.Millisecond, time.Millisecond))
+	require.Nil(t, err)
+
+	_, err = client.RestoreObjectV2(context.Background(), &RestoreObjectV2Input{Bucket: "bucket", Key: "key", Days: 1})
+	require.Nil(t, err)
+	require.Len(t, transport.bodies, 2)
+	require.NotEmpty(t, transport.bodies[0])
+	require.Equal(t, transport.bodies[0], transport.bodies[1])
+	require.Equal(t, transport.md5s[0], transport.md5s[1])
+
+	transport.bodies, transport.md5s = nil, nil
+	_, err = client.CompleteMultipartUploadV2(context.Background(), &CompleteMultipartUploadV2Input{Bucket: "bucket",
+		Key: "key", UploadID: "upload", Parts: []UploadedPartV2{{PartNumber: 1, ETag: "etag"}}})
+	require.Nil(t, err)
+	require.Len(t, transport.bodies, 2)
+	require.Equal(t, transport.bodies[0], transport.bodies[1])
+}
+
+func TestHTTP2Enabled(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Proto", r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	for _, enabled := range []bool{true, false} {
+		config := DefaultTransportConfig()
+		config.InsecureSkipVerify = true
+		config.EnableHTTP2 = enabled
+		transport := NewDefaultTransport(&config)
+		res, err := transport.client.Get(server.URL)
+		require.Nil(t, err)
+		_ = res.Body.Close()
+		if enabled {
+			require.Equal(t, "HTTP/2.0", res.Header.Get("X-Proto"))
+		} else {
+			require.Equal(t, "HTTP/1.1", res.Header.Get("X-Proto"))
+		}
+	}
+}
+
+func TestConnectionPoolOptions(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithMaxIdleConnsPerHost(64), WithMaxConnsPerHost(256),
+		WithTCPKeepAlive(15*time.Second))
+	require.Nil(t, err)
+	transport := client.transport.(*DefaultTransport).client.Transport.(*http.Transport)
+	require.Equal(t, 64, transport.MaxIdleConnsPerHost)
+	require.Equal(t, 256, transport.MaxConnsPerHost)
+	require.Equal(t, 15*time.Second, client.config.TransportConfig.KeepAlive)
+
+	client, err = NewClientV2("tos-cn-beijing.volces.com")
+	require.Nil(t, err)
+	transport = client.transport.(*DefaultTransport).client.Transport.(*http.Transport)
+	// http.DefaultMaxIdleConnsPerHost is used by default
+	require.Equal(t, 0, transport.MaxIdleConnsPerHost)
+	require.Equal(t, 0, transport.MaxConnsPerHost)
+}
+
+func TestUnixSocketEndpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-unix")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "tos.sock")
+	listener, err := net.Listen("unix", socket)
+	require.Nil(t, err)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderRequestID, r.URL.Path)
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	client, err := NewClientV2("unix://" + socket)
+	require.Nil(t, err)
+	output, err := client.HeadBucket(context.Background(), &HeadBucketInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, "/bucket", output.RequestID)
+
+	dialed := false
+	client, err = NewClientV2("http://tos-cn-beijing.volces.com",
+		WithDialContext(func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed = true
+			return net.Dial("unix", socket)
+		}))
+	require.Nil(t, err)
+	_, err = client.HeadBucket(context.Background(), &HeadBucketInput{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.True(t, dialed)
+}
+
+func TestClientCertificateAndRootCAs(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderRequestID, strconv.Itoa(len(r.TLS.PeerCertificates)))
+		_, _ = w.Write([]byte("{}"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	client, err := NewClientV2(server.URL, WithRootCAs(pool), WithClientCertificate(server.TLS.Certificates[0]))
+	require.Nil(t, err)
+	output, err := client.ListBucketsV2(context.Background(), &ListBucketsV2Input{})
+	require.Nil(t, err)
+	require.Equal(t, "1", output.RequestID)
+
+	// server is not trusted without RootCAs
+	client, err = NewClientV2(server.URL, WithClientCertificate(server.TLS.Certificates[0]))
+	require.Nil(t, err)
+	_, err = client.ListBucketsV2(context.Background(), &ListBucketsV2Input{})
+	require.NotNil(t, err)
+
+	// TLSConfig takes precedence
+	client, err = NewClientV2(server.URL, WithRootCAs(x509.NewCertPool()),
+		WithTLSConfig(&tls.Config{RootCAs: pool, Certificates: server.TLS.Certificates}))
+	require.Nil(t, err)
+	_, err = client.ListBucketsV2(context.Background(), &ListBucketsV2Input{})
+	require.Nil(t, err)
+}
+
+type countingRoundTripper struct {
+	base  http.RoundTripper
+	count int
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.count++
+	return rt.base.RoundTrip(req)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Header.Get(authorization)) == 0 {
+			w.WriteHeader(http.StatusForbidden)
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	rt := &countingRoundTripper{base: http.DefaultTransport}
+	client, err := NewClientV2(server.URL, WithHTTPClient(&http.Client{Transport: rt}),
+		WithRegion("test-region"), WithCredentials(NewStaticCredentials("ak", "sk")))
+	require.Nil(t, err)
+	_, err = client.ListBucketsV2(context.Background(), &ListBucketsV2Input{})
+	require.Nil(t, err)
+	require.Equal(t, 1, rt.count)
+
+	client, err = NewClientV2(server.URL, WithHTTPRoundTripper(rt))
+	require.Nil(t, err)
+	_, err = client.ListBucketsV2(context.Background(), &ListBucketsV2Input{})
+	require.Equal(t, http.StatusForbidden, StatusCode(err))
+	require.Equal(t, 2, rt.count)
+
+	// nil is ignored, the default transport is kept
+	client, err = NewClientV2(server.URL, WithHTTPClient(nil))
+	require.Nil(t, err)
+	_, ok := client.transport.(*DefaultTransport)
+	require.True(t, ok)
+}
+
+func TestWithOperationTimeout(t *testing.T) {
+	client, err := NewClient("https://localhost", WithRegion("test-region"))
+	require.Nil(t, err)
+
+	var deadline time.Time
+	ok := func(ctx context.Context, req *Request) (*Response, error) {
+		deadline, _ = ctx.Deadline()
+		return &Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("data"))}, nil
+	}
+	res, err := client.newBuilder("bucket", "key", WithOperationTimeout(time.Minute)).
+		Request(context.Background(), http.MethodGet, nil, ok)
+	require.Nil(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	data, err := ioutil.ReadAll(res.Body)
+	require.Nil(t, err)
+	require.Equal(t, "data", string(data))
+	require.Nil(t, res.Close())
+
+	hang := func(ctx context.Context, req *Request) (*Response, error) {
+		<-ctx.Done()
+		return nil, newTosClientError(ctx.Err().Error(), ctx.Err())
+	}
+	start := time.Now()
+	_, err = client.newBuilder("bucket", "key", WithOperationTimeout(50*time.Millisecond)).
+		Request(context.Background(), http.MethodGet, nil, hang)
+	require.NotNil(t, err)
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestWithResponseHeaderTimeout(t *testing.T) {
+	client, err := NewClient("https://localhost", WithRegion("test-region"))
+	require.Nil(t, err)
+
+	hang := func(ctx context.Context, req *Request) (*Response, error) {
+		<-ctx.Done()
+		return nil, newTosClientError(ctx.Err().Error(), ctx.Err())
+	}
+	_, err = client.newBuilder("bucket", "key", WithResponseHeaderTimeout(50*time.Millisecond)).
+		Request(context.Background(), http.MethodGet, nil, hang)
+	require.NotNil(t, err)
+	cause, ok := err.(*TosClientError).Cause.(interface{ Timeout() bool })
+	require.True(t, ok)
+	require.True(t, cause.Timeout())
+	require.Equal(t, "tos: timeout awaiting response headers", err.Error())
+
+	var attemptCtx context.Context
+	ok2 := func(ctx context.Context, req *Request) (*Response, error) {
+		attemptCtx = ctx
+		return &Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("data"))}, nil
+	}
+	res, err := client.newBuilder("bucket", "key", WithResponseHeaderTimeout(50*time.Millisecond)).
+		Request(context.Background(), http.MethodGet, nil, ok2)
+	require.Nil(t, err)
+	time.Sleep(100 * time.Millisecond)
+	// the timeout only applies before response headers are received
+	require.Nil(t, attemptCtx.Err())
+	require.Nil(t, res.Close())
+	require.NotNil(t, attemptCtx.Err())
+}
+
+func TestV2PerCallOptions(t *testing.T) {
+	var header http.Header
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header, query = r.Header, r.URL.Query()
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client, err := NewClientV2(server.URL, WithRegion("test-region"))
+	require.Nil(t, err)
+	_, err = client.ListBucketsV2(context.Background(), &ListBucketsV2Input{},
+		WithHeader("X-Tos-New-Feature", "on"), WithQuery("new-feature", "v1"))
+	require.Nil(t, err)
+	require.Equal(t, "on", header.Get("X-Tos-New-Feature"))
+	require.Equal(t, "v1", query.Get("new-feature"))
+}
+
+func TestAttemptTimeoutAndOperationDeadline(t *testing.T) {
+	attempts := 0
+	hangOnce := func(ctx context.Context, req *Request) (*Response, error) {
+		attempts++
+		if attempts == 1 {
+			<-ctx.Done()
+			return nil, newTosClientError(ctx.Err().Error(), ctx.Err())
+		}
+		return &Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("data"))}, nil
+	}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithAttemptTimeout(20*time.Millisecond),
+		WithMaxRetryCount(1), WithRetryBackoff(time.Millisecond, time.Millisecond))
+	require.Nil(t, err)
+	// the timed out attempt is retried
+	res, err := client.newBuilder("bucket", "key").WithRetry(nil, StatusCodeClassifier{}).
+		Request(context.Background(), http.MethodGet, nil, hangOnce)
+	require.Nil(t, err)
+	require.Nil(t, res.Close())
+	require.Equal(t, 2, attempts)
+
+	hang := func(ctx context.Context, req *Request) (*Response, error) {
+		<-ctx.Done()
+		return nil, newTosClientError(ctx.Err().Error(), ctx.Err())
+	}
+	client, err = NewClientV2("tos-cn-beijing.volces.com", WithOperationDeadline(50*time.Millisecond),
+		WithMaxRetryCount(3), WithRetryBackoff(time.Hour, time.Hour))
+	require.Nil(t, err)
+	// the back-off exceeds the deadline
+	start := time.Now()
+	_, err = client.newBuilder("bucket", "key", WithResponseHeaderTimeout(10*time.Millisecond)).
+		WithRetry(nil, StatusCodeClassifier{}).Request(context.Background(), http.MethodGet, nil, hang)
+	require.NotNil(t, err)
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestSleepWithContext(t *testing.T) {
+	require.True(t, sleepWithContext(context.Background(), time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	require.False(t, sleepWithContext(ctx, time.Hour))
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+}
@@ -0,0 +1,48 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+)
+
+// RestoreObjectV2 restore an object of archive storage classes, so that it can be read by GetObjectV2 for
+// input.Days days. Restoring takes minutes to hours depending on input.Tier, poll HeadObjectV2 and check
+// RestoreInfo of output to know when it's done.
+//
+// Restoring an object being restored fails with code RestoreAlreadyInProgress, and restoring an object
+// of other storage classes fails with code NotArchiveObject, see codes package.
+func (cli *ClientV2) RestoreObjectV2(ctx context.Context, input *RestoreObjectV2Input, options ...Option) (*RestoreObjectV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	if input.Days <= 0 {
+		return nil, newTosClientError("tos: days of RestoreObject must be positive", nil)
+	}
+	restore := restoreObjectV2Input{Days: input.Days}
+	if len(input.Tier) > 0 {
+		restore.RestoreJobParameters = &restoreJobParameters{Tier: input.Tier}
+	}
+	in, contentMD5, err := marshalInput("RestoreObjectV2Input", restore)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationRestoreObject).
+		WithQuery("restore", "").
+		WithParams(*input).
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, ServerErrorClassifier{}).
+		Request(ctx, http.MethodPost, bytes.NewReader(in), func(ctx context.Context, req *Request) (*Response, error) {
+			// 202 if a restore is started, 200 if the object is restored already
+			return cli.roundTrip(ctx, req, http.StatusAccepted, http.StatusOK)
+		})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &RestoreObjectV2Output{
+		RequestInfo: res.RequestInfo(),
+		Accepted:    res.StatusCode == http.StatusAccepted,
+	}, nil
+}
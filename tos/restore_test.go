@@ -0,0 +1,58 @@
+package tos
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func TestRestoreObjectV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusAccepted, "")
+
+	output, err := client.RestoreObjectV2(context.Background(), &RestoreObjectV2Input{Bucket: "bucket", Key: "key",
+		VersionID: "v1", Days: 3, Tier: enum.TierExpedited})
+	require.Nil(t, err)
+	require.True(t, output.Accepted)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, OperationRestoreObject, req.OperationName)
+	require.Contains(t, req.Query, "restore")
+	require.Equal(t, "v1", req.Query.Get("versionId"))
+	body, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	var restore map[string]interface{}
+	require.Nil(t, json.Unmarshal(body, &restore))
+	require.Equal(t, float64(3), restore["Days"])
+	require.Equal(t, map[string]interface{}{"Tier": "Expedited"}, restore["RestoreJobParameters"])
+
+	transport.res.StatusCode = http.StatusOK
+	output, err = client.RestoreObjectV2(context.Background(), &RestoreObjectV2Input{Bucket: "bucket", Key: "key", Days: 1})
+	require.Nil(t, err)
+	require.False(t, output.Accepted)
+
+	_, err = client.RestoreObjectV2(context.Background(), &RestoreObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 2)
+}
+
+func TestHeadObjectV2RestoreInfo(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderRestore, `ongoing-request="false", expiry-date="Fri, 19 Apr 2024 00:00:00 GMT"`)
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	output, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.NotNil(t, output.RestoreInfo)
+	require.False(t, output.RestoreInfo.OngoingRequest)
+	require.Equal(t, time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC), output.RestoreInfo.ExpiryDate)
+
+	require.Equal(t, &RestoreInfo{OngoingRequest: true}, parseRestoreInfo(`ongoing-request="true"`))
+	require.Nil(t, parseRestoreInfo(""))
+}
@@ -0,0 +1,235 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+// frame types of the response of SelectObjectContent
+const (
+	selectFrameRecords  = 0x800001
+	selectFrameProgress = 0x800004
+	selectFrameEnd      = 0x800005
+)
+
+const (
+	// selectFrameHeaderSize version (1 byte), frame type (3 bytes), payload length (4 bytes) and header checksum (4 bytes)
+	selectFrameHeaderSize = 12
+	// selectMaxPayloadSize protect from allocating huge buffers for corrupted frames
+	selectMaxPayloadSize = 64 << 20
+)
+
+// only for Marshal
+type selectObjectContentInput struct {
+	Expression          string
+	ExpressionType      string
+	RequestProgress     selectRequestProgress
+	InputSerialization  SelectInputSerialization
+	OutputSerialization SelectOutputSerialization
+	Options             selectOptions
+}
+
+type selectRequestProgress struct {
+	Enabled bool
+}
+
+type selectOptions struct {
+	SkipPartialDataRecord    bool  `json:"SkipPartialDataRecord,omitempty"`
+	MaxSkippedRecordsAllowed int64 `json:"MaxSkippedRecordsAllowed,omitempty"`
+}
+
+// SelectObjectContent filter content of a CSV, JSON or Parquet object by a SQL expression on the server side,
+// so that only the result is downloaded. The result is streamed as events, see SelectObjectContentOutput.
+//
+// Errors occurring after the response header is received, e.g. a malformed row, are returned by Read or NextEvent
+// as TosServerError.
+func (cli *ClientV2) SelectObjectContent(ctx context.Context, input *SelectObjectContentInput, options ...Option) (*SelectObjectContentOutput, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	if len(input.Expression) == 0 {
+		return nil, newTosClientError("tos: empty expression of SelectObjectContent", nil)
+	}
+	in, contentMD5, err := marshalInput("SelectObjectContentInput", selectObjectContentInput{
+		Expression:          input.Expression,
+		ExpressionType:      "SQL",
+		RequestProgress:     selectRequestProgress{Enabled: input.RequestProgress},
+		InputSerialization:  input.InputSerialization,
+		OutputSerialization: input.OutputSerialization,
+		Options: selectOptions{
+			SkipPartialDataRecord:    input.SkipPartialDataRecord,
+			MaxSkippedRecordsAllowed: input.MaxSkippedRecordsAllowed,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the query reads the object only, so it's safe to retry
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationSelectObject).
+		WithQuery("select", "").
+		WithParams(*input).
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPost, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	return &SelectObjectContentOutput{
+		RequestInfo: res.RequestInfo(),
+		events: &selectEventReader{
+			body:      res.Body,
+			info:      res.RequestInfo(),
+			verifyCrc: input.OutputSerialization.EnablePayloadCrc,
+		},
+	}, nil
+}
+
+// Read read result records, progress events are skipped. It returns io.EOF once the query finishes successfully.
+func (o *SelectObjectContentOutput) Read(p []byte) (int, error) {
+	return o.events.Read(p)
+}
+
+// NextEvent return the next event, or io.EOF after SelectEventEnd
+func (o *SelectObjectContentOutput) NextEvent() (*SelectEvent, error) {
+	return o.events.next()
+}
+
+// BytesScanned return bytes of the object scanned so far, which is the total once the query finishes
+func (o *SelectObjectContentOutput) BytesScanned() int64 {
+	return o.events.scanned
+}
+
+func (o *SelectObjectContentOutput) Close() error {
+	return o.events.body.Close()
+}
+
+// selectEventReader decode frames of the response of SelectObjectContent, each frame is
+//
+//	| version (1) | frame type (3) | payload length (4) | header checksum (4) | payload | payload checksum (4) |
+//
+// in big endian, payload checksum is CRC32 (IEEE) of payload. Payload of each frame type is
+//
+//	records:  | bytes scanned (8) | records |
+//	progress: | bytes scanned (8) |
+//	end:      | bytes scanned (8) | total bytes scanned (8) | status code (4) | error message |
+type selectEventReader struct {
+	body      io.ReadCloser
+	info      RequestInfo
+	verifyCrc bool
+	scanned   int64
+	pending   []byte // records not read by Read yet
+	err       error  // sticky error, io.EOF after the end frame
+}
+
+func (r *selectEventReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		event, err := r.next()
+		if err != nil {
+			return 0, err
+		}
+		if event.Type == enum.SelectEventRecords {
+			r.pending = event.Records
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *selectEventReader) next() (*SelectEvent, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	event, err := r.readEvent()
+	if err != nil {
+		r.err = err
+		return nil, err
+	}
+	return event, nil
+}
+
+func (r *selectEventReader) readEvent() (*SelectEvent, error) {
+	for {
+		var header [selectFrameHeaderSize]byte
+		if _, err := io.ReadFull(r.body, header[:]); err != nil {
+			// the stream must end with an end frame
+			return nil, newTosClientError("tos: read select event failed", unexpectedEOF(err))
+		}
+		frameType := binary.BigEndian.Uint32(header[0:4]) & 0xffffff
+		length := binary.BigEndian.Uint32(header[4:8])
+		if length > selectMaxPayloadSize {
+			return nil, &TosServerError{
+				TosError:    TosError{fmt.Sprintf("tos: select event of %d bytes is too large", length)},
+				RequestInfo: r.info,
+			}
+		}
+		frame := make([]byte, length+4)
+		if _, err := io.ReadFull(r.body, frame); err != nil {
+			return nil, newTosClientError("tos: read select event failed", unexpectedEOF(err))
+		}
+		payload := frame[:length]
+		if r.verifyCrc {
+			expected := binary.BigEndian.Uint32(frame[length:])
+			if actual := crc32.ChecksumIEEE(payload); actual != expected {
+				return nil, &ChecksumError{
+					RequestID:        r.info.RequestID,
+					ExpectedChecksum: fmt.Sprintf("%d", expected),
+					ActualChecksum:   fmt.Sprintf("%d", actual),
+				}
+			}
+		}
+		if frameType != selectFrameRecords && frameType != selectFrameProgress && frameType != selectFrameEnd {
+			// skip frames introduced by newer servers
+			continue
+		}
+		if len(payload) < 8 {
+			return nil, &TosServerError{
+				TosError:    TosError{fmt.Sprintf("tos: invalid select event of type %#x", frameType)},
+				RequestInfo: r.info,
+			}
+		}
+		r.scanned = int64(binary.BigEndian.Uint64(payload[:8]))
+		switch frameType {
+		case selectFrameRecords:
+			return &SelectEvent{Type: enum.SelectEventRecords, Records: payload[8:], BytesScanned: r.scanned}, nil
+		case selectFrameProgress:
+			return &SelectEvent{Type: enum.SelectEventProgress, BytesScanned: r.scanned}, nil
+		}
+		return r.endEvent(payload)
+	}
+}
+
+// endEvent return SelectEventEnd, or TosServerError if the query failed, the following next returns io.EOF
+func (r *selectEventReader) endEvent(payload []byte) (*SelectEvent, error) {
+	if len(payload) < 20 {
+		return nil, &TosServerError{TosError: TosError{"tos: invalid select end event"}, RequestInfo: r.info}
+	}
+	r.scanned = int64(binary.BigEndian.Uint64(payload[8:16]))
+	statusCode := int(binary.BigEndian.Uint32(payload[16:20]))
+	if statusCode >= http.StatusBadRequest {
+		info := r.info
+		info.StatusCode = statusCode
+		return nil, &TosServerError{
+			TosError:      TosError{string(payload[20:])},
+			RequestInfo:   info,
+			OperationName: OperationSelectObject,
+		}
+	}
+	r.err = io.EOF
+	return &SelectEvent{Type: enum.SelectEventEnd, BytesScanned: r.scanned}, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
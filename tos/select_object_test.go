@@ -0,0 +1,132 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+func selectFrame(frameType uint32, payload []byte) []byte {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.BigEndian, frameType|1<<24) // version 1
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(payload)))
+	_ = binary.Write(&buf, binary.BigEndian, uint32(0))
+	buf.Write(payload)
+	_ = binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(payload))
+	return buf.Bytes()
+}
+
+func selectPayload(scanned uint64, data []byte) []byte {
+	payload := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint64(payload, scanned)
+	return append(payload, data...)
+}
+
+func selectEndFrame(scanned uint64, statusCode uint32, message string) []byte {
+	payload := selectPayload(scanned, nil)
+	payload = append(payload, make([]byte, 12)...)
+	binary.BigEndian.PutUint64(payload[8:], scanned)
+	binary.BigEndian.PutUint32(payload[16:], statusCode)
+	return selectFrame(selectFrameEnd, append(payload, message...))
+}
+
+func newSelectClient(t *testing.T, frames ...[]byte) (*ClientV2, *recordTransport) {
+	transport := &recordTransport{res: &Response{StatusCode: http.StatusOK, Header: make(http.Header),
+		Body: ioutil.NopCloser(bytes.NewReader(bytes.Join(frames, nil)))}}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+	return client, transport
+}
+
+func TestSelectObjectContent(t *testing.T) {
+	client, transport := newSelectClient(t,
+		selectFrame(selectFrameRecords, selectPayload(10, []byte("a,1\n"))),
+		selectFrame(selectFrameProgress, selectPayload(20, nil)),
+		selectFrame(0x800099, []byte("unknown")),
+		selectFrame(selectFrameRecords, selectPayload(30, []byte("b,2\n"))),
+		selectEndFrame(40, 200, ""))
+	output, err := client.SelectObjectContent(context.Background(), &SelectObjectContentInput{
+		Bucket:     "bucket",
+		Key:        "data.csv",
+		Expression: "select * from tosobject",
+		InputSerialization: SelectInputSerialization{
+			CSV: &CSVInput{FileHeaderInfo: enum.FileHeaderInfoUse},
+		},
+		OutputSerialization: SelectOutputSerialization{CSV: &CSVOutput{}, EnablePayloadCrc: true},
+		RequestProgress:     true,
+	})
+	require.Nil(t, err)
+	defer output.Close()
+	records, err := ioutil.ReadAll(output)
+	require.Nil(t, err)
+	require.Equal(t, "a,1\nb,2\n", string(records))
+	require.Equal(t, int64(40), output.BytesScanned())
+
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, OperationSelectObject, req.OperationName)
+	require.Contains(t, req.Query, "select")
+	body, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	var in map[string]interface{}
+	require.Nil(t, json.Unmarshal(body, &in))
+	require.Equal(t, "select * from tosobject", in["Expression"])
+	require.Equal(t, map[string]interface{}{"Enabled": true}, in["RequestProgress"])
+	require.Equal(t, map[string]interface{}{"CSV": map[string]interface{}{"FileHeaderInfo": "Use"}}, in["InputSerialization"])
+}
+
+func TestSelectObjectContentEvents(t *testing.T) {
+	client, _ := newSelectClient(t,
+		selectFrame(selectFrameProgress, selectPayload(20, nil)),
+		selectFrame(selectFrameRecords, selectPayload(30, []byte(`{"a":1}`))),
+		selectEndFrame(40, 400, "invalid row"))
+	output, err := client.SelectObjectContent(context.Background(), &SelectObjectContentInput{Bucket: "bucket",
+		Key: "data.json", Expression: "select * from tosobject",
+		InputSerialization: SelectInputSerialization{JSON: &JSONInput{Type: enum.SelectJSONLines}}})
+	require.Nil(t, err)
+	defer output.Close()
+
+	event, err := output.NextEvent()
+	require.Nil(t, err)
+	require.Equal(t, &SelectEvent{Type: enum.SelectEventProgress, BytesScanned: 20}, event)
+	event, err = output.NextEvent()
+	require.Nil(t, err)
+	require.Equal(t, enum.SelectEventRecords, event.Type)
+	require.Equal(t, `{"a":1}`, string(event.Records))
+	_, err = output.NextEvent()
+	require.Equal(t, http.StatusBadRequest, StatusCode(err))
+	require.Equal(t, "invalid row", err.Error())
+	_, err = output.NextEvent()
+	require.Equal(t, http.StatusBadRequest, StatusCode(err))
+}
+
+func TestSelectObjectContentCorrupted(t *testing.T) {
+	frame := selectFrame(selectFrameRecords, selectPayload(10, []byte("a,1\n")))
+	frame[len(frame)-1] ^= 0xff
+	client, _ := newSelectClient(t, frame)
+	output, err := client.SelectObjectContent(context.Background(), &SelectObjectContentInput{Bucket: "bucket",
+		Key: "data.csv", Expression: "select * from tosobject",
+		OutputSerialization: SelectOutputSerialization{EnablePayloadCrc: true}})
+	require.Nil(t, err)
+	_, err = ioutil.ReadAll(output)
+	_, ok := err.(*ChecksumError)
+	require.True(t, ok)
+
+	// truncated without end frame
+	client, _ = newSelectClient(t, selectFrame(selectFrameRecords, selectPayload(10, []byte("a,1\n"))))
+	output, err = client.SelectObjectContent(context.Background(), &SelectObjectContentInput{Bucket: "bucket",
+		Key: "data.csv", Expression: "select * from tosobject"})
+	require.Nil(t, err)
+	_, err = ioutil.ReadAll(output)
+	require.NotNil(t, err)
+	require.True(t, err != io.EOF)
+}
@@ -0,0 +1,28 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetObjectMetaV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	_, err := client.SetObjectMetaV2(context.Background(), &SetObjectMetaV2Input{Bucket: "bucket", Key: "key",
+		VersionID: "v1", ContentType: "text/plain", CacheControl: "no-cache",
+		ContentDisposition: "attachment; filename=\"文件.txt\"", Meta: map[string]string{"k": "v"}})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, OperationSetObjectMeta, req.OperationName)
+	require.Contains(t, req.Query, "metadata")
+	require.Equal(t, "v1", req.Query.Get("versionId"))
+	require.Equal(t, "text/plain", req.Header.Get(HeaderContentType))
+	require.Equal(t, "no-cache", req.Header.Get(HeaderCacheControl))
+	require.Equal(t, "attachment; filename=\"%E6%96%87%E4%BB%B6.txt\"", req.Header.Get(HeaderContentDisposition))
+	require.Equal(t, "v", req.Header.Get(HeaderMetaPrefix+"k"))
+	require.Nil(t, req.Content)
+}
@@ -0,0 +1,215 @@
+package tos
+
+import (
+	"container/heap"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	DefaultKeyShardWidth     = 2 // 256 shards
+	DefaultKeyShardSeparator = "/"
+	maxKeyShardWidth         = 4
+)
+
+// KeySharder adds a deterministic hash-based prefix to keys, so that objects written under one logical prefix
+// are spread over many prefixes, which avoids hot prefixes when writing and reading at large scale.
+//
+// e.g. with Width 2, key "logs/2022/a.txt" becomes "3f/logs/2022/a.txt".
+// List a logical prefix by NewShardedObjectsIterator, and delete it by DeletePrefix with KeySharder set.
+type KeySharder struct {
+	Width     int    // number of hex chars of shard, in [1, 4]
+	Separator string // between shard and key
+}
+
+// NewKeySharder create KeySharder with width hex chars of shard, width is in [1, 4]
+func NewKeySharder(width int) (*KeySharder, error) {
+	if width < 1 || width > maxKeyShardWidth {
+		return nil, newTosClientError(fmt.Sprintf("tos: invalid key shard width %d, must be in [1, %d]", width, maxKeyShardWidth), nil)
+	}
+	return &KeySharder{Width: width, Separator: DefaultKeyShardSeparator}, nil
+}
+
+// Shard return shard of key, it only depends on key and Width
+func (s *KeySharder) Shard(key string) string {
+	sum := md5.Sum([]byte(key))
+	return hex.EncodeToString(sum[:])[:s.Width]
+}
+
+// ShardedKey return key with its shard as prefix
+func (s *KeySharder) ShardedKey(key string) string {
+	return s.Shard(key) + s.Separator + key
+}
+
+// ParseKey split a key generated by ShardedKey into shard and original key,
+// ok is false if shardedKey is not generated by s
+func (s *KeySharder) ParseKey(shardedKey string) (shard, key string, ok bool) {
+	if len(shardedKey) < s.Width+len(s.Separator) || !strings.HasPrefix(shardedKey[s.Width:], s.Separator) {
+		return "", "", false
+	}
+	shard, key = shardedKey[:s.Width], shardedKey[s.Width+len(s.Separator):]
+	if s.Shard(key) != shard {
+		return "", "", false
+	}
+	return shard, key, true
+}
+
+// Shards return all shards in order
+func (s *KeySharder) Shards() []string {
+	count := 1 << (4 * uint(s.Width))
+	shards := make([]string, 0, count)
+	format := fmt.Sprintf("%%0%dx", s.Width)
+	for i := 0; i < count; i++ {
+		shards = append(shards, fmt.Sprintf(format, i))
+	}
+	return shards
+}
+
+// ShardPrefixes return the real prefixes covering all sharded keys with the logical prefix
+func (s *KeySharder) ShardPrefixes(prefix string) []string {
+	shards := s.Shards()
+	for i, shard := range shards {
+		shards[i] = shard + s.Separator + prefix
+	}
+	return shards
+}
+
+type ListShardedObjectsInput struct {
+	Bucket     string
+	Prefix     string      // logical prefix, objects under all prefixes returned by KeySharder.ShardPrefixes are listed
+	KeySharder *KeySharder // required
+	// TaskNum number of shard prefixes listed concurrently, the default is 1
+	TaskNum int
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string
+}
+
+// ShardedObject an object yielded by ShardedObjectsIterator
+type ShardedObject struct {
+	ListedObject        // Key is the sharded key in the bucket
+	LogicalKey   string // Key without its shard
+}
+
+// ShardedObjectsIterator iterates objects under all shard prefixes of a logical prefix, listed by ListObjectsV2
+// page by page, and merged in order of LogicalKey. A page of each shard prefix is kept in memory.
+// It's not safe for concurrent use.
+type ShardedObjectsIterator struct {
+	input   ListShardedObjectsInput
+	listers []*objectLister
+	pending []int // listers whose next object is not in heads yet
+	heads   shardedObjectHeap
+}
+
+// NewShardedObjectsIterator create an iterator listing objects of a logical prefix written by KeySharder.ShardedKey
+func (cli *ClientV2) NewShardedObjectsIterator(input *ListShardedObjectsInput, options ...Option) *ShardedObjectsIterator {
+	it := &ShardedObjectsIterator{input: *input}
+	if input.KeySharder == nil {
+		return it
+	}
+	for i, prefix := range input.KeySharder.ShardPrefixes(input.Prefix) {
+		it.listers = append(it.listers, &objectLister{cli: cli, bucket: input.Bucket, prefix: prefix,
+			requestPayer: input.RequestPayer, options: options})
+		it.pending = append(it.pending, i)
+	}
+	return it
+}
+
+// Next return the next object, or nil if there's no more. Errors of listing are returned as is, calling Next again
+// retries the failed pages.
+func (it *ShardedObjectsIterator) Next(ctx context.Context) (*ShardedObject, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if it.input.KeySharder == nil {
+		return nil, newTosClientError("tos: nil KeySharder of ListShardedObjectsInput", nil)
+	}
+	if err := IsValidBucketName(it.input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := it.fill(ctx); err != nil {
+		return nil, err
+	}
+	if len(it.heads) == 0 {
+		return nil, nil
+	}
+	head := heap.Pop(&it.heads).(shardedObjectHead)
+	// the next object of the lister is listed by the following call, so that its error doesn't lose this one
+	it.pending = append(it.pending, head.lister)
+	return &head.object, nil
+}
+
+// fill list the next objects of pending listers by TaskNum goroutines
+func (it *ShardedObjectsIterator) fill(ctx context.Context) error {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		failed   []int
+		pending  = make(chan int, len(it.pending))
+	)
+	for _, i := range it.pending {
+		pending <- i
+	}
+	close(pending)
+	taskNum := it.input.TaskNum
+	if taskNum < 1 {
+		taskNum = 1
+	}
+	// keys listed start with the shard and separator
+	shardLen := it.input.KeySharder.Width + len(it.input.KeySharder.Separator)
+	for i := 0; i < min(taskNum, len(it.pending)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range pending {
+				object, err := it.listers[i].next(ctx)
+				mu.Lock()
+				switch {
+				case err != nil:
+					if firstErr == nil {
+						firstErr = err
+					}
+					failed = append(failed, i)
+				case object != nil:
+					heap.Push(&it.heads, shardedObjectHead{
+						object: ShardedObject{ListedObject: *object, LogicalKey: object.Key[shardLen:]},
+						lister: i,
+					})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	it.pending = failed
+	return firstErr
+}
+
+type shardedObjectHead struct {
+	object ShardedObject
+	lister int
+}
+
+// shardedObjectHeap implements heap.Interface, ordered by LogicalKey
+type shardedObjectHeap []shardedObjectHead
+
+func (h shardedObjectHeap) Len() int { return len(h) }
+
+func (h shardedObjectHeap) Less(i, j int) bool {
+	return h[i].object.LogicalKey < h[j].object.LogicalKey
+}
+
+func (h shardedObjectHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *shardedObjectHeap) Push(x interface{}) { *h = append(*h, x.(shardedObjectHead)) }
+
+func (h *shardedObjectHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
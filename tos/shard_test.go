@@ -0,0 +1,150 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySharder(t *testing.T) {
+	_, err := NewKeySharder(0)
+	require.NotNil(t, err)
+	_, err = NewKeySharder(5)
+	require.NotNil(t, err)
+
+	sharder, err := NewKeySharder(DefaultKeyShardWidth)
+	require.Nil(t, err)
+	key := "logs/2022/a.txt"
+	sharded := sharder.ShardedKey(key)
+	require.Equal(t, sharded, sharder.ShardedKey(key))
+	require.True(t, strings.HasSuffix(sharded, "/"+key))
+
+	shard, parsed, ok := sharder.ParseKey(sharded)
+	require.True(t, ok)
+	require.Equal(t, key, parsed)
+	require.Equal(t, sharder.Shard(key), shard)
+	_, _, ok = sharder.ParseKey("zz/" + key)
+	require.False(t, ok)
+	_, _, ok = sharder.ParseKey("a")
+	require.False(t, ok)
+
+	prefixes := sharder.ShardPrefixes("logs/")
+	require.Len(t, prefixes, 256)
+	require.Equal(t, "00/logs/", prefixes[0])
+	require.Equal(t, "ff/logs/", prefixes[255])
+	covered := false
+	for _, prefix := range prefixes {
+		covered = covered || strings.HasPrefix(sharded, prefix)
+	}
+	require.True(t, covered)
+}
+
+// shardTransport serve ListObjectsV2 of keys 2 objects per page, and DeleteMultiObjects removing keys,
+// listing failPrefix fails with 500 once
+type shardTransport struct {
+	mu         sync.Mutex
+	keys       []string // sorted
+	failPrefix string
+	lists      int
+}
+
+func (rt *shardTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	respond := func(code int, v interface{}) (*Response, error) {
+		body, _ := json.Marshal(v)
+		return &Response{StatusCode: code, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewReader(body))}, nil
+	}
+	if req.Method == http.MethodPost {
+		var in deleteMultiObjectsInput
+		if err := json.NewDecoder(req.Content).Decode(&in); err != nil {
+			return nil, err
+		}
+		for _, object := range in.Objects {
+			i := sort.SearchStrings(rt.keys, object.Key)
+			rt.keys = append(rt.keys[:i], rt.keys[i+1:]...)
+		}
+		return respond(http.StatusOK, &DeleteMultiObjectsOutput{})
+	}
+	rt.lists++
+	prefix, marker := req.Query.Get("prefix"), req.Query.Get("marker")
+	if len(prefix) > 0 && prefix == rt.failPrefix {
+		rt.failPrefix = ""
+		return respond(http.StatusInternalServerError, map[string]string{"Code": "InternalError"})
+	}
+	var out ListObjectsOutput
+	for _, key := range rt.keys {
+		if strings.HasPrefix(key, prefix) && key > marker {
+			if len(out.Contents) == 2 {
+				out.IsTruncated = true
+				break
+			}
+			out.Contents = append(out.Contents, ListedObject{Key: key})
+		}
+	}
+	return respond(http.StatusOK, &out)
+}
+
+func newShardTransport(sharder *KeySharder, keys ...string) *shardTransport {
+	rt := &shardTransport{}
+	for _, key := range keys {
+		rt.keys = append(rt.keys, sharder.ShardedKey(key))
+	}
+	sort.Strings(rt.keys)
+	return rt
+}
+
+func TestShardedObjectsIterator(t *testing.T) {
+	sharder, err := NewKeySharder(1)
+	require.Nil(t, err)
+	keys := []string{"logs/a", "logs/b", "logs/c", "logs/d", "logs/e", "logs/f", "other/a"}
+	transport := newShardTransport(sharder, keys...)
+	transport.failPrefix = sharder.Shard("logs/c") + "/logs/"
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport), WithMaxRetryCount(0))
+	require.Nil(t, err)
+
+	it := client.NewShardedObjectsIterator(&ListShardedObjectsInput{Bucket: "bucket", Prefix: "logs/",
+		KeySharder: sharder, TaskNum: 4})
+	// the failed shard prefix is listed again by the next call
+	_, err = it.Next(context.Background())
+	require.Equal(t, http.StatusInternalServerError, StatusCode(err))
+	var listed []string
+	for {
+		object, err := it.Next(context.Background())
+		require.Nil(t, err)
+		if object == nil {
+			break
+		}
+		require.Equal(t, sharder.ShardedKey(object.LogicalKey), object.Key)
+		listed = append(listed, object.LogicalKey)
+	}
+	// merged in order of logical keys across shards
+	require.Equal(t, keys[:6], listed)
+
+	_, err = client.NewShardedObjectsIterator(&ListShardedObjectsInput{Bucket: "bucket"}).Next(context.Background())
+	require.NotNil(t, err)
+}
+
+func TestDeletePrefixKeySharder(t *testing.T) {
+	sharder, err := NewKeySharder(1)
+	require.Nil(t, err)
+	transport := newShardTransport(sharder, "logs/a", "logs/b", "logs/c", "logs/d", "logs/e", "other/a")
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+
+	output, err := client.DeletePrefix(context.Background(), &DeletePrefixInput{Bucket: "bucket", Prefix: "logs/",
+		KeySharder: sharder})
+	require.Nil(t, err)
+	require.Equal(t, int64(5), output.Deleted)
+	require.Equal(t, []string{sharder.ShardedKey("other/a")}, transport.keys)
+	// every shard prefix is listed
+	require.True(t, transport.lists >= 16)
+}
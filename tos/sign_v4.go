@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -59,13 +60,26 @@ type SigningKeyInfo struct {
 	Credential *Credential
 }
 
+// QueryCanonicalization decides how query string is canonicalized when signing
+type QueryCanonicalization int
+
+const (
+	// QueryCanonicalizationDefault sort query by key, values of the same key keep their order
+	QueryCanonicalizationDefault QueryCanonicalization = iota
+
+	// QueryCanonicalizationS3Compat sort query by encoded key and then by encoded value,
+	// it's required by S3-compatible gateways such as MinIO and Ceph
+	QueryCanonicalizationS3Compat
+)
+
 type SignV4 struct {
-	credentials   Credentials
-	region        string
-	signingHeader func(key string, isSigningQuery bool) bool
-	signingQuery  func(key string) bool
-	now           func() time.Time
-	signingKey    func(*SigningKeyInfo) []byte
+	credentials           Credentials
+	region                string
+	signingHeader         func(key string, isSigningQuery bool) bool
+	signingQuery          func(key string) bool
+	now                   func() time.Time
+	signingKey            func(*SigningKeyInfo) []byte
+	queryCanonicalization QueryCanonicalization
 }
 
 // NewSignV4 create SignV4
@@ -86,6 +100,11 @@ func (sv *SignV4) WithSigningKey(signingKey func(*SigningKeyInfo) []byte) {
 	sv.signingKey = signingKey
 }
 
+// WithQueryCanonicalization set how query string is canonicalized, the default is QueryCanonicalizationDefault
+func (sv *SignV4) WithQueryCanonicalization(mode QueryCanonicalization) {
+	sv.queryCanonicalization = mode
+}
+
 func (sv *SignV4) signedHeader(header http.Header, isSignedQuery bool) KVs {
 	var signed = make(KVs, 0, 10)
 	for key, values := range header {
@@ -130,7 +149,11 @@ func (sv *SignV4) canonicalRequest(method, path, contentSha256 string, header, q
 	buf.WriteByte(split)
 
 	// query
-	buf.Write(encodeQuery(query))
+	if sv.queryCanonicalization == QueryCanonicalizationS3Compat {
+		buf.Write(encodeQueryS3Compat(query))
+	} else {
+		buf.Write(encodeQuery(query))
+	}
 	buf.WriteByte(split)
 
 	// canonical headers
@@ -156,6 +179,36 @@ func (sv *SignV4) canonicalRequest(method, path, contentSha256 string, header, q
 	return buf.Bytes()
 }
 
+// fixedCredentials return the same Credential
+type fixedCredentials struct {
+	cred Credential
+}
+
+func (fc fixedCredentials) Credential() Credential {
+	return fc.cred
+}
+
+// snapshot return a copy of sv with the time and Credential fixed, and signing keys cached,
+// so that signing many requests with it only computes them once. It's safe for concurrent use.
+func (sv *SignV4) snapshot() *SignV4 {
+	now := sv.now()
+	snapshot := *sv
+	snapshot.now = func() time.Time { return now }
+	snapshot.credentials = fixedCredentials{cred: sv.credentials.Credential()}
+	var keys sync.Map // date/region -> []byte
+	signingKey := sv.signingKey
+	snapshot.signingKey = func(info *SigningKeyInfo) []byte {
+		cacheKey := info.Date + "/" + info.Region
+		if key, ok := keys.Load(cacheKey); ok {
+			return key.([]byte)
+		}
+		key := signingKey(info)
+		keys.Store(cacheKey, key)
+		return key
+	}
+	return &snapshot
+}
+
 func SigningKey(info *SigningKeyInfo) []byte {
 	date := hmacSHA256([]byte(info.Credential.AccessKeySecret), []byte(info.Date))
 	region := hmacSHA256(date, []byte(info.Region))
@@ -335,6 +388,35 @@ func encodeQuery(query KVs) []byte {
 	return buf.Bytes()
 }
 
+// encodeQueryS3Compat sort query pairs by encoded key and encoded value, as AWS Signature Version 4 does
+func encodeQueryS3Compat(query KVs) []byte {
+	pairs := make([]KV, 0, len(query))
+	for _, kv := range query {
+		key := string(URIEncode(kv.Key, true))
+		for _, v := range kv.Values {
+			pairs = append(pairs, KV{Key: key, Values: []string{string(URIEncode(v, true))}})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Key != pairs[j].Key {
+			return pairs[i].Key < pairs[j].Key
+		}
+		return pairs[i].Values[0] < pairs[j].Values[0]
+	})
+
+	var buf bytes.Buffer
+	buf.Grow(512)
+	for i, kv := range pairs {
+		if i > 0 {
+			buf.WriteByte('&')
+		}
+		buf.WriteString(kv.Key)
+		buf.WriteByte('=')
+		buf.WriteString(kv.Values[0])
+	}
+	return buf.Bytes()
+}
+
 func URIEncode(in string, encodeSlash bool) []byte {
 	hexCount := 0
 	for i := 0; i < len(in); i++ {
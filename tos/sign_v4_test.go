@@ -3,10 +3,12 @@ package tos
 import (
 	"net/http"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
 )
 
 func TestURIEncode(t *testing.T) {
@@ -68,3 +70,57 @@ func TestAlgV4(t *testing.T) {
 	require.Equal(t, "20210721T104454Z", header.Get("Date"))
 	require.Equal(t, "", header.Get(v4ContentSHA256))
 }
+
+func TestEncodeQuery(t *testing.T) {
+	query := KVs{
+		{Key: "b", Values: []string{"2", "1"}},
+		{Key: "a+b", Values: []string{"x y"}},
+		{Key: "B", Values: []string{""}},
+	}
+	require.Equal(t, "B=&a%2Bb=x%20y&b=2&b=1", string(encodeQuery(query)))
+	require.Equal(t, "B=&a%2Bb=x%20y&b=1&b=2", string(encodeQueryS3Compat(query)))
+	require.Equal(t, "", string(encodeQueryS3Compat(nil)))
+}
+
+func TestWithQueryCanonicalization(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithRegion("cn-beijing"),
+		WithCredentials(NewStaticCredentials("ak", "sk")), WithQueryCanonicalization(QueryCanonicalizationS3Compat))
+	require.Nil(t, err)
+	require.Equal(t, QueryCanonicalizationS3Compat, client.signer.(*SignV4).queryCanonicalization)
+}
+
+type countingCredentials struct {
+	calls int64
+}
+
+func (c *countingCredentials) Credential() Credential {
+	atomic.AddInt64(&c.calls, 1)
+	return Credential{AccessKeyID: "ak", AccessKeySecret: "sk"}
+}
+
+func TestPreSignedURLs(t *testing.T) {
+	cred := &countingCredentials{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithRegion("cn-beijing"), WithCredentials(cred))
+	require.Nil(t, err)
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	client.signer.(*SignV4).now = func() time.Time { return now }
+
+	inputs := []PreSignedURLInput{
+		{HTTPMethod: enum.HttpMethodGet, Bucket: "bucket", Key: "a"},
+		{HTTPMethod: enum.HttpMethodPut, Bucket: "bucket", Key: "b", Expires: 60, Header: map[string]string{"Content-Type": "text/plain"}},
+	}
+	outputs, err := client.PreSignedURLs(inputs)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), cred.calls)
+	require.Len(t, outputs, 2)
+	for i := range inputs {
+		single, err := client.PreSignedURL(&inputs[i])
+		require.Nil(t, err)
+		require.Equal(t, single, outputs[i])
+	}
+	require.Contains(t, outputs[1].SignedUrl, "X-Tos-Expires=60")
+
+	_, err = client.PreSignedURLs([]PreSignedURLInput{inputs[0], {HTTPMethod: enum.HttpMethodGet, Bucket: "B"}})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "input 1")
+}
@@ -0,0 +1,202 @@
+package tos
+
+import (
+	"math/bits"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// OperationStats statistics of an operation, see Client.Stats
+type OperationStats struct {
+	// Requests number of completed operations, each including all its retries
+	Requests int64
+	// InFlight number of operations being sent
+	InFlight int64
+	// NetworkErrors number of operations failed without a response, e.g. network errors and timeouts
+	NetworkErrors int64
+	// ClientErrors number of operations failed with 4xx status codes except 429
+	ClientErrors int64
+	// Throttled number of operations failed with status code 429
+	Throttled int64
+	// ServerErrors number of operations failed with 5xx status codes
+	ServerErrors int64
+	// Retries number of retries
+	Retries int64
+	// BytesSent length of request bodies of uploads
+	BytesSent int64
+	// BytesReceived Content-Length of responses
+	BytesReceived int64
+	// Latency distribution of time from sending the first request to receiving the last response header
+	Latency LatencyStats
+}
+
+// LatencyStats percentiles of latency, accurate to about 6%
+type LatencyStats struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// Stats return a snapshot of statistics of each operation sent by the client since it's created, keyed by
+// operation name, see Operation* constants. It's for applications polling their own telemetry,
+// see WithMetricsCollector and WithHooks to push metrics instead.
+func (cli *Client) Stats() map[string]OperationStats {
+	return cli.stats.snapshot()
+}
+
+// clientStats statistics of all operations of a client
+type clientStats struct {
+	operations sync.Map // operation name -> *operationStats
+}
+
+func (s *clientStats) operation(name string) *operationStats {
+	if op, ok := s.operations.Load(name); ok {
+		return op.(*operationStats)
+	}
+	op, _ := s.operations.LoadOrStore(name, &operationStats{})
+	return op.(*operationStats)
+}
+
+func (s *clientStats) snapshot() map[string]OperationStats {
+	snapshot := make(map[string]OperationStats)
+	if s == nil {
+		return snapshot
+	}
+	s.operations.Range(func(name, op interface{}) bool {
+		snapshot[name.(string)] = op.(*operationStats).snapshot()
+		return true
+	})
+	return snapshot
+}
+
+type operationStats struct {
+	mu      sync.Mutex
+	stats   OperationStats
+	latency latencyHistogram
+}
+
+func (s *operationStats) start() {
+	s.mu.Lock()
+	s.stats.InFlight++
+	s.mu.Unlock()
+}
+
+// done record an operation started at start completed with statusCode, 0 if no response is received
+func (s *operationStats) done(start time.Time, statusCode int, sent, received int64, retries int) {
+	latency := time.Since(start)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.InFlight--
+	s.stats.Requests++
+	switch {
+	case statusCode == 0:
+		s.stats.NetworkErrors++
+	case statusCode == http.StatusTooManyRequests:
+		s.stats.Throttled++
+	case statusCode >= 500:
+		s.stats.ServerErrors++
+	case statusCode >= 400:
+		s.stats.ClientErrors++
+	}
+	s.stats.Retries += int64(retries)
+	s.stats.BytesSent += sent
+	s.stats.BytesReceived += received
+	s.latency.record(latency)
+}
+
+func (s *operationStats) snapshot() OperationStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	stats.Latency = LatencyStats{
+		P50: s.latency.percentile(0.5),
+		P90: s.latency.percentile(0.9),
+		P99: s.latency.percentile(0.99),
+		Max: s.latency.max,
+	}
+	return stats
+}
+
+// recordStats record the result of rb started at start
+func (rb *requestBuilder) recordStats(stats *operationStats, start time.Time, res *Response, err error) {
+	var (
+		statusCode int
+		sent       int64
+		received   int64
+	)
+	if rb.ContentLength != nil && *rb.ContentLength > 0 {
+		sent = *rb.ContentLength
+	}
+	if err != nil {
+		statusCode = StatusCode(err)
+	} else {
+		statusCode = res.StatusCode
+		if res.ContentLength > 0 {
+			received = res.ContentLength
+		}
+	}
+	stats.done(start, statusCode, sent, received, retryCount(rb.attempts))
+}
+
+const latencySubBuckets = 16
+
+// latencyHistogram records latencies in microseconds into buckets of powers of 2, each is split into
+// latencySubBuckets linear sub-buckets, as HdrHistogram does, so that percentiles are accurate to 1/latencySubBuckets
+type latencyHistogram struct {
+	counts [64 * latencySubBuckets]int64
+	total  int64
+	max    time.Duration
+}
+
+func (h *latencyHistogram) record(latency time.Duration) {
+	us := uint64(latency / time.Microsecond)
+	h.counts[latencyBucket(us)]++
+	h.total++
+	if latency > h.max {
+		h.max = latency
+	}
+}
+
+// percentile return the upper bound of the bucket holding the p percentile, capped by max
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	rank := int64(p*float64(h.total) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	var count int64
+	for i, c := range h.counts {
+		count += c
+		if count >= rank {
+			if upper := time.Duration(latencyUpperBound(i)) * time.Microsecond; upper < h.max {
+				return upper
+			}
+			return h.max
+		}
+	}
+	return h.max
+}
+
+// latencyBucket return index of the bucket of us: values less than latencySubBuckets have their own buckets,
+// others are indexed by the position of the highest bit and the next 4 bits
+func latencyBucket(us uint64) int {
+	if us < latencySubBuckets {
+		return int(us)
+	}
+	exponent := bits.Len64(us) - 4 // >= 1
+	return exponent*latencySubBuckets + int(us>>uint(exponent-1)) - latencySubBuckets
+}
+
+// latencyUpperBound return the largest value in bucket i
+func latencyUpperBound(i int) uint64 {
+	if i < latencySubBuckets {
+		return uint64(i)
+	}
+	exponent := i / latencySubBuckets
+	sub := uint64(i%latencySubBuckets + latencySubBuckets)
+	return (sub+1)<<uint(exponent-1) - 1
+}
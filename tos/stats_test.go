@@ -0,0 +1,58 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&flakyTransport{failures: 1}),
+		WithMaxRetryCount(1), WithRetryBackoff(time.Millisecond, time.Millisecond))
+	require.Nil(t, err)
+	ctx := context.Background()
+	require.Empty(t, client.Stats())
+
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key"},
+		Content: strings.NewReader("hello")})
+	require.Nil(t, err)
+	client.retry = nil
+	client.transport = &recordTransport{res: newRecordResponse(http.StatusTooManyRequests, "")}
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.NotNil(t, err)
+	client.transport = &recordTransport{err: newTosClientError("tos: connection refused", nil)}
+	_, err = client.HeadObjectV2(ctx, &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.NotNil(t, err)
+
+	stats := client.Stats()
+	put := stats[OperationPutObject]
+	require.Equal(t, int64(1), put.Requests)
+	require.Equal(t, int64(1), put.Retries)
+	require.Equal(t, int64(5), put.BytesSent)
+	require.Equal(t, int64(0), put.InFlight)
+	require.Equal(t, int64(0), put.ServerErrors)
+	require.True(t, put.Latency.Max > 0 && put.Latency.P50 <= put.Latency.Max)
+	head := stats[OperationHeadObject]
+	require.Equal(t, int64(2), head.Requests)
+	require.Equal(t, int64(1), head.Throttled)
+	require.Equal(t, int64(1), head.NetworkErrors)
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	for _, us := range []uint64{0, 15, 16, 31, 32, 33, 1000, 123456789, 1 << 62} {
+		i := latencyBucket(us)
+		require.True(t, us <= latencyUpperBound(i), us)
+		require.True(t, i == 0 || us > latencyUpperBound(i-1), us)
+	}
+	var h latencyHistogram
+	for i := 1; i <= 100; i++ {
+		h.record(time.Duration(i) * time.Millisecond)
+	}
+	require.InEpsilon(t, float64(50*time.Millisecond), float64(h.percentile(0.5)), 0.07)
+	require.InEpsilon(t, float64(99*time.Millisecond), float64(h.percentile(0.99)), 0.07)
+	require.Equal(t, 100*time.Millisecond, h.percentile(1))
+}
@@ -0,0 +1,76 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PutSymlinkV2 create a symlink Key pointing to SymlinkTargetKey, GetObjectV2 and HeadObjectV2 of the symlink
+// return the target object.
+func (cli *ClientV2) PutSymlinkV2(ctx context.Context, input *PutSymlinkV2Input, options ...Option) (*PutSymlinkV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key, input.SymlinkTargetKey); err != nil {
+		return nil, err
+	}
+	if len(input.SymlinkTargetBucket) > 0 {
+		if err := IsValidBucketName(input.SymlinkTargetBucket); err != nil {
+			return nil, err
+		}
+	}
+	// a retry of a put which succeeded fails if overwriting is forbidden
+	var classifier Classifier = StatusCodeClassifier{}
+	if input.ForbidOverwrite {
+		classifier = NoRetryClassifier{}
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationPutSymlink).
+		WithQuery("symlink", "").
+		WithHeader(HeaderSymlinkTarget, string(URIEncode(input.SymlinkTargetKey, true))).
+		WithParams(*input).
+		WithRetry(nil, classifier).
+		Request(ctx, http.MethodPut, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutSymlinkV2Output{
+		RequestInfo: res.RequestInfo(),
+		VersionID:   res.Header.Get(HeaderVersionID),
+	}, nil
+}
+
+// GetSymlinkV2 get the target of a symlink created by PutSymlinkV2
+func (cli *ClientV2) GetSymlinkV2(ctx context.Context, input *GetSymlinkV2Input, options ...Option) (*GetSymlinkV2Output, error) {
+	if err := isValidNames(input.Bucket, input.Key); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, input.Key, options...).
+		WithOperation(OperationGetSymlink).
+		WithQuery("symlink", "").
+		WithParams(*input).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	lastModified, _ := time.ParseInLocation(http.TimeFormat, res.Header.Get(HeaderLastModified), time.UTC)
+	return &GetSymlinkV2Output{
+		RequestInfo:         res.RequestInfo(),
+		VersionID:           res.Header.Get(HeaderVersionID),
+		SymlinkTargetKey:    symlinkTargetKey(res.Header),
+		SymlinkTargetBucket: res.Header.Get(HeaderSymlinkBucket),
+		ETag:                res.Header.Get(HeaderETag),
+		LastModified:        lastModified,
+	}, nil
+}
+
+// symlinkTargetKey return the target key of symlink in header, it's encoded by PutSymlinkV2
+func symlinkTargetKey(header http.Header) string {
+	target := header.Get(HeaderSymlinkTarget)
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		return unescaped
+	}
+	return target
+}
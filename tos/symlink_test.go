@@ -0,0 +1,60 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutSymlinkV2(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderVersionID, "v1")
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	output, err := client.PutSymlinkV2(context.Background(), &PutSymlinkV2Input{Bucket: "bucket", Key: "link",
+		SymlinkTargetKey: "dir/target 1", SymlinkTargetBucket: "other-bucket", ForbidOverwrite: true,
+		Meta: map[string]string{"k": "v"}})
+	require.Nil(t, err)
+	require.Equal(t, "v1", output.VersionID)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Equal(t, OperationPutSymlink, req.OperationName)
+	require.Contains(t, req.Query, "symlink")
+	require.Equal(t, "dir%2Ftarget%201", req.Header.Get(HeaderSymlinkTarget))
+	require.Equal(t, "other-bucket", req.Header.Get(HeaderSymlinkBucket))
+	require.Equal(t, "true", req.Header.Get(HeaderForbidOverwrite))
+	require.Equal(t, "v", req.Header.Get(HeaderMetaPrefix+"k"))
+
+	_, err = client.PutSymlinkV2(context.Background(), &PutSymlinkV2Input{Bucket: "bucket", Key: "link"})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 1)
+}
+
+func TestGetSymlinkV2(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderSymlinkTarget, "dir%2Ftarget%201")
+	header.Set(HeaderSymlinkBucket, "other-bucket")
+	header.Set(HeaderLastModified, "Fri, 19 Apr 2024 00:00:00 GMT")
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	output, err := client.GetSymlinkV2(context.Background(), &GetSymlinkV2Input{Bucket: "bucket", Key: "link"})
+	require.Nil(t, err)
+	require.Equal(t, "dir/target 1", output.SymlinkTargetKey)
+	require.Equal(t, "other-bucket", output.SymlinkTargetBucket)
+	require.Equal(t, 2024, output.LastModified.Year())
+	require.Equal(t, http.MethodGet, transport.requests[0].Method)
+	require.Contains(t, transport.requests[0].Query, "symlink")
+
+	header.Set(HeaderObjectType, "Symlink")
+	header.Set(HeaderSymlinkTargetSize, "1024")
+	head, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "link"})
+	require.Nil(t, err)
+	require.Equal(t, "Symlink", head.ObjectType)
+	require.Equal(t, "dir/target 1", head.SymlinkTargetKey)
+	require.Equal(t, "other-bucket", head.SymlinkTargetBucket)
+	require.Equal(t, int64(1024), head.SymlinkTargetSize)
+}
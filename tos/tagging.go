@@ -0,0 +1,120 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+)
+
+// encodeTagSet encode tags as X-Tos-Tagging, e.g. "k1=v1&k2=v2", keys and values are URI encoded
+func encodeTagSet(tagSet *TagSet) string {
+	var sb strings.Builder
+	for i, tag := range tagSet.Tags {
+		if i > 0 {
+			sb.WriteByte('&')
+		}
+		sb.Write(URIEncode(tag.Key, true))
+		sb.WriteByte('=')
+		sb.Write(URIEncode(tag.Value, true))
+	}
+	return sb.String()
+}
+
+// withTagSet set X-Tos-Tagging of tagSet, tagging is the raw Tagging of input, which is set by WithParams already
+func (rb *requestBuilder) withTagSet(tagging string, tagSet *TagSet) error {
+	if tagSet == nil {
+		return nil
+	}
+	if len(tagging) > 0 {
+		return newTosClientError("tos: only one of Tagging and TagSet can be set", nil)
+	}
+	if err := validateTags(tagSet.Tags); err != nil {
+		return err
+	}
+	rb.WithHeader(HeaderTagging, encodeTagSet(tagSet))
+	return nil
+}
+
+// validateTags validate keys of tags are not empty and unique
+func validateTags(tags []Tag) error {
+	keys := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if len(tag.Key) == 0 {
+			return newTosClientError("tos: empty key of TagSet", nil)
+		}
+		if _, ok := keys[tag.Key]; ok {
+			return newTosClientError("tos: duplicate key "+tag.Key+" of TagSet", nil)
+		}
+		keys[tag.Key] = struct{}{}
+	}
+	return nil
+}
+
+// PutBucketTaggingV2 replace tags of a bucket, e.g. for cost allocation
+func (cli *ClientV2) PutBucketTaggingV2(ctx context.Context, input *PutBucketTaggingV2Input, options ...Option) (*PutBucketTaggingV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if len(input.TagSet.Tags) == 0 {
+		return nil, newTosClientError("tos: empty TagSet, use DeleteBucketTaggingV2 to remove tags", nil)
+	}
+	if err := validateTags(input.TagSet.Tags); err != nil {
+		return nil, err
+	}
+	in, contentMD5, err := marshalInput("PutBucketTaggingV2Input", struct {
+		TagSet TagSet `json:"TagSet"`
+	}{input.TagSet})
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketTagging).
+		WithQuery("tagging", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketTaggingV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketTaggingV2 get tags of a bucket, it fails with code NoSuchTagSet if there's none
+func (cli *ClientV2) GetBucketTaggingV2(ctx context.Context, input *GetBucketTaggingV2Input, options ...Option) (*GetBucketTaggingV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketTagging).
+		WithQuery("tagging", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketTaggingV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketTaggingV2 delete all tags of a bucket
+func (cli *ClientV2) DeleteBucketTaggingV2(ctx context.Context, input *DeleteBucketTaggingV2Input, options ...Option) (*DeleteBucketTaggingV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketTagging).
+		WithQuery("tagging", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketTaggingV2Output{RequestInfo: res.RequestInfo()}, nil
+}
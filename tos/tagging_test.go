@@ -0,0 +1,83 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaggingAtUpload(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, `{"ETag":"etag"}`)
+	tagSet := &TagSet{Tags: []Tag{{Key: "k1", Value: "v1"}, {Key: "k 2", Value: "v&2"}}}
+
+	_, err := client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", TagSet: tagSet},
+		Content:             strings.NewReader("a")})
+	require.Nil(t, err)
+	require.Equal(t, "k1=v1&k%202=v%262", transport.requests[0].Header.Get(HeaderTagging))
+
+	_, err = client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", Tagging: "k1=v1"},
+		Content:             strings.NewReader("a")})
+	require.Nil(t, err)
+	require.Equal(t, "k1=v1", transport.requests[1].Header.Get(HeaderTagging))
+
+	_, err = client.CreateMultipartUploadV2(context.Background(), &CreateMultipartUploadV2Input{
+		Bucket: "bucket", Key: "key", TagSet: tagSet})
+	require.Nil(t, err)
+	require.Equal(t, "k1=v1&k%202=v%262", transport.requests[2].Header.Get(HeaderTagging))
+
+	transport.respond(http.StatusOK, `{"ETag":"etag"}`)
+	_, err = client.CopyObject(context.Background(), &CopyObjectInput{
+		Bucket: "bucket", Key: "key", SrcBucket: "src", SrcKey: "src", TagSet: tagSet})
+	require.Nil(t, err)
+	require.Equal(t, "k1=v1&k%202=v%262", transport.requests[3].Header.Get(HeaderTagging))
+	require.Equal(t, "REPLACE", transport.requests[3].Header.Get(HeaderTaggingDirective))
+
+	// only one of Tagging and TagSet
+	_, err = client.CreateMultipartUploadV2(context.Background(), &CreateMultipartUploadV2Input{
+		Bucket: "bucket", Key: "key", Tagging: "k1=v1", TagSet: tagSet})
+	require.NotNil(t, err)
+	_, err = client.CopyObject(context.Background(), &CopyObjectInput{Bucket: "bucket", Key: "key",
+		SrcBucket: "src", SrcKey: "src", TagSet: &TagSet{Tags: []Tag{{Value: "v"}}}})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 4)
+}
+
+func TestBucketTaggingV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	tagSet := TagSet{Tags: []Tag{{Key: "project", Value: "tos"}, {Key: "team", Value: "storage"}}}
+	_, err := client.PutBucketTaggingV2(context.Background(), &PutBucketTaggingV2Input{Bucket: "bucket", TagSet: tagSet})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "tagging")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"TagSet":{"Tags":[{"Key":"project","Value":"tos"},{"Key":"team","Value":"storage"}]}}`,
+		string(data))
+
+	transport.respond(http.StatusOK, `{"TagSet":{"Tags":[{"Key":"owner","Value":"ops"}]}}`)
+	out, err := client.GetBucketTaggingV2(context.Background(), &GetBucketTaggingV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, TagSet{Tags: []Tag{{Key: "owner", Value: "ops"}}}, out.TagSet)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "tagging")
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketTaggingV2(context.Background(), &DeleteBucketTaggingV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+
+	for _, invalid := range []TagSet{{}, {Tags: []Tag{{Key: "k"}, {Key: "k"}}}} {
+		_, err = client.PutBucketTaggingV2(context.Background(), &PutBucketTaggingV2Input{Bucket: "bucket",
+			TagSet: invalid})
+		require.NotNil(t, err)
+	}
+	require.Len(t, transport.requests, 3)
+}
@@ -0,0 +1,111 @@
+package tos
+
+import (
+	"context"
+	"strconv"
+)
+
+// tracerName name of the Tracer got from TracerProvider
+const tracerName = "github.com/volcengine/ve-tos-golang-sdk/v2/tos"
+
+// attribute keys of spans
+const (
+	SpanAttributeOperation  = "tos.operation"
+	SpanAttributeBucket     = "tos.bucket"
+	SpanAttributeKey        = "tos.key"
+	SpanAttributePartNumber = "tos.part_number"
+	SpanAttributeStatusCode = "http.status_code"
+	SpanAttributeRequestID  = "tos.request_id"
+	SpanAttributeRetryCount = "tos.retry_count"
+	SpanAttributeBytesSent  = "tos.bytes_sent"     // length of request body, absent if unknown
+	SpanAttributeBytesRecv  = "tos.bytes_received" // Content-Length of response, absent if unknown
+	SpanAttributeFileSize   = "tos.file_size"      // size of file of UploadFile
+)
+
+// TracerProvider provides the Tracer to emit spans, set by WithTracerProvider.
+//
+// The interfaces mirror the ones of OpenTelemetry, so the SDK does not depend on it,
+// adapt trace.TracerProvider with a few lines, e.g.
+//
+//	type otelTracer struct{ tracer trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, tos.Span) {
+//	  ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//	  return ctx, otelSpan{span}
+//	}
+type TracerProvider interface {
+	Tracer(name string) Tracer
+}
+
+// Tracer starts spans
+type Tracer interface {
+	// Start a span as a child of the one in ctx if any, return ctx with the new span
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span of an operation
+type Span interface {
+	// SetAttributes set attributes, see SpanAttribute* constants
+	SetAttributes(attributes ...SpanAttribute)
+	// RecordError record the error the operation failed with
+	RecordError(err error)
+	End()
+}
+
+// SpanAttribute key and value of an attribute of Span, Value is one of string, int64 and int
+type SpanAttribute struct {
+	Key   string
+	Value interface{}
+}
+
+// startSpan start the span of rb, a span is emitted for each operation covering all retries and redirects
+func (rb *requestBuilder) startSpan(ctx context.Context) (context.Context, Span) {
+	ctx, span := rb.tracer.Start(ctx, rb.OperationName)
+	attributes := []SpanAttribute{{Key: SpanAttributeOperation, Value: rb.OperationName}}
+	if len(rb.Bucket) > 0 {
+		attributes = append(attributes, SpanAttribute{Key: SpanAttributeBucket, Value: rb.Bucket})
+	}
+	if len(rb.Object) > 0 {
+		attributes = append(attributes, SpanAttribute{Key: SpanAttributeKey, Value: rb.Object})
+	}
+	if partNumber, err := strconv.Atoi(rb.Query.Get("partNumber")); err == nil {
+		attributes = append(attributes, SpanAttribute{Key: SpanAttributePartNumber, Value: partNumber})
+	}
+	if rb.ContentLength != nil && *rb.ContentLength >= 0 {
+		attributes = append(attributes, SpanAttribute{Key: SpanAttributeBytesSent, Value: *rb.ContentLength})
+	}
+	span.SetAttributes(attributes...)
+	return ctx, span
+}
+
+// endSpan set the result of rb to span and end it
+func (rb *requestBuilder) endSpan(span Span, res *Response, err error) {
+	attributes := []SpanAttribute{{Key: SpanAttributeRetryCount, Value: retryCount(rb.attempts)}}
+	if err != nil {
+		if code := StatusCode(err); code != 0 {
+			attributes = append(attributes, SpanAttribute{Key: SpanAttributeStatusCode, Value: code})
+		}
+		if requestID := RequestID(err); len(requestID) > 0 {
+			attributes = append(attributes, SpanAttribute{Key: SpanAttributeRequestID, Value: requestID})
+		}
+		span.SetAttributes(attributes...)
+		span.RecordError(err)
+		span.End()
+		return
+	}
+	attributes = append(attributes,
+		SpanAttribute{Key: SpanAttributeStatusCode, Value: res.StatusCode},
+		SpanAttribute{Key: SpanAttributeRequestID, Value: res.RequestInfo().RequestID})
+	if res.ContentLength >= 0 {
+		attributes = append(attributes, SpanAttribute{Key: SpanAttributeBytesRecv, Value: res.ContentLength})
+	}
+	span.SetAttributes(attributes...)
+	span.End()
+}
+
+func retryCount(attempts int) int {
+	if attempts <= 1 {
+		return 0
+	}
+	return attempts - 1
+}
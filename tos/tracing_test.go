@@ -0,0 +1,99 @@
+package tos
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type spanKey struct{}
+
+type recordSpan struct {
+	name       string
+	parent     *recordSpan
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *recordSpan) SetAttributes(attributes ...SpanAttribute) {
+	for _, a := range attributes {
+		s.attributes[a.Key] = a.Value
+	}
+}
+
+func (s *recordSpan) RecordError(err error) { s.err = err }
+
+func (s *recordSpan) End() { s.ended = true }
+
+type recordTracer struct {
+	mu    sync.Mutex
+	spans []*recordSpan
+}
+
+func (t *recordTracer) Tracer(name string) Tracer { return t }
+
+func (t *recordTracer) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parent, _ := ctx.Value(spanKey{}).(*recordSpan)
+	span := &recordSpan{name: spanName, parent: parent, attributes: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func TestWithTracerProvider(t *testing.T) {
+	tracer := &recordTracer{}
+	client, _ := newRecordClient(t, http.StatusServiceUnavailable, "", WithTracerProvider(tracer),
+		WithMaxRetryCount(2), WithRetryBackoff(time.Millisecond, time.Millisecond))
+
+	_, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.NotNil(t, err)
+	require.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	require.Equal(t, OperationHeadObject, span.name)
+	require.True(t, span.ended)
+	require.Equal(t, err, span.err)
+	require.Equal(t, "bucket", span.attributes[SpanAttributeBucket])
+	require.Equal(t, "key", span.attributes[SpanAttributeKey])
+	require.Equal(t, http.StatusServiceUnavailable, span.attributes[SpanAttributeStatusCode])
+	require.Equal(t, 2, span.attributes[SpanAttributeRetryCount])
+}
+
+func TestUploadFileSpans(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-upload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	require.Nil(t, ioutil.WriteFile(file, []byte("hello"), 0666))
+
+	tracer := &recordTracer{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&multipartTransport{}), WithTracerProvider(tracer))
+	require.Nil(t, err)
+	_, err = client.UploadFile(context.Background(), &UploadFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:                     file,
+	})
+	require.Nil(t, err)
+
+	var names []string
+	for _, span := range tracer.spans {
+		names = append(names, span.name)
+		require.True(t, span.ended)
+		if span.name == OperationUploadPart {
+			require.Equal(t, "UploadFile", span.parent.name)
+			require.Equal(t, 1, span.attributes[SpanAttributePartNumber])
+			require.Equal(t, int64(5), span.attributes[SpanAttributeBytesSent])
+		}
+	}
+	require.Equal(t, []string{"UploadFile", OperationCreateMultipartUpload, OperationUploadPart,
+		OperationCompleteMultipartUpload}, names)
+	require.Equal(t, int64(5), tracer.spans[0].attributes[SpanAttributeFileSize])
+}
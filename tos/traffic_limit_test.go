@@ -0,0 +1,34 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrafficLimit(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	ctx := context.Background()
+
+	_, err := client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key", TrafficLimit: 819200})
+	require.Nil(t, err)
+	_, err = client.PutObjectV2(ctx, &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", TrafficLimit: 819200},
+		Content:             strings.NewReader("a")})
+	require.Nil(t, err)
+	_, err = client.UploadPartV2(ctx, &UploadPartV2Input{
+		UploadPartBasicInput: UploadPartBasicInput{Bucket: "bucket", Key: "key", UploadID: "upload", PartNumber: 1,
+			TrafficLimit: 819200},
+		Content: strings.NewReader("a")})
+	require.Nil(t, err)
+	for _, req := range transport.requests {
+		require.Equal(t, "819200", req.Header.Get(HeaderTrafficLimit), req.OperationName)
+	}
+
+	_, err = client.GetObjectV2(ctx, &GetObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Empty(t, transport.requests[3].Header.Get(HeaderTrafficLimit))
+}
@@ -0,0 +1,63 @@
+package tos
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// rateWindow the period Speed of DataTransferStatus is measured over
+	rateWindow = time.Second
+	// rateSampleInterval the minimum interval between samples, bounding the number of samples in rateWindow
+	rateSampleInterval = 100 * time.Millisecond
+)
+
+type rateSample struct {
+	at       time.Time
+	consumed int64
+}
+
+// transferRate compute speed and ETA of a transfer, it's shared by all parts of a transfer
+type transferRate struct {
+	mu      sync.Mutex
+	start   time.Time
+	samples []rateSample // the first one is the latest sample at least rateWindow ago, if any
+}
+
+// newTransferRate start measuring a transfer from now
+func newTransferRate() *transferRate {
+	now := time.Now()
+	return &transferRate{start: now, samples: []rateSample{{at: now}}}
+}
+
+// update set Speed, AverageSpeed and ETA of status by its ConsumedBytes and TotalBytes
+func (r *transferRate) update(status *DataTransferStatus) {
+	now := time.Now()
+	r.mu.Lock()
+	for len(r.samples) > 1 && now.Sub(r.samples[1].at) >= rateWindow {
+		r.samples = r.samples[1:]
+	}
+	oldest := r.samples[0]
+	if now.Sub(r.samples[len(r.samples)-1].at) >= rateSampleInterval {
+		r.samples = append(r.samples, rateSample{at: now, consumed: status.ConsumedBytes})
+	}
+	r.mu.Unlock()
+
+	if elapsed := now.Sub(oldest.at).Seconds(); elapsed > 0 {
+		status.Speed = float64(status.ConsumedBytes-oldest.consumed) / elapsed
+	}
+	if elapsed := now.Sub(r.start).Seconds(); elapsed > 0 {
+		status.AverageSpeed = float64(status.ConsumedBytes) / elapsed
+	}
+	status.ETA = -1
+	switch speed := status.Speed; {
+	case status.TotalBytes > 0 && status.ConsumedBytes >= status.TotalBytes:
+		status.ETA = 0
+	case status.TotalBytes <= 0:
+	case speed > 0 || status.AverageSpeed > 0:
+		if speed <= 0 {
+			speed = status.AverageSpeed
+		}
+		status.ETA = time.Duration(float64(status.TotalBytes-status.ConsumedBytes) / speed * float64(time.Second))
+	}
+}
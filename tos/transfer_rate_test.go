@@ -0,0 +1,63 @@
+package tos
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+)
+
+type statusListener struct {
+	statuses []DataTransferStatus
+}
+
+func (l *statusListener) DataTransferStatusChange(status *DataTransferStatus) {
+	l.statuses = append(l.statuses, *status)
+}
+
+func (l *statusListener) internal() {}
+
+func TestTransferRate(t *testing.T) {
+	rate := newTransferRate()
+	// pretend the transfer started 2s ago, and 100 bytes were consumed 1s ago
+	rate.start = rate.start.Add(-2 * time.Second)
+	rate.samples = []rateSample{{at: rate.start}, {at: rate.start.Add(time.Second), consumed: 100}}
+
+	status := &DataTransferStatus{ConsumedBytes: 300, TotalBytes: 1000}
+	rate.update(status)
+	require.InEpsilon(t, 200, status.Speed, 0.05)
+	require.InEpsilon(t, 150, status.AverageSpeed, 0.05)
+	require.InEpsilon(t, float64(3500*time.Millisecond), float64(status.ETA), 0.05)
+
+	status = &DataTransferStatus{ConsumedBytes: 300, TotalBytes: -1}
+	rate.update(status)
+	require.Equal(t, time.Duration(-1), status.ETA)
+	status = &DataTransferStatus{ConsumedBytes: 1000, TotalBytes: 1000}
+	rate.update(status)
+	require.Equal(t, time.Duration(0), status.ETA)
+}
+
+func TestDataTransferStatusSpeed(t *testing.T) {
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&readingTransport{}))
+	require.Nil(t, err)
+	listener := &statusListener{}
+	_, err = client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", ContentLength: 5,
+			DataTransferListener: listener},
+		Content: iotest.OneByteReader(strings.NewReader("hello")),
+	})
+	require.Nil(t, err)
+	last := listener.statuses[len(listener.statuses)-1]
+	require.Equal(t, enum.DataTransferSucceed, last.Type)
+	require.True(t, last.AverageSpeed > 0)
+	for _, status := range listener.statuses {
+		if status.Type == enum.DataTransferRW {
+			require.True(t, status.ETA >= 0)
+			require.True(t, status.AverageSpeed > 0)
+		}
+	}
+}
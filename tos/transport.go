@@ -3,6 +3,7 @@ package tos
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"net"
 	"net/http"
 	"time"
@@ -13,6 +14,12 @@ type TransportConfig struct {
 	// MaxIdleConns same as http.Transport MaxIdleConns
 	MaxIdleConns int
 
+	// MaxIdleConnsPerHost same as http.Transport MaxIdleConnsPerHost, 0 means http.DefaultMaxIdleConnsPerHost
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost same as http.Transport MaxConnsPerHost, 0 means no limit
+	MaxConnsPerHost int
+
 	// RequestTimeout same as http.Client Timeout
 	RequestTimeout time.Duration
 
@@ -42,6 +49,26 @@ type TransportConfig struct {
 
 	// InsecureSkipVerify set tls.Config InsecureSkipVerify
 	InsecureSkipVerify bool
+
+	// ClientCertificates set tls.Config Certificates, presented to server for mutual TLS
+	ClientCertificates []tls.Certificate
+
+	// RootCAs set tls.Config RootCAs, nil means the system pool is used
+	RootCAs *x509.CertPool
+
+	// TLSConfig nullable, if it's set, InsecureSkipVerify, ClientCertificates and RootCAs are ignored
+	TLSConfig *tls.Config
+
+	// DialContext nullable, dial connections with it instead of net.Dialer, e.g. dial a local proxy.
+	// DialTimeout and KeepAlive are ignored if it's set, ReadTimeout and WriteTimeout still take effect
+	DialContext func(ctx context.Context, network, address string) (net.Conn, error)
+
+	// IPv4Only dial IPv4 addresses only, otherwise both IPv4 and IPv6 addresses of a dual-stack host are tried,
+	// with fallback between them
+	IPv4Only bool
+
+	// EnableHTTP2 try HTTP/2 on TLS connections, HTTP/1.1 is used if it's disabled or not supported by server
+	EnableHTTP2 bool
 }
 
 type Transport interface {
@@ -52,8 +79,52 @@ type DefaultTransport struct {
 	client http.Client
 }
 
+func newTLSConfig(config *TransportConfig) *tls.Config {
+	if config.TLSConfig != nil {
+		return config.TLSConfig.Clone()
+	}
+	// #nosec G402
+	return &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		Certificates:       config.ClientCertificates,
+		RootCAs:            config.RootCAs,
+	}
+}
+
 // NewDefaultTransport create a DefaultTransport with config
 func NewDefaultTransport(config *TransportConfig) *DefaultTransport {
+	transport := &http.Transport{
+		//DialContext: (&net.Dialer{
+		//	Timeout:   config.DialTimeout,
+		//	KeepAlive: config.KeepAlive,
+		//}).DialContext,
+		DialContext: (&TimeoutDialer{
+			Dialer: net.Dialer{
+				Timeout:   config.DialTimeout,
+				KeepAlive: config.KeepAlive,
+			},
+			ReadTimeout:     config.ReadTimeout,
+			WriteTimeout:    config.WriteTimeout,
+			DialContextFunc: config.DialContext,
+			IPv4Only:        config.IPv4Only,
+		}).DialContext,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+		ExpectContinueTimeout: config.ExpectContinueTimeout,
+		DisableCompression:    true,
+		TLSClientConfig:       newTLSConfig(config),
+	}
+	if config.EnableHTTP2 {
+		// custom DialContext and TLSClientConfig disable HTTP/2 unless it's forced
+		transport.ForceAttemptHTTP2 = true
+	} else {
+		// a non-nil empty map disables HTTP/2
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
 	return &DefaultTransport{
 		client: http.Client{
 			// TODO: uncomment this in v2.2.0
@@ -61,28 +132,7 @@ func NewDefaultTransport(config *TransportConfig) *DefaultTransport {
 			// CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			// 	return http.ErrUseLastResponse
 			// },
-			Transport: &http.Transport{
-				//DialContext: (&net.Dialer{
-				//	Timeout:   config.DialTimeout,
-				//	KeepAlive: config.KeepAlive,
-				//}).DialContext,
-				DialContext: (&TimeoutDialer{
-					Dialer: net.Dialer{
-						Timeout:   config.DialTimeout,
-						KeepAlive: config.KeepAlive,
-					},
-					ReadTimeout:  config.ReadTimeout,
-					WriteTimeout: config.WriteTimeout,
-				}).DialContext,
-				MaxIdleConns:          config.MaxIdleConns,
-				IdleConnTimeout:       config.IdleConnTimeout,
-				TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
-				ResponseHeaderTimeout: config.ResponseHeaderTimeout,
-				ExpectContinueTimeout: config.ExpectContinueTimeout,
-				DisableCompression:    true,
-				// #nosec G402
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify},
-			},
+			Transport: transport,
 		},
 	}
 }
@@ -164,10 +214,21 @@ type TimeoutDialer struct {
 	net.Dialer
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	// DialContextFunc nullable, used instead of Dialer if set
+	DialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+	// IPv4Only dial "tcp4" instead of "tcp"
+	IPv4Only bool
 }
 
 func (d *TimeoutDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
-	conn, err := d.Dialer.DialContext(ctx, network, address)
+	dial := d.Dialer.DialContext
+	if d.DialContextFunc != nil {
+		dial = d.DialContextFunc
+	}
+	if d.IPv4Only && network == "tcp" {
+		network = "tcp4"
+	}
+	conn, err := dial(ctx, network, address)
 	if err != nil {
 		return nil, err
 	}
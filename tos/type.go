@@ -76,6 +76,80 @@ type PutObjectACLInput struct {
 	Grants           []Grant
 }
 
+// AccessControlPolicy owner and grants of an object
+type AccessControlPolicy struct {
+	Owner  Owner
+	Grants []Grant
+	// BucketOwnerEntrusted grant the owner of bucket full control of the object
+	BucketOwnerEntrusted bool
+}
+
+// PutObjectACLV2Input set ACL by a canned ACL, grant headers, or AccessControlPolicy, which can not be set
+// together with the others
+type PutObjectACLV2Input struct {
+	Bucket              string
+	Key                 string
+	VersionID           string       `location:"query" locationName:"versionId"`
+	ACL                 enum.ACLType `location:"header" locationName:"X-Tos-Acl"`
+	GrantFullControl    string       `location:"header" locationName:"X-Tos-Grant-Full-Control"` // e.g. id="123",id="456"
+	GrantRead           string       `location:"header" locationName:"X-Tos-Grant-Read"`
+	GrantReadAcp        string       `location:"header" locationName:"X-Tos-Grant-Read-Acp"`
+	GrantWriteAcp       string       `location:"header" locationName:"X-Tos-Grant-Write-Acp"`
+	AccessControlPolicy *AccessControlPolicy
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
+}
+
+type PutObjectACLV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetObjectACLV2Input struct {
+	Bucket    string
+	Key       string
+	VersionID string `location:"query" locationName:"versionId"`
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
+}
+
+type GetObjectACLV2Output struct {
+	RequestInfo `json:"-"`
+	VersionID   string
+	AccessControlPolicy
+}
+
+// BucketAccessControlPolicy owner and grants of a bucket
+type BucketAccessControlPolicy struct {
+	Owner  Owner
+	Grants []Grant
+}
+
+// PutBucketACLV2Input set ACL by a canned ACL, grant headers, or AccessControlPolicy, which can not be set
+// together with the others
+type PutBucketACLV2Input struct {
+	Bucket              string
+	ACL                 enum.ACLType `location:"header" locationName:"X-Tos-Acl"`
+	GrantFullControl    string       `location:"header" locationName:"X-Tos-Grant-Full-Control"` // e.g. id="123",id="456"
+	GrantRead           string       `location:"header" locationName:"X-Tos-Grant-Read"`
+	GrantReadAcp        string       `location:"header" locationName:"X-Tos-Grant-Read-Acp"`
+	GrantWrite          string       `location:"header" locationName:"X-Tos-Grant-Write"`
+	GrantWriteAcp       string       `location:"header" locationName:"X-Tos-Grant-Write-Acp"`
+	AccessControlPolicy *BucketAccessControlPolicy
+}
+
+type PutBucketACLV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketACLV2Input struct {
+	Bucket string
+}
+
+type GetBucketACLV2Output struct {
+	RequestInfo `json:"-"`
+	BucketAccessControlPolicy
+}
+
 type PutObjectAclOutput struct {
 	RequestInfo `json:"-"`
 }
@@ -118,6 +192,7 @@ type CreateBucketV2Input struct {
 	GrantWriteAcp    string                `location:"header" locationName:"X-Tos-Grant-Write-Acp"`    // optional
 	StorageClass     enum.StorageClassType `location:"header" locationName:"X-Tos-Storage-Class"`      // setting the default storage type for buckets
 	AzRedundancy     enum.AzRedundancyType `location:"header" locationName:"X-Tos-Az-Redundancy"`      // setting the AZ type for buckets
+	BucketType       enum.BucketType       `location:"header" locationName:"X-Tos-Bucket-Type"`        // BucketTypeHNS to create a bucket with hierarchical namespace
 }
 
 type CreateBucketOutput struct {
@@ -133,12 +208,52 @@ type HeadBucketOutput struct {
 	RequestInfo  `json:"-"`
 	Region       string                `json:"Region,omitempty"`
 	StorageClass enum.StorageClassType `json:"StorageClass,omitempty"`
+	AzRedundancy enum.AzRedundancyType `json:"AzRedundancy,omitempty"` // empty if server doesn't return it
+	BucketType   enum.BucketType       `json:"BucketType,omitempty"`   // empty if server doesn't return it
 }
 
 type HeadBucketInput struct {
 	Bucket string
 }
 
+type PutBucketStorageClassV2Input struct {
+	Bucket       string
+	StorageClass enum.StorageClassType `location:"header" locationName:"X-Tos-Storage-Class"` // required
+}
+
+type PutBucketStorageClassV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketLocationV2Input struct {
+	Bucket string
+}
+
+type GetBucketLocationV2Output struct {
+	RequestInfo      `json:"-"`
+	Region           string `json:"Region,omitempty"`
+	ExtranetEndpoint string `json:"ExtranetEndpoint,omitempty"`
+	IntranetEndpoint string `json:"IntranetEndpoint,omitempty"`
+}
+
+type GetBucketInfoInput struct {
+	Bucket string
+}
+
+// GetBucketInfoOutput RequestInfo is of the HeadBucket request
+type GetBucketInfoOutput struct {
+	RequestInfo      `json:"-"`
+	Name             string
+	Region           string
+	CreationDate     string // empty if the bucket is not listed by the AK, e.g. accessed by bucket policy
+	StorageClass     enum.StorageClassType
+	AzRedundancy     enum.AzRedundancyType
+	BucketType       enum.BucketType
+	VersioningStatus string // BucketVersioningEnable, BucketVersioningSuspended, or empty if never enabled or unknown
+	ExtranetEndpoint string
+	IntranetEndpoint string
+}
+
 type DeleteBucketInput struct {
 	Bucket string
 }
@@ -168,6 +283,7 @@ type ListedBucket struct {
 	Location         string `json:"Location,omitempty"`
 	ExtranetEndpoint string `json:"ExtranetEndpoint,omitempty"`
 	IntranetEndpoint string `json:"IntranetEndpoint,omitempty"`
+	ProjectName      string `json:"ProjectName,omitempty"`
 }
 
 type ListBucketsV2Output struct {
@@ -178,7 +294,10 @@ type ListBucketsV2Output struct {
 
 type ListBucketsInput struct{}
 
-type ListBucketsV2Input ListBucketsInput
+type ListBucketsV2Input struct {
+	// ProjectName optional, list only buckets of the project
+	ProjectName string `location:"header" locationName:"X-Tos-Project-Name"`
+}
 
 type PutObjectBasicInput struct {
 	Bucket             string
@@ -205,14 +324,43 @@ type PutObjectBasicInput struct {
 	SSECKey                 string                `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
 	SSECKeyMD5              string                `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
 	ServerSideEncryption    string                `location:"header" locationName:"X-Tos-Server-Side-Encryption"`
+	TrafficLimit            int64                 `location:"header" locationName:"X-Tos-Traffic-Limit"` // bit/s, enforced by the server
+	Tagging                 string                `location:"header" locationName:"X-Tos-Tagging"`       // e.g. "k1=v1&k2=v2"
+	RequestPayer            string                `location:"header" locationName:"X-Tos-Request-Payer"`
 	Meta                    map[string]string     `location:"headers"`
 	DataTransferListener    DataTransferListener
 	RateLimiter             RateLimiter
+	// ForbidOverwrite create the object only if Key doesn't exist, or fail with code ObjectAlreadyExists,
+	// see IsObjectAlreadyExists
+	ForbidOverwrite bool
+	// Callback optional, the application server called after the object is created, see Callback
+	Callback *Callback
+	// CallbackVar optional, custom variables referenced by Callback.Body, keys must start with "x:"
+	CallbackVar map[string]string
+	// TagSet optional, tags of the object, which is encoded as Tagging, only one of them can be set
+	TagSet *TagSet
+}
+
+type Tag struct {
+	Key   string `json:"Key,omitempty"`
+	Value string `json:"Value,omitempty"`
+}
+
+type TagSet struct {
+	Tags []Tag `json:"Tags,omitempty"`
 }
 
 type PutObjectV2Input struct {
 	PutObjectBasicInput
 	Content io.Reader
+	// ContentProvider optional, if it's set, Content is ignored and the content of each attempt is read from
+	// a new reader returned by it, so that the request can be retried even if the content is not seekable.
+	// Otherwise, the request is retried only if Content is seekable, or it's buffered, see WithRetryBufferSize.
+	ContentProvider ContentProvider
+
+	// Codec optional, name of a registered Codec to compress Content with, the name is recorded in object meta.
+	// Compressed content is sent in chunked encoding.
+	Codec string
 }
 
 type PutObjectV2Output struct {
@@ -222,6 +370,20 @@ type PutObjectV2Output struct {
 	SSECKeyMD5    string
 	VersionID     string
 	HashCrc64ecma uint64
+	// CallbackResult response body of the application server, set only if Callback of input is set
+	CallbackResult string
+}
+
+// Callback the application server called by TOS after an object is created by PutObjectV2 or
+// CompleteMultipartUploadV2, the response of the application server is returned as CallbackResult of the output.
+// If the call fails, the object is still created, and the error code is CallbackFailed.
+type Callback struct {
+	URL  string `json:"callbackUrl"`            // required, URL of the application server
+	Host string `json:"callbackHost,omitempty"` // optional, Host header of the call
+	// Body required, body of the call, which may reference system variables, e.g. ${bucket}, ${object}, ${etag},
+	// and custom variables in CallbackVar, e.g. ${x:var}
+	Body     string `json:"callbackBody"`
+	BodyType string `json:"callbackBodyType,omitempty"` // optional, Content-Type of Body
 }
 
 type PutObjectOutput struct {
@@ -293,6 +455,7 @@ type AppendObjectV2Input struct {
 	WebsiteRedirectLocation string                `location:"header" locationName:"X-Tos-Website-Redirect-Location"`
 	StorageClass            enum.StorageClassType `location:"header" locationName:"X-Tos-Storage-Class"`
 
+	RequestPayer         string            `location:"header" locationName:"X-Tos-Request-Payer"`
 	Meta                 map[string]string `location:"headers"`
 	DataTransferListener DataTransferListener
 	RateLimiter          RateLimiter
@@ -312,232 +475,1250 @@ type AppendObjectV2Output struct {
 	HashCrc64ecma    uint64 `json:"HashCrc64Ecma,omitempty"`
 }
 
-type SetObjectMetaInput struct {
-	Bucket    string
-	Key       string
-	VersionID string
+type SetObjectMetaInput struct {
+	Bucket    string
+	Key       string
+	VersionID string
+
+	CacheControl       string    `location:"header" locationName:"Cache-Control"`
+	ContentDisposition string    `location:"header" locationName:"Content-Disposition"`
+	ContentEncoding    string    `location:"header" locationName:"Content-Encoding"`
+	ContentLanguage    string    `location:"header" locationName:"Content-Language"`
+	ContentType        string    `location:"header" locationName:"Content-Type"`
+	Expires            time.Time `location:"header" locationName:"Expires"`
+
+	Meta map[string]string `location:"headers"`
+}
+
+type SetObjectMetaOutput struct {
+	RequestInfo `json:"-"`
+}
+
+// SetObjectMetaV2Input metadata set by SetObjectMetaV2, all of them replace the previous ones, including unset ones
+type SetObjectMetaV2Input struct {
+	Bucket    string
+	Key       string
+	VersionID string `location:"query" locationName:"versionId"`
+
+	CacheControl       string    `location:"header" locationName:"Cache-Control"`
+	ContentDisposition string    `location:"header" locationName:"Content-Disposition" encodeChinese:"true"`
+	ContentEncoding    string    `location:"header" locationName:"Content-Encoding"`
+	ContentLanguage    string    `location:"header" locationName:"Content-Language"`
+	ContentType        string    `location:"header" locationName:"Content-Type"`
+	Expires            time.Time `location:"header" locationName:"Expires"`
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
+
+	Meta map[string]string `location:"headers"`
+}
+
+type SetObjectMetaV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type ListObjectsV2Input struct {
+	Bucket string
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
+	ListObjectsInput
+}
+
+type ListObjectsInput struct {
+	Prefix       string `location:"query" locationName:"prefix"`
+	Delimiter    string `location:"query" locationName:"delimiter"`
+	Marker       string `location:"query" locationName:"marker"`
+	MaxKeys      int    `location:"query" locationName:"max-keys"`
+	Reverse      bool   `location:"query" locationName:"reverse"`
+	EncodingType string `location:"query" locationName:"encoding-type"` // "" or "url"
+}
+
+type ListedObject struct {
+	Key           string `json:"Key,omitempty"`
+	LastModified  string `json:"LastModified,omitempty"`
+	ETag          string `json:"ETag,omitempty"`
+	Size          int64  `json:"Size,omitempty"`
+	Owner         Owner  `json:"Owner,omitempty"`
+	StorageClass  string `json:"StorageClass,omitempty"`
+	HashCrc64ecma uint64 `json:"HashCrc64Ecma,omitempty"`
+}
+
+type ListedCommonPrefix struct {
+	Prefix string `json:"Prefix,omitempty"`
+}
+
+type ListObjectsOutput struct {
+	RequestInfo    `json:"-"`
+	Name           string               `json:"Name,omitempty"` // bucket name
+	Prefix         string               `json:"Prefix,omitempty"`
+	Marker         string               `json:"Marker,omitempty"`
+	MaxKeys        int64                `json:"MaxKeys,omitempty"`
+	NextMarker     string               `json:"NextMarker,omitempty"`
+	Delimiter      string               `json:"Delimiter,omitempty"`
+	IsTruncated    bool                 `json:"IsTruncated,omitempty"`
+	EncodingType   string               `json:"EncodingType,omitempty"`
+	CommonPrefixes []ListedCommonPrefix `json:"CommonPrefixes,omitempty"`
+	Contents       []ListedObject       `json:"Contents,omitempty"`
+}
+
+type ListObjectsV2Output struct {
+	ListObjectsOutput
+}
+
+// ListObjectsType2Input input of ListObjectsType2, set ContinuationToken to NextContinuationToken of the previous
+// output to list the next page
+type ListObjectsType2Input struct {
+	Bucket            string
+	Prefix            string `location:"query" locationName:"prefix"`
+	Delimiter         string `location:"query" locationName:"delimiter"`
+	StartAfter        string `location:"query" locationName:"start-after"` // ignored if ContinuationToken is set
+	ContinuationToken string `location:"query" locationName:"continuation-token"`
+	MaxKeys           int    `location:"query" locationName:"max-keys"`
+	FetchOwner        bool   `location:"query" locationName:"fetch-owner"`   // Owner of objects is set only if it's true
+	EncodingType      string `location:"query" locationName:"encoding-type"` // "" or "url"
+	RequestPayer      string `location:"header" locationName:"X-Tos-Request-Payer"`
+}
+
+type ListObjectsType2Output struct {
+	RequestInfo           `json:"-"`
+	Name                  string               `json:"Name,omitempty"` // bucket name
+	Prefix                string               `json:"Prefix,omitempty"`
+	StartAfter            string               `json:"StartAfter,omitempty"`
+	ContinuationToken     string               `json:"ContinuationToken,omitempty"`
+	NextContinuationToken string               `json:"NextContinuationToken,omitempty"`
+	MaxKeys               int                  `json:"MaxKeys,omitempty"`
+	KeyCount              int                  `json:"KeyCount,omitempty"` // count of Contents and CommonPrefixes
+	Delimiter             string               `json:"Delimiter,omitempty"`
+	IsTruncated           bool                 `json:"IsTruncated,omitempty"`
+	EncodingType          string               `json:"EncodingType,omitempty"`
+	CommonPrefixes        []ListedCommonPrefix `json:"CommonPrefixes,omitempty"`
+	Contents              []ListedObject       `json:"Contents,omitempty"`
+}
+type ListObjectVersionsInput struct {
+	Prefix          string `location:"query" locationName:"prefix"`
+	Delimiter       string `location:"query" locationName:"delimiter"`
+	KeyMarker       string `location:"query" locationName:"key-marker"`
+	VersionIDMarker string `location:"query" locationName:"version-id-marker"`
+	MaxKeys         int    `location:"query" locationName:"max-keys"`
+	EncodingType    string `location:"query" locationName:"encoding-type"` // "" or "url"
+}
+
+type ListObjectVersionsV2Input struct {
+	Bucket string `json:"Prefix,omitempty"`
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
+	ListObjectVersionsInput
+}
+
+type ListedObjectVersion struct {
+	ETag          string `json:"ETag,omitempty"`
+	IsLatest      bool   `json:"IsLatest,omitempty"`
+	Key           string `json:"Key,omitempty"`
+	LastModified  string `json:"LastModified,omitempty"`
+	Owner         Owner  `json:"Owner,omitempty"`
+	Size          int64  `json:"Size,omitempty"`
+	StorageClass  string `json:"StorageClass,omitempty"`
+	VersionID     string `json:"VersionId,omitempty"`
+	HashCrc64ecma uint64 `json:"HashCrc64Ecma,omitempty"`
+}
+
+type ListedDeleteMarkerEntry struct {
+	IsLatest     bool   `json:"IsLatest,omitempty"`
+	Key          string `json:"Key,omitempty"`
+	LastModified string `json:"LastModified,omitempty"`
+	Owner        Owner  `json:"Owner,omitempty"`
+	VersionID    string `json:"VersionId,omitempty"`
+}
+
+type ListedDeleteMarker struct {
+	Key          string
+	LastModified time.Time
+	IsLatest     bool
+	Owner        Owner
+	VersionID    string
+}
+
+type ListObjectVersionsV2Output struct {
+	RequestInfo         `json:"-"`
+	Name                string                `json:"Name,omitempty"` // bucket name
+	Prefix              string                `json:"Prefix,omitempty"`
+	KeyMarker           string                `json:"KeyMarker,omitempty"`
+	VersionIDMarker     string                `json:"VersionIdMarker,omitempty"`
+	Delimiter           string                `json:"Delimiter,omitempty"`
+	EncodingType        string                `json:"EncodingType,omitempty"`
+	MaxKeys             int64                 `json:"MaxKeys,omitempty"`
+	NextKeyMarker       string                `json:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string                `json:"NextVersionIdMarker,omitempty"`
+	IsTruncated         bool                  `json:"IsTruncated,omitempty"`
+	CommonPrefixes      []ListedCommonPrefix  `json:"CommonPrefixes,omitempty"`
+	Versions            []ListedObjectVersion `json:"Versions,omitempty"`
+	DeleteMarkers       []ListedDeleteMarker  `json:"DeleteMarkers,omitempty"`
+}
+
+type ListObjectVersionsOutput struct {
+	RequestInfo         `json:"-"`
+	Name                string                    `json:"Name,omitempty"` // bucket name
+	Prefix              string                    `json:"Prefix,omitempty"`
+	KeyMarker           string                    `json:"KeyMarker,omitempty"`
+	VersionIDMarker     string                    `json:"VersionIdMarker,omitempty"`
+	Delimiter           string                    `json:"Delimiter,omitempty"`
+	EncodingType        string                    `json:"EncodingType,omitempty"`
+	MaxKeys             int64                     `json:"MaxKeys,omitempty"`
+	NextKeyMarker       string                    `json:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string                    `json:"NextVersionIdMarker,omitempty"`
+	IsTruncated         bool                      `json:"IsTruncated,omitempty"`
+	CommonPrefixes      []ListedCommonPrefix      `json:"CommonPrefixes,omitempty"`
+	Versions            []ListedObjectVersion     `json:"Versions,omitempty"`
+	DeleteMarkers       []ListedDeleteMarkerEntry `json:"DeleteMarkers,omitempty"`
+}
+
+type GetObjectOutput struct {
+	RequestInfo  `json:"-"`
+	ContentRange string        `json:"ContentRange,omitempty"`
+	Content      io.ReadCloser `json:"-"`
+	ObjectMeta
+}
+
+type GetObjectV2Input struct {
+	Bucket    string
+	Key       string
+	VersionID string `location:"query" locationName:"versionId"`
+
+	IfMatch           string    `location:"header" locationName:"If-Match"`
+	IfModifiedSince   time.Time `location:"header" locationName:"If-Modified-Since"`
+	IfNoneMatch       string    `location:"header" locationName:"If-None-Match"`
+	IfUnmodifiedSince time.Time `location:"header" locationName:"If-Unmodified-Since"`
+
+	SSECAlgorithm string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Algorithm"`
+	SSECKey       string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
+	SSECKeyMD5    string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
+
+	ResponseCacheControl       string    `location:"query" locationName:"Cache-Control"`
+	ResponseContentDisposition string    `location:"query" locationName:"Content-Disposition"`
+	ResponseContentEncoding    string    `location:"query" locationName:"Content-Encoding"`
+	ResponseContentLanguage    string    `location:"query" locationName:"Content-Language"`
+	ResponseContentType        string    `location:"query" locationName:"Content-Type"`
+	ResponseExpires            time.Time `location:"query" locationName:"Expires"`
+
+	// TrafficLimit optional, bandwidth limit of the request enforced by the server, in bit/s
+	TrafficLimit int64 `location:"header" locationName:"X-Tos-Traffic-Limit"`
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
+
+	RangeStart int64
+	RangeEnd   int64
+
+	DataTransferListener DataTransferListener
+	RateLimiter          RateLimiter
+
+	// DecodeContent decompress Content with the Codec recorded in object meta, if any
+	DecodeContent bool
+
+	// DecompressGzip decompress Content if Content-Encoding of the response is gzip, e.g. compressed by a gateway,
+	// and CRC checking is skipped as CRC of the object doesn't match the decompressed content. ContentLength of output
+	// is still the length of the compressed content. It's ignored for range requests. The raw encoded content is
+	// returned if it's not set.
+	DecompressGzip bool
+
+	// ReaderPipelineHook nullable, customize stages wrapping Content of output, e.g. insert custom stages
+	ReaderPipelineHook func(pipeline *ReaderPipeline)
+
+	// Process optional, image or video processing applied to the object, e.g. "image/resize,w_100", or a style
+	// "style/name". Content of output is the processed result, CRC of the object isn't checked in this case.
+	// Use ProcessObject to save the result to another object.
+	Process string
+}
+
+type ProcessObjectInput struct {
+	Bucket    string
+	Key       string
+	VersionID string `location:"query" locationName:"versionId"`
+	// Process image or video processing applied to the object, the same as Process of GetObjectV2Input
+	Process string
+	// SaveAsBucket the bucket where the result is saved, empty means Bucket
+	SaveAsBucket string
+	// SaveAsKey required, the key of the saved result
+	SaveAsKey string
+}
+
+type ProcessObjectOutput struct {
+	RequestInfo `json:"-"`
+	Bucket      string `json:"bucket,omitempty"`   // the bucket where the result is saved
+	Key         string `json:"object,omitempty"`   // the key of the saved result
+	FileSize    int64  `json:"fileSize,omitempty"` // size of the saved result
+	Status      string `json:"status,omitempty"`
+}
+
+type GetObjectBasicOutput struct {
+	RequestInfo
+
+	ContentRange string
+	ObjectMetaV2
+}
+
+type GetObjectV2Output struct {
+	GetObjectBasicOutput
+	Content io.ReadCloser
+}
+
+type GetObjectToFileInput struct {
+	GetObjectV2Input
+	FilePath string
+}
+
+type GetObjectToFileOutput struct {
+	GetObjectBasicOutput
+}
+
+type HeadObjectV2Input struct {
+	Bucket    string
+	Key       string
+	VersionID string `location:"query" locationName:"versionId"`
+
+	IfMatch           string    `location:"header" locationName:"If-Match"`
+	IfModifiedSince   time.Time `location:"header" locationName:"If-Modified-Since"`
+	IfNoneMatch       string    `location:"header" locationName:"If-None-Match"`
+	IfUnmodifiedSince time.Time `location:"header" locationName:"If-Unmodified-Since"`
+
+	SSECAlgorithm string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Algorithm"`
+	SSECKey       string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
+	SSECKeyMD5    string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
+	RequestPayer  string `location:"header" locationName:"X-Tos-Request-Payer"`
+}
+
+type HeadObjectOutput struct {
+	RequestInfo  `json:"-"`
+	ContentRange string `json:"ContentRange,omitempty"`
+	ObjectMeta
+}
+
+type HeadObjectV2Output struct {
+	RequestInfo `json:"-"`
+	ObjectMetaV2
+}
+
+type RestoreObjectV2Input struct {
+	Bucket    string
+	Key       string
+	VersionID string `location:"query" locationName:"versionId"`
+	// Days how long the restored copy is kept
+	Days int64
+	// Tier retrieval speed, the server decides if it's empty
+	Tier enum.TierType
+}
+
+type restoreObjectV2Input struct {
+	Days                 int64                 `json:"Days,omitempty"`
+	RestoreJobParameters *restoreJobParameters `json:"RestoreJobParameters,omitempty"`
+}
+
+type restoreJobParameters struct {
+	Tier enum.TierType `json:"Tier,omitempty"`
+}
+
+type RestoreObjectV2Output struct {
+	RequestInfo `json:"-"`
+	// Accepted is true if a restore is started, and false if the object is restored already,
+	// in which case the expiry date of the restored copy is updated
+	Accepted bool
+}
+
+type RenameObjectV2Input struct {
+	Bucket string
+	Key    string // the object to rename
+	NewKey string `location:"query" locationName:"name"`
+	// ForbidOverwrite fail with 409 if NewKey exists, instead of overwriting it
+	ForbidOverwrite bool `location:"header" locationName:"X-Tos-Forbid-Overwrite"`
+}
+
+type RenameObjectV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type CreateDirectoryInput struct {
+	Bucket string
+	Key    string // path of the directory, '/' is appended if it doesn't end with '/'
+}
+
+type CreateDirectoryOutput struct {
+	RequestInfo `json:"-"`
+}
+
+type DeleteDirectoryInput struct {
+	Bucket string
+	Key    string // path of the directory, '/' is appended if it doesn't end with '/'
+	// Recursive delete the directory with all objects and directories in it, otherwise only an empty directory
+	// can be deleted
+	Recursive bool
+}
+
+type DeleteDirectoryOutput struct {
+	RequestInfo `json:"-"`
+}
+
+type GetFileStatusInput struct {
+	Bucket string
+	Key    string
+}
+
+// GetFileStatusOutput status of an object or a directory, a directory is returned with Key ending with '/'
+type GetFileStatusOutput struct {
+	RequestInfo  `json:"-"`
+	Key          string    `json:"Key"`
+	Size         int64     `json:"Size"`
+	LastModified time.Time `json:"LastModified"`
+	CRC32        string    `json:"CRC32,omitempty"`
+	CRC64        string    `json:"CRC64,omitempty"`
+}
+
+// ObjectLockConfiguration Object Lock of a bucket, Rule is the default retention of new objects
+type ObjectLockConfiguration struct {
+	ObjectLockEnabled string          `json:"ObjectLockEnabled,omitempty"` // "Enabled", Object Lock can't be disabled once enabled
+	Rule              *ObjectLockRule `json:"Rule,omitempty"`
+}
+
+type ObjectLockRule struct {
+	DefaultRetention DefaultRetention `json:"DefaultRetention"`
+}
+
+// DefaultRetention retention of new objects, one of Days and Years is set
+type DefaultRetention struct {
+	Mode  enum.ObjectLockModeType `json:"Mode,omitempty"`
+	Days  int                     `json:"Days,omitempty"`
+	Years int                     `json:"Years,omitempty"`
+}
+
+// LifecycleRule a lifecycle rule applying to objects with Prefix and all of Tags, at least one of the actions is set,
+// see LifecycleRuleBuilder
+type LifecycleRule struct {
+	ID     string                   `json:"ID,omitempty"`
+	Prefix string                   `json:"Prefix,omitempty"`
+	Status enum.LifecycleStatusType `json:"Status"`
+	Tags   []Tag                    `json:"Tags,omitempty"`
+
+	Expiration                     *LifecycleExpiration            `json:"Expiration,omitempty"`
+	Transitions                    []LifecycleTransition           `json:"Transitions,omitempty"`
+	NoncurrentVersionExpiration    *NoncurrentVersionExpiration    `json:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransitions   []NoncurrentVersionTransition   `json:"NoncurrentVersionTransitions,omitempty"`
+	AbortIncompleteMultipartUpload *AbortIncompleteMultipartUpload `json:"AbortIncompleteMultipartUpload,omitempty"`
+}
+
+// LifecycleExpiration objects expire Days after they're created, or on Date, one of them is set. Date is midnight UTC.
+type LifecycleExpiration struct {
+	Days int       `json:"Days,omitempty"`
+	Date time.Time `json:"Date"`
+}
+
+// LifecycleTransition objects transition to StorageClass Days after they're created, or on Date, one of them is set.
+// Date is midnight UTC.
+type LifecycleTransition struct {
+	Days         int                   `json:"Days,omitempty"`
+	Date         time.Time             `json:"Date"`
+	StorageClass enum.StorageClassType `json:"StorageClass"`
+}
+
+// NoncurrentVersionExpiration noncurrent versions expire NoncurrentDays after they become noncurrent
+type NoncurrentVersionExpiration struct {
+	NoncurrentDays int `json:"NoncurrentDays"`
+}
+
+// NoncurrentVersionTransition noncurrent versions transition to StorageClass NoncurrentDays after they become noncurrent
+type NoncurrentVersionTransition struct {
+	NoncurrentDays int                   `json:"NoncurrentDays"`
+	StorageClass   enum.StorageClassType `json:"StorageClass"`
+}
+
+// AbortIncompleteMultipartUpload multipart uploads are aborted DaysAfterInitiation after they're created
+type AbortIncompleteMultipartUpload struct {
+	DaysAfterInitiation int `json:"DaysAfterInitiation"`
+}
+
+type PutBucketLifecycleV2Input struct {
+	Bucket string
+	Rules  []LifecycleRule
+}
+
+type PutBucketLifecycleV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketLifecycleV2Input struct {
+	Bucket string
+}
+
+type GetBucketLifecycleV2Output struct {
+	RequestInfo `json:"-"`
+	Rules       []LifecycleRule `json:"Rules,omitempty"`
+}
+
+type DeleteBucketLifecycleV2Input struct {
+	Bucket string
+}
+
+type DeleteBucketLifecycleV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+// CORSRule cross-origin requests from AllowedOrigins with AllowedMethods are allowed, an origin can contain at most
+// one wildcard '*'
+type CORSRule struct {
+	AllowedOrigins []string `json:"AllowedOrigins"`
+	AllowedMethods []string `json:"AllowedMethods"` // GET, PUT, POST, DELETE or HEAD
+	AllowedHeaders []string `json:"AllowedHeaders,omitempty"`
+	ExposeHeaders  []string `json:"ExposeHeaders,omitempty"`
+	MaxAgeSeconds  int      `json:"MaxAgeSeconds,omitempty"` // how long browsers cache the preflight response
+}
+
+type PutBucketCORSV2Input struct {
+	Bucket    string
+	CORSRules []CORSRule
+}
+
+type PutBucketCORSV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketCORSV2Input struct {
+	Bucket string
+}
+
+type GetBucketCORSV2Output struct {
+	RequestInfo `json:"-"`
+	CORSRules   []CORSRule `json:"CORSRules,omitempty"`
+}
+
+type DeleteBucketCORSV2Input struct {
+	Bucket string
+}
+
+type DeleteBucketCORSV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+// WebsiteConfiguration static website hosting of a bucket, either RedirectAllRequestsTo or IndexDocument is set
+type WebsiteConfiguration struct {
+	RedirectAllRequestsTo *RedirectAllRequestsTo `json:"RedirectAllRequestsTo,omitempty"`
+	IndexDocument         *IndexDocument         `json:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `json:"ErrorDocument,omitempty"`
+	RoutingRules          []RoutingRule          `json:"RoutingRules,omitempty"`
+}
+
+// RedirectAllRequestsTo redirect all requests to another host
+type RedirectAllRequestsTo struct {
+	HostName string `json:"HostName"`
+	Protocol string `json:"Protocol,omitempty"` // "http" or "https", the protocol of the request by default
+}
+
+type IndexDocument struct {
+	Suffix string `json:"Suffix"` // e.g. "index.html", appended to requests for directories
+	// ForbiddenSubDir return 404 rather than the index document of subdirectories
+	ForbiddenSubDir bool `json:"ForbiddenSubDir,omitempty"`
+}
+
+type ErrorDocument struct {
+	Key string `json:"Key"` // the object returned on 4XX errors
+}
+
+// RoutingRule redirect requests matching Condition
+type RoutingRule struct {
+	Condition RoutingRuleCondition `json:"Condition"`
+	Redirect  RoutingRuleRedirect  `json:"Redirect"`
+}
+
+// RoutingRuleCondition at least one of KeyPrefixEquals and HttpErrorCodeReturnedEquals is set
+type RoutingRuleCondition struct {
+	KeyPrefixEquals             string `json:"KeyPrefixEquals,omitempty"`
+	HttpErrorCodeReturnedEquals int    `json:"HttpErrorCodeReturnedEquals,omitempty"`
+}
+
+// RoutingRuleRedirect at most one of ReplaceKeyPrefixWith and ReplaceKeyWith is set
+type RoutingRuleRedirect struct {
+	Protocol             string `json:"Protocol,omitempty"`
+	HostName             string `json:"HostName,omitempty"`
+	ReplaceKeyPrefixWith string `json:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `json:"ReplaceKeyWith,omitempty"`
+	HttpRedirectCode     int    `json:"HttpRedirectCode,omitempty"` // 301 or 302, 301 by default
+}
+
+type PutBucketWebsiteV2Input struct {
+	Bucket string
+	WebsiteConfiguration
+}
+
+type PutBucketWebsiteV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketWebsiteV2Input struct {
+	Bucket string
+}
+
+type GetBucketWebsiteV2Output struct {
+	RequestInfo `json:"-"`
+	WebsiteConfiguration
+}
+
+type DeleteBucketWebsiteV2Input struct {
+	Bucket string
+}
+
+type DeleteBucketWebsiteV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+// NotificationRule deliver Events of objects matching Filter to Destination
+type NotificationRule struct {
+	RuleID      string                       `json:"RuleId"`
+	Events      []enum.NotificationEventType `json:"Events"`
+	Filter      *NotificationFilter          `json:"Filter,omitempty"`
+	Destination NotificationDestination      `json:"Destination"`
+}
+
+type NotificationFilter struct {
+	TOSKey NotificationFilterKey `json:"TOSKey"`
+}
+
+type NotificationFilterKey struct {
+	FilterRules []NotificationFilterRule `json:"FilterRules"`
+}
+
+// NotificationFilterRule Name is "prefix" or "suffix" of keys
+type NotificationFilterRule struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// NotificationDestination at least one destination is set
+type NotificationDestination struct {
+	RocketMQ []RocketMQDestination `json:"RocketMQ,omitempty"`
+	VeFaaS   []VeFaaSDestination   `json:"VeFaaS,omitempty"`
+}
+
+// RocketMQDestination a topic of RocketMQ, Role is the TRN of the role assumed by TOS to send messages
+type RocketMQDestination struct {
+	Role        string `json:"Role"`
+	InstanceID  string `json:"InstanceId"`
+	Topic       string `json:"Topic"`
+	AccessKeyID string `json:"AccessKeyId"`
+}
+
+// VeFaaSDestination a function of the cloud function service
+type VeFaaSDestination struct {
+	FunctionID string `json:"FunctionId"`
+}
+
+type PutBucketNotificationV2Input struct {
+	Bucket string
+	Rules  []NotificationRule
+}
+
+type PutBucketNotificationV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketNotificationV2Input struct {
+	Bucket string
+}
+
+type GetBucketNotificationV2Output struct {
+	RequestInfo `json:"-"`
+	Rules       []NotificationRule `json:"Rules,omitempty"`
+	Version     string             `json:"Version,omitempty"`
+}
+
+// NotificationMessage the payload of a message delivered to destinations of bucket notification,
+// see ParseNotificationMessage
+type NotificationMessage struct {
+	Events []NotificationEvent
+}
+
+type NotificationEvent struct {
+	EventName    enum.NotificationEventType
+	EventSource  string
+	EventTime    time.Time
+	EventVersion string
+	Region       string
+	RuleID       string
+	RequestID    string // ID of the request which caused the event
+	PrincipalID  string // the user who sent the request
+	SourceIP     string // IP address of the request
+	Bucket       NotificationEventBucket
+	Object       NotificationEventObject
+}
+
+type NotificationEventBucket struct {
+	Name          string `json:"name"`
+	TRN           string `json:"trn"`
+	OwnerIdentity string `json:"ownerIdentify"`
+}
+
+type NotificationEventObject struct {
+	Key       string `json:"key"`
+	ETag      string `json:"eTag"`
+	Size      int64  `json:"size"`
+	VersionID string `json:"versionId"`
+}
+
+// BucketEncryptionRule objects uploaded without encryption headers are encrypted by ApplyServerSideEncryptionByDefault
+type BucketEncryptionRule struct {
+	ApplyServerSideEncryptionByDefault ApplyServerSideEncryptionByDefault `json:"ApplyServerSideEncryptionByDefault"`
+}
+
+type ApplyServerSideEncryptionByDefault struct {
+	SSEAlgorithm enum.SSEAlgorithmType `json:"SSEAlgorithm"`
+	// KMSMasterKeyID optional for SSEAlgorithmKMS, the key managed by TOS in KMS is used if it's empty
+	KMSMasterKeyID string `json:"KMSMasterKeyID,omitempty"`
+}
+
+type PutBucketEncryptionV2Input struct {
+	Bucket string
+	Rule   BucketEncryptionRule
+}
+
+type PutBucketEncryptionV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketEncryptionV2Input struct {
+	Bucket string
+}
+
+type GetBucketEncryptionV2Output struct {
+	RequestInfo `json:"-"`
+	Rule        BucketEncryptionRule `json:"Rule"`
+}
+
+type DeleteBucketEncryptionV2Input struct {
+	Bucket string
+}
+
+type DeleteBucketEncryptionV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type PutBucketTaggingV2Input struct {
+	Bucket string
+	TagSet TagSet
+}
+
+type PutBucketTaggingV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketTaggingV2Input struct {
+	Bucket string
+}
+
+type GetBucketTaggingV2Output struct {
+	RequestInfo `json:"-"`
+	TagSet      TagSet `json:"TagSet"`
+}
+
+type DeleteBucketTaggingV2Input struct {
+	Bucket string
+}
+
+type DeleteBucketTaggingV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+// MirrorBackRule objects matching Condition are fetched from the source in Redirect, e.g. for lazy migration
+type MirrorBackRule struct {
+	ID        string              `json:"ID"`
+	Condition MirrorBackCondition `json:"Condition"`
+	Redirect  MirrorBackRedirect  `json:"Redirect"`
+}
+
+// MirrorBackCondition HttpCode is the status code of the request on TOS, only 404 is supported now
+type MirrorBackCondition struct {
+	HttpCode  int    `json:"HttpCode"`
+	KeyPrefix string `json:"KeyPrefix,omitempty"`
+}
+
+type MirrorBackRedirect struct {
+	RedirectType          enum.MirrorBackRedirectType `json:"RedirectType"`
+	FetchSourceOnRedirect bool                        `json:"FetchSourceOnRedirect"` // fetch the object if the source redirects
+	PassQuery             bool                        `json:"PassQuery"`             // pass the query string to the source
+	FollowRedirect        bool                        `json:"FollowRedirect"`        // follow 3xx responses of the source
+	PublicSource          MirrorBackPublicSource      `json:"PublicSource"`
+}
+
+type MirrorBackPublicSource struct {
+	SourceEndpoint MirrorBackSourceEndpoint `json:"SourceEndpoint"`
+}
+
+// MirrorBackSourceEndpoint Follower endpoints are tried if all Primary endpoints fail
+type MirrorBackSourceEndpoint struct {
+	Primary  []string `json:"Primary"`
+	Follower []string `json:"Follower,omitempty"`
+}
+
+type PutBucketMirrorBackV2Input struct {
+	Bucket string
+	Rules  []MirrorBackRule
+}
+
+type PutBucketMirrorBackV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketMirrorBackV2Input struct {
+	Bucket string
+}
+
+type GetBucketMirrorBackV2Output struct {
+	RequestInfo `json:"-"`
+	Rules       []MirrorBackRule `json:"Rules,omitempty"`
+}
+
+type DeleteBucketMirrorBackV2Input struct {
+	Bucket string
+}
+
+type DeleteBucketMirrorBackV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+// CustomDomainRule a domain bound to a bucket, CertID is the ID of the certificate in certificate center for HTTPS.
+// Cname, Forbidden, ForbiddenReason and CertStatus are returned by ListBucketCustomDomainV2.
+type CustomDomainRule struct {
+	Domain          string                        `json:"Domain"`
+	CertID          string                        `json:"CertId,omitempty"`
+	Protocol        enum.CustomDomainProtocolType `json:"Protocol,omitempty"`
+	Cname           string                        `json:"Cname,omitempty"`
+	Forbidden       bool                          `json:"Forbidden,omitempty"`
+	ForbiddenReason string                        `json:"ForbiddenReason,omitempty"`
+	CertStatus      string                        `json:"CertStatus,omitempty"`
+}
+
+type PutBucketCustomDomainV2Input struct {
+	Bucket string
+	Rule   CustomDomainRule
+}
+
+type PutBucketCustomDomainV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type ListBucketCustomDomainV2Input struct {
+	Bucket string
+}
+
+type ListBucketCustomDomainV2Output struct {
+	RequestInfo `json:"-"`
+	Rules       []CustomDomainRule `json:"CustomDomainRules,omitempty"`
+}
+
+type DeleteBucketCustomDomainV2Input struct {
+	Bucket string
+	Domain string
+}
+
+type DeleteBucketCustomDomainV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+// RealTimeLogConfiguration access logs of a bucket are delivered to TLS (Torch Log Service) by Role, which must be
+// granted to TOS in IAM
+type RealTimeLogConfiguration struct {
+	Role          string                 `json:"Role"`
+	Configuration AccessLogConfiguration `json:"Configuration"`
+}
+
+// AccessLogConfiguration logs are delivered to the topic created by TOS if UseServiceTopic is true, otherwise to
+// the topic TLSTopicID of project TLSProjectID
+type AccessLogConfiguration struct {
+	UseServiceTopic bool   `json:"UseServiceTopic"`
+	TLSProjectID    string `json:"TLSProjectID,omitempty"`
+	TLSTopicID      string `json:"TLSTopicID,omitempty"`
+}
+
+type PutBucketRealTimeLogV2Input struct {
+	Bucket        string
+	Configuration RealTimeLogConfiguration
+}
+
+type PutBucketRealTimeLogV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketRealTimeLogV2Input struct {
+	Bucket string
+}
+
+type GetBucketRealTimeLogV2Output struct {
+	RequestInfo   `json:"-"`
+	Configuration RealTimeLogConfiguration `json:"RealTimeLogConfiguration"`
+}
+
+type DeleteBucketRealTimeLogV2Input struct {
+	Bucket string
+}
+
+type DeleteBucketRealTimeLogV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type PutBucketAccessMonitorV2Input struct {
+	Bucket string
+	Status enum.AccessMonitorStatusType
+}
+
+type PutBucketAccessMonitorV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketAccessMonitorV2Input struct {
+	Bucket string
+}
+
+type GetBucketAccessMonitorV2Output struct {
+	RequestInfo `json:"-"`
+	Status      enum.AccessMonitorStatusType `json:"Status,omitempty"`
+}
+
+// IntelligentTieringTransition objects not accessed for Days move to AccessTier
+type IntelligentTieringTransition struct {
+	Days       int                 `json:"Days"`
+	AccessTier enum.AccessTierType `json:"AccessTier"`
+}
+
+type PutBucketIntelligentTieringV2Input struct {
+	Bucket      string
+	Status      enum.IntelligentTieringStatusType
+	Transitions []IntelligentTieringTransition
+}
+
+type PutBucketIntelligentTieringV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketIntelligentTieringV2Input struct {
+	Bucket string
+}
+
+type GetBucketIntelligentTieringV2Output struct {
+	RequestInfo `json:"-"`
+	Status      enum.IntelligentTieringStatusType `json:"Status,omitempty"`
+	Transitions []IntelligentTieringTransition    `json:"Transitions,omitempty"`
+}
+
+type PutBucketRequestPaymentV2Input struct {
+	Bucket string
+	Payer  enum.PayerType
+}
+
+type PutBucketRequestPaymentV2Output struct {
+	RequestInfo `json:"-"`
+}
+
+type GetBucketRequestPaymentV2Input struct {
+	Bucket string
+}
+
+type GetBucketRequestPaymentV2Output struct {
+	RequestInfo `json:"-"`
+	Payer       enum.PayerType `json:"Payer,omitempty"`
+}
+
+type PutBucketAccelerateConfigurationV2Input struct {
+	Bucket string
+	Status enum.AccelerateStatusType
+}
 
-	CacheControl       string    `location:"header" locationName:"Cache-Control"`
-	ContentDisposition string    `location:"header" locationName:"Content-Disposition"`
-	ContentEncoding    string    `location:"header" locationName:"Content-Encoding"`
-	ContentLanguage    string    `location:"header" locationName:"Content-Language"`
-	ContentType        string    `location:"header" locationName:"Content-Type"`
-	Expires            time.Time `location:"header" locationName:"Expires"`
+type PutBucketAccelerateConfigurationV2Output struct {
+	RequestInfo `json:"-"`
+}
 
-	Meta map[string]string `location:"headers"`
+type GetBucketAccelerateConfigurationV2Input struct {
+	Bucket string
 }
 
-type SetObjectMetaOutput struct {
+type GetBucketAccelerateConfigurationV2Output struct {
 	RequestInfo `json:"-"`
+	Status      enum.AccelerateStatusType `json:"Status,omitempty"` // empty if it's never enabled
 }
 
-type ListObjectsV2Input struct {
-	Bucket string
-	ListObjectsInput
+type BucketAlias struct {
+	Alias        string `json:"Alias"`
+	CreationDate string `json:"CreationDate,omitempty"`
 }
 
-type ListObjectsInput struct {
-	Prefix       string `location:"query" locationName:"prefix"`
-	Delimiter    string `location:"query" locationName:"delimiter"`
-	Marker       string `location:"query" locationName:"marker"`
-	MaxKeys      int    `location:"query" locationName:"max-keys"`
-	Reverse      bool   `location:"query" locationName:"reverse"`
-	EncodingType string `location:"query" locationName:"encoding-type"` // "" or "url"
+type PutBucketAliasV2Input struct {
+	Bucket string
+	Alias  string // another name of Bucket, following the naming rules of buckets
 }
 
-type ListedObject struct {
-	Key           string `json:"Key,omitempty"`
-	LastModified  string `json:"LastModified,omitempty"`
-	ETag          string `json:"ETag,omitempty"`
-	Size          int64  `json:"Size,omitempty"`
-	Owner         Owner  `json:"Owner,omitempty"`
-	StorageClass  string `json:"StorageClass,omitempty"`
-	HashCrc64ecma uint64 `json:"HashCrc64Ecma,omitempty"`
+type PutBucketAliasV2Output struct {
+	RequestInfo `json:"-"`
 }
 
-type ListedCommonPrefix struct {
-	Prefix string `json:"Prefix,omitempty"`
+type ListBucketAliasV2Input struct {
+	Bucket string
 }
 
-type ListObjectsOutput struct {
-	RequestInfo    `json:"-"`
-	Name           string               `json:"Name,omitempty"` // bucket name
-	Prefix         string               `json:"Prefix,omitempty"`
-	Marker         string               `json:"Marker,omitempty"`
-	MaxKeys        int64                `json:"MaxKeys,omitempty"`
-	NextMarker     string               `json:"NextMarker,omitempty"`
-	Delimiter      string               `json:"Delimiter,omitempty"`
-	IsTruncated    bool                 `json:"IsTruncated,omitempty"`
-	EncodingType   string               `json:"EncodingType,omitempty"`
-	CommonPrefixes []ListedCommonPrefix `json:"CommonPrefixes,omitempty"`
-	Contents       []ListedObject       `json:"Contents,omitempty"`
+type ListBucketAliasV2Output struct {
+	RequestInfo `json:"-"`
+	Aliases     []BucketAlias `json:"Aliases,omitempty"`
 }
 
-type ListObjectsV2Output struct {
-	ListObjectsOutput
+type DeleteBucketAliasV2Input struct {
+	Bucket string
+	Alias  string
 }
-type ListObjectVersionsInput struct {
-	Prefix          string `location:"query" locationName:"prefix"`
-	Delimiter       string `location:"query" locationName:"delimiter"`
-	KeyMarker       string `location:"query" locationName:"key-marker"`
-	VersionIDMarker string `location:"query" locationName:"version-id-marker"`
-	MaxKeys         int    `location:"query" locationName:"max-keys"`
-	EncodingType    string `location:"query" locationName:"encoding-type"` // "" or "url"
+
+type DeleteBucketAliasV2Output struct {
+	RequestInfo `json:"-"`
 }
 
-type ListObjectVersionsV2Input struct {
-	Bucket string `json:"Prefix,omitempty"`
-	ListObjectVersionsInput
+type GetBucketStatV2Input struct {
+	Bucket string
 }
 
-type ListedObjectVersion struct {
-	ETag          string `json:"ETag,omitempty"`
-	IsLatest      bool   `json:"IsLatest,omitempty"`
-	Key           string `json:"Key,omitempty"`
-	LastModified  string `json:"LastModified,omitempty"`
-	Owner         Owner  `json:"Owner,omitempty"`
-	Size          int64  `json:"Size,omitempty"`
-	StorageClass  string `json:"StorageClass,omitempty"`
-	VersionID     string `json:"VersionId,omitempty"`
-	HashCrc64ecma uint64 `json:"HashCrc64Ecma,omitempty"`
+// GetBucketStatV2Output statistics of a bucket, sizes are in bytes
+type GetBucketStatV2Output struct {
+	RequestInfo    `json:"-"`
+	StorageSize    int64 `json:"StorageSize"`
+	ObjectCount    int64 `json:"ObjectCount"`
+	LastModifyTime int64 `json:"LastModifyTime"` // unix timestamp in seconds of the statistics
+
+	StandardStorageSize           int64 `json:"StandardStorageSize"`
+	StandardObjectCount           int64 `json:"StandardObjectCount"`
+	IaStorageSize                 int64 `json:"IaStorageSize"`
+	IaObjectCount                 int64 `json:"IaObjectCount"`
+	ArchiveFrStorageSize          int64 `json:"ArchiveFrStorageSize"`
+	ArchiveFrObjectCount          int64 `json:"ArchiveFrObjectCount"`
+	ArchiveStorageSize            int64 `json:"ArchiveStorageSize"`
+	ArchiveObjectCount            int64 `json:"ArchiveObjectCount"`
+	ColdArchiveStorageSize        int64 `json:"ColdArchiveStorageSize"`
+	ColdArchiveObjectCount        int64 `json:"ColdArchiveObjectCount"`
+	IntelligentTieringStorageSize int64 `json:"IntelligentTieringStorageSize"`
+	IntelligentTieringObjectCount int64 `json:"IntelligentTieringObjectCount"`
+}
+
+type PutObjectLockConfigurationInput struct {
+	Bucket string
+	ObjectLockConfiguration
 }
 
-type ListedDeleteMarkerEntry struct {
-	IsLatest     bool   `json:"IsLatest,omitempty"`
-	Key          string `json:"Key,omitempty"`
-	LastModified string `json:"LastModified,omitempty"`
-	Owner        Owner  `json:"Owner,omitempty"`
-	VersionID    string `json:"VersionId,omitempty"`
+type PutObjectLockConfigurationOutput struct {
+	RequestInfo `json:"-"`
 }
 
-type ListedDeleteMarker struct {
-	Key          string
-	LastModified time.Time
-	IsLatest     bool
-	Owner        Owner
-	VersionID    string
+type GetObjectLockConfigurationInput struct {
+	Bucket string
 }
 
-type ListObjectVersionsV2Output struct {
-	RequestInfo         `json:"-"`
-	Name                string                `json:"Name,omitempty"` // bucket name
-	Prefix              string                `json:"Prefix,omitempty"`
-	KeyMarker           string                `json:"KeyMarker,omitempty"`
-	VersionIDMarker     string                `json:"VersionIdMarker,omitempty"`
-	Delimiter           string                `json:"Delimiter,omitempty"`
-	EncodingType        string                `json:"EncodingType,omitempty"`
-	MaxKeys             int64                 `json:"MaxKeys,omitempty"`
-	NextKeyMarker       string                `json:"NextKeyMarker,omitempty"`
-	NextVersionIDMarker string                `json:"NextVersionIdMarker,omitempty"`
-	IsTruncated         bool                  `json:"IsTruncated,omitempty"`
-	CommonPrefixes      []ListedCommonPrefix  `json:"CommonPrefixes,omitempty"`
-	Versions            []ListedObjectVersion `json:"Versions,omitempty"`
-	DeleteMarkers       []ListedDeleteMarker  `json:"DeleteMarkers,omitempty"`
+type GetObjectLockConfigurationOutput struct {
+	RequestInfo `json:"-"`
+	ObjectLockConfiguration
 }
 
-type ListObjectVersionsOutput struct {
-	RequestInfo         `json:"-"`
-	Name                string                    `json:"Name,omitempty"` // bucket name
-	Prefix              string                    `json:"Prefix,omitempty"`
-	KeyMarker           string                    `json:"KeyMarker,omitempty"`
-	VersionIDMarker     string                    `json:"VersionIdMarker,omitempty"`
-	Delimiter           string                    `json:"Delimiter,omitempty"`
-	EncodingType        string                    `json:"EncodingType,omitempty"`
-	MaxKeys             int64                     `json:"MaxKeys,omitempty"`
-	NextKeyMarker       string                    `json:"NextKeyMarker,omitempty"`
-	NextVersionIDMarker string                    `json:"NextVersionIdMarker,omitempty"`
-	IsTruncated         bool                      `json:"IsTruncated,omitempty"`
-	CommonPrefixes      []ListedCommonPrefix      `json:"CommonPrefixes,omitempty"`
-	Versions            []ListedObjectVersion     `json:"Versions,omitempty"`
-	DeleteMarkers       []ListedDeleteMarkerEntry `json:"DeleteMarkers,omitempty"`
+type PutObjectRetentionInput struct {
+	Bucket    string
+	Key       string
+	VersionID string `location:"query" locationName:"versionId"`
+	Mode      enum.ObjectLockModeType
+	// RetainUntilDate the object version can't be overwritten or deleted until then
+	RetainUntilDate time.Time
+	// BypassGovernanceRetention shorten or remove retention in GOVERNANCE mode, which requires special permission
+	BypassGovernanceRetention bool
 }
 
-type GetObjectOutput struct {
-	RequestInfo  `json:"-"`
-	ContentRange string        `json:"ContentRange,omitempty"`
-	Content      io.ReadCloser `json:"-"`
-	ObjectMeta
+type PutObjectRetentionOutput struct {
+	RequestInfo `json:"-"`
 }
 
-type GetObjectV2Input struct {
+type PutObjectLegalHoldInput struct {
 	Bucket    string
 	Key       string
 	VersionID string `location:"query" locationName:"versionId"`
+	Status    enum.LegalHoldStatusType
+}
 
-	IfMatch           string    `location:"header" locationName:"If-Match"`
-	IfModifiedSince   time.Time `location:"header" locationName:"If-Modified-Since"`
-	IfNoneMatch       string    `location:"header" locationName:"If-None-Match"`
-	IfUnmodifiedSince time.Time `location:"header" locationName:"If-Unmodified-Since"`
+type PutObjectLegalHoldOutput struct {
+	RequestInfo `json:"-"`
+}
 
-	SSECAlgorithm string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Algorithm"`
-	SSECKey       string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
-	SSECKeyMD5    string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
+type PutSymlinkV2Input struct {
+	Bucket string
+	Key    string // the symlink
+	// SymlinkTargetKey the object the symlink points to, it's not required to exist
+	SymlinkTargetKey string
+	// SymlinkTargetBucket the bucket of SymlinkTargetKey, empty means Bucket
+	SymlinkTargetBucket string `location:"header" locationName:"X-Tos-Symlink-Bucket"`
+	// ForbidOverwrite fail with 409 if Key exists, instead of overwriting it
+	ForbidOverwrite bool                  `location:"header" locationName:"X-Tos-Forbid-Overwrite"`
+	ACL             enum.ACLType          `location:"header" locationName:"X-Tos-Acl"`
+	StorageClass    enum.StorageClassType `location:"header" locationName:"X-Tos-Storage-Class"`
+	Meta            map[string]string     `location:"headers"`
+}
+
+type PutSymlinkV2Output struct {
+	RequestInfo `json:"-"`
+	VersionID   string
+}
 
-	ResponseCacheControl       string    `location:"query" locationName:"Cache-Control"`
-	ResponseContentDisposition string    `location:"query" locationName:"Content-Disposition"`
-	ResponseContentEncoding    string    `location:"query" locationName:"Content-Encoding"`
-	ResponseContentLanguage    string    `location:"query" locationName:"Content-Language"`
-	ResponseContentType        string    `location:"query" locationName:"Content-Type"`
-	ResponseExpires            time.Time `location:"query" locationName:"Expires"`
+type GetSymlinkV2Input struct {
+	Bucket    string
+	Key       string
+	VersionID string `location:"query" locationName:"versionId"`
+}
 
-	RangeStart int64
-	RangeEnd   int64
+type GetSymlinkV2Output struct {
+	RequestInfo         `json:"-"`
+	VersionID           string
+	SymlinkTargetKey    string
+	SymlinkTargetBucket string // empty if the target is in the same bucket
+	ETag                string
+	LastModified        time.Time
+}
 
-	DataTransferListener DataTransferListener
-	RateLimiter          RateLimiter
+type FetchObjectV2Input struct {
+	Bucket string
+	Key    string
+	// URL the source to fetch, it must be accessible from the server
+	URL string
+	// IgnoreSameKey fail instead of overwriting if Key exists
+	IgnoreSameKey bool
+	// ContentMD5 optional, hex-encoded md5 of the source, the fetch fails if it mismatches
+	ContentMD5 string
+
+	ACL              enum.ACLType `location:"header" locationName:"X-Tos-Acl"`
+	GrantFullControl string       `location:"header" locationName:"X-Tos-Grant-Full-Control"`
+	GrantRead        string       `location:"header" locationName:"X-Tos-Grant-Read"`
+	GrantReadAcp     string       `location:"header" locationName:"X-Tos-Grant-Read-Acp"`
+	GrantWriteAcp    string       `location:"header" locationName:"X-Tos-Grant-Write-Acp"`
+
+	StorageClass  enum.StorageClassType `location:"header" locationName:"X-Tos-Storage-Class"`
+	SSECAlgorithm string                `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Algorithm"`
+	SSECKey       string                `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
+	SSECKeyMD5    string                `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
+	Meta          map[string]string     `location:"headers"`
+}
+
+type FetchObjectV2Output struct {
+	RequestInfo   `json:"-"`
+	VersionID     string `json:"-"`
+	ETag          string `json:"ETag,omitempty"`
+	SSECAlgorithm string `json:"-"`
+	SSECKeyMD5    string `json:"-"`
 }
 
-type GetObjectBasicOutput struct {
-	RequestInfo
+type PutFetchTaskV2Input struct {
+	Bucket string
+	Key    string
+	// URL the source to fetch, it must be accessible from the server
+	URL string
+	// IgnoreSameKey fail instead of overwriting if Key exists
+	IgnoreSameKey bool
+	// ContentMD5 optional, hex-encoded md5 of the source, the task fails if it mismatches
+	ContentMD5 string
+}
 
-	ContentRange string
-	ObjectMetaV2
+type PutFetchTaskV2Output struct {
+	RequestInfo `json:"-"`
+	TaskID      string `json:"TaskId,omitempty"`
 }
 
-type GetObjectV2Output struct {
-	GetObjectBasicOutput
-	Content io.ReadCloser
+type GetFetchTaskV2Input struct {
+	Bucket string
+	TaskID string `location:"query" locationName:"taskId"`
 }
 
-type GetObjectToFileInput struct {
-	GetObjectV2Input
-	FilePath string
+type GetFetchTaskV2Output struct {
+	RequestInfo `json:"-"`
+	State       enum.FetchTaskStateType `json:"State,omitempty"`
+	// Cause why the task failed, set if State is enum.FetchTaskStateFailed
+	Cause string `json:"Cause,omitempty"`
 }
 
-type GetObjectToFileOutput struct {
-	GetObjectBasicOutput
+// Finished return true if the task will not change any more, i.e. it's not running
+func (o *GetFetchTaskV2Output) Finished() bool {
+	return o.State != enum.FetchTaskStateRunning
 }
 
-type HeadObjectV2Input struct {
+type SelectObjectContentInput struct {
 	Bucket    string
 	Key       string
 	VersionID string `location:"query" locationName:"versionId"`
-
-	IfMatch           string    `location:"header" locationName:"If-Match"`
-	IfModifiedSince   time.Time `location:"header" locationName:"If-Modified-Since"`
-	IfNoneMatch       string    `location:"header" locationName:"If-None-Match"`
-	IfUnmodifiedSince time.Time `location:"header" locationName:"If-Unmodified-Since"`
+	// Expression the SQL expression, e.g. "select * from tosobject where age > 18"
+	Expression string
+	// RequestProgress send SelectEventProgress events periodically, so that a long query doesn't look hung
+	RequestProgress     bool
+	InputSerialization  SelectInputSerialization
+	OutputSerialization SelectOutputSerialization
+	// SkipPartialDataRecord skip records lacking columns referenced by Expression, instead of taking them as null
+	SkipPartialDataRecord bool
+	// MaxSkippedRecordsAllowed the query fails once more records than it are skipped, 0 means no record can be skipped
+	MaxSkippedRecordsAllowed int64
 
 	SSECAlgorithm string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Algorithm"`
 	SSECKey       string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
 	SSECKeyMD5    string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
 }
 
-type HeadObjectOutput struct {
-	RequestInfo  `json:"-"`
-	ContentRange string `json:"ContentRange,omitempty"`
-	ObjectMeta
+// SelectInputSerialization the format of the object, exactly one of CSV, JSON and Parquet must be set
+type SelectInputSerialization struct {
+	CompressionType enum.CompressionType `json:"CompressionType,omitempty"`
+	CSV             *CSVInput            `json:"CSV,omitempty"`
+	JSON            *JSONInput           `json:"JSON,omitempty"`
+	Parquet         *ParquetInput        `json:"Parquet,omitempty"`
 }
 
-type HeadObjectV2Output struct {
-	RequestInfo `json:"-"`
-	ObjectMetaV2
+type CSVInput struct {
+	FileHeaderInfo             enum.FileHeaderInfoType `json:"FileHeaderInfo,omitempty"`
+	RecordDelimiter            string                  `json:"RecordDelimiter,omitempty"` // "\n" by default
+	FieldDelimiter             string                  `json:"FieldDelimiter,omitempty"`  // "," by default
+	QuoteCharacter             string                  `json:"QuoteCharacter,omitempty"`  // "\"" by default
+	CommentCharacter           string                  `json:"CommentCharacter,omitempty"`
+	AllowQuotedRecordDelimiter bool                    `json:"AllowQuotedRecordDelimiter,omitempty"`
+}
+
+type JSONInput struct {
+	Type enum.SelectJSONType `json:"Type,omitempty"`
+}
+
+type ParquetInput struct{}
+
+// SelectOutputSerialization the format of result records, exactly one of CSV and JSON must be set
+type SelectOutputSerialization struct {
+	CSV  *CSVOutput  `json:"CSV,omitempty"`
+	JSON *JSONOutput `json:"JSON,omitempty"`
+	// EnablePayloadCrc ask the server to checksum each event, which is verified by the SDK
+	EnablePayloadCrc bool `json:"EnablePayloadCrc,omitempty"`
+}
+
+type CSVOutput struct {
+	RecordDelimiter string `json:"RecordDelimiter,omitempty"`
+	FieldDelimiter  string `json:"FieldDelimiter,omitempty"`
+	QuoteCharacter  string `json:"QuoteCharacter,omitempty"`
+}
+
+type JSONOutput struct {
+	RecordDelimiter string `json:"RecordDelimiter,omitempty"`
+}
+
+// SelectEvent an event of the response of SelectObjectContent
+type SelectEvent struct {
+	Type enum.SelectEventType
+	// Records result records of SelectEventRecords
+	Records []byte
+	// BytesScanned bytes of the object scanned so far
+	BytesScanned int64
+}
+
+// SelectObjectContentOutput the streamed result of SelectObjectContent, read records by Read, or all events by
+// NextEvent, but not both. It must be closed.
+type SelectObjectContentOutput struct {
+	RequestInfo
+	events *selectEventReader
 }
 
 type DeleteObjectV2Input struct {
-	Bucket    string
-	Key       string
-	VersionID string `location:"query" locationName:"versionId"`
+	Bucket       string
+	Key          string
+	VersionID    string `location:"query" locationName:"versionId"`
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
 }
 
 type DeleteObjectOutput struct {
@@ -559,6 +1740,8 @@ type DeleteMultiObjectsInput struct {
 	Bucket  string
 	Objects []ObjectTobeDeleted `json:"Objects,omitempty"`
 	Quiet   bool                `json:"Quiet,omitempty"`
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
 }
 
 type Deleted struct {
@@ -586,7 +1769,7 @@ type CopyObjectInput struct {
 	Key                string
 	SrcBucket          string
 	SrcKey             string
-	SrcVersionID       string
+	SrcVersionID       string       `location:"query" locationName:"versionId"`
 	CacheControl       string       `location:"header" locationName:"Cache-Control"`
 	ContentDisposition string       `location:"header" locationName:"Content-Disposition" encodeChinese:"true"`
 	ContentEncoding    string       `location:"header" locationName:"Content-Encoding"`
@@ -608,13 +1791,28 @@ type CopyObjectInput struct {
 	CopySourceIfNoneMatch       string    `location:"header" locationName:"X-Tos-Copy-Source-If-None-Match"`
 	CopySourceIfUnmodifiedSince time.Time `location:"header" locationName:"X-Tos-Copy-Source-If-Unmodified-Since"`
 
-	CopySourceSSECAlgorithm string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Algorithm"`
-	CopySourceSSECKey       string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
-	CopySourceSSECKeyMD5    string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
-	ServerSideEncryption    string `location:"header" locationName:"X-Tos-Server-Side-Encryption"`
+	// CopySourceSSEC* the SSE-C key of source object
+	CopySourceSSECAlgorithm string `location:"header" locationName:"X-Tos-Copy-Source-Server-Side-Encryption-Customer-Algorithm"`
+	CopySourceSSECKey       string `location:"header" locationName:"X-Tos-Copy-Source-Server-Side-Encryption-Customer-Key"`
+	CopySourceSSECKeyMD5    string `location:"header" locationName:"X-Tos-Copy-Source-Server-Side-Encryption-Customer-Key-MD5"`
+	// SSEC* the SSE-C key to encrypt the destination object
+	SSECAlgorithm        string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Algorithm"`
+	SSECKey              string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
+	SSECKeyMD5           string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
+	ServerSideEncryption string `location:"header" locationName:"X-Tos-Server-Side-Encryption"`
+
+	// TaggingDirective copy tags of source object by default, or replace them with Tagging, e.g. "k1=v1&k2=v2"
+	TaggingDirective enum.TaggingDirectiveType `location:"header" locationName:"X-Tos-Tagging-Directive"`
+	Tagging          string                    `location:"header" locationName:"X-Tos-Tagging"`
 
+	// MetadataDirective copy metadata of source object by default, or replace them with metadata of input,
+	// including Content-* headers, Expires and Meta
 	MetadataDirective enum.MetadataDirectiveType `location:"header" locationName:"X-Tos-Metadata-Directive"`
+	RequestPayer      string                     `location:"header" locationName:"X-Tos-Request-Payer"`
 	Meta              map[string]string          `location:"headers"`
+	// TagSet optional, tags of the destination object, which is encoded as Tagging, only one of them can be set.
+	// TaggingDirective is REPLACE if it's not set.
+	TagSet *TagSet
 }
 
 type CopyObjectOutput struct {
@@ -662,9 +1860,10 @@ type UploadPartCopyV2Input struct {
 	CopySourceIfNoneMatch       string    `location:"header" locationName:"X-Tos-Copy-Source-If-None-Match"`
 	CopySourceIfUnmodifiedSince time.Time `location:"header" locationName:"X-Tos-Copy-Source-If-Unmodified-Since"`
 
-	CopySourceSSECAlgorithm string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Algorithm"`
-	CopySourceSSECKey       string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
-	CopySourceSSECKeyMD5    string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
+	CopySourceSSECAlgorithm string `location:"header" locationName:"X-Tos-Copy-Source-Server-Side-Encryption-Customer-Algorithm"`
+	CopySourceSSECKey       string `location:"header" locationName:"X-Tos-Copy-Source-Server-Side-Encryption-Customer-Key"`
+	CopySourceSSECKeyMD5    string `location:"header" locationName:"X-Tos-Copy-Source-Server-Side-Encryption-Customer-Key-MD5"`
+	RequestPayer            string `location:"header" locationName:"X-Tos-Request-Payer"`
 }
 
 type UploadPartCopyV2Output struct {
@@ -698,7 +1897,11 @@ type CreateMultipartUploadV2Input struct {
 	SSECKey                 string                `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
 	SSECKeyMD5              string                `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
 	ServerSideEncryption    string                `location:"header" locationName:"X-Tos-Server-Side-Encryption"`
+	Tagging                 string                `location:"header" locationName:"X-Tos-Tagging"` // e.g. "k1=v1&k2=v2"
+	RequestPayer            string                `location:"header" locationName:"X-Tos-Request-Payer"`
 	Meta                    map[string]string     `location:"headers"`
+	// TagSet optional, tags of the object, which is encoded as Tagging, only one of them can be set
+	TagSet *TagSet
 }
 
 type CreateMultipartUploadOutput struct {
@@ -751,6 +1954,10 @@ type UploadPartBasicInput struct {
 	SSECKey              string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key"`
 	SSECKeyMD5           string `location:"header" locationName:"X-Tos-Server-Side-Encryption-Customer-Key-MD5"`
 	ServerSideEncryption string `location:"header" locationName:"X-Tos-Server-Side-Encryption"`
+	// TrafficLimit optional, bandwidth limit of the request enforced by the server, in bit/s
+	TrafficLimit int64 `location:"header" locationName:"X-Tos-Traffic-Limit"`
+	// RequestPayer optional, "requester" to access a requester-pays bucket, see PutBucketRequestPaymentV2
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
 
 	DataTransferListener DataTransferListener
 	RateLimiter          RateLimiter
@@ -760,6 +1967,10 @@ type UploadPartV2Input struct {
 	UploadPartBasicInput
 	Content       io.Reader
 	ContentLength int64 `location:"header" locationName:"Content-Length"`
+	// ContentProvider optional, see PutObjectV2Input.ContentProvider
+	ContentProvider ContentProvider
+
+	object *TransferObject // nullable, the whole object when the part is uploaded by UploadFile
 }
 
 type UploadPartV2Output struct {
@@ -825,10 +2036,18 @@ type CompleteMultipartUploadOutput struct {
 }
 
 type CompleteMultipartUploadV2Input struct {
-	Bucket   string
-	Key      string
-	UploadID string `location:"query" locationName:"uploadId"`
-	Parts    []UploadedPartV2
+	Bucket       string
+	Key          string
+	UploadID     string `location:"query" locationName:"uploadId"`
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
+	Parts        []UploadedPartV2
+	// ForbidOverwrite complete the upload only if Key doesn't exist, or fail with code ObjectAlreadyExists,
+	// see IsObjectAlreadyExists
+	ForbidOverwrite bool
+	// Callback optional, the application server called after the object is created, see Callback
+	Callback *Callback
+	// CallbackVar optional, custom variables referenced by Callback.Body, keys must start with "x:"
+	CallbackVar map[string]string
 }
 
 type CompleteMultipartUploadV2Output struct {
@@ -839,13 +2058,17 @@ type CompleteMultipartUploadV2Output struct {
 	Location      string
 	VersionID     string
 	HashCrc64ecma uint64
+	// CallbackResult response body of the application server, set only if Callback of input is set,
+	// Bucket, Key and Location are not set in this case
+	CallbackResult string `json:"-"`
 }
 
 type AbortMultipartUploadInput struct {
 	// Bucket is needed in V2 api
-	Bucket   string
-	Key      string
-	UploadID string `location:"query" locationName:"uploadId"`
+	Bucket       string
+	Key          string
+	UploadID     string `location:"query" locationName:"uploadId"`
+	RequestPayer string `location:"header" locationName:"X-Tos-Request-Payer"`
 }
 
 type AbortMultipartUploadOutput struct {
@@ -889,12 +2112,13 @@ type ListMultipartUploadsOutput struct {
 
 type ListMultipartUploadsV2Input struct {
 	Bucket         string
-	Prefix         string `location:"query" locationName:"uploads"`
+	Prefix         string `location:"query" locationName:"prefix"`
 	Delimiter      string `location:"query" locationName:"delimiter"`
 	KeyMarker      string `location:"query" locationName:"key-marker"`
 	UploadIDMarker string `location:"query" locationName:"upload-id-marker"`
 	MaxUploads     int    `location:"query" locationName:"max-uploads"`
 	EncodingType   string `location:"query" locationName:"encoding-type"` // "" or "url"
+	RequestPayer   string `location:"header" locationName:"X-Tos-Request-Payer"`
 }
 
 type ListedUpload struct {
@@ -949,6 +2173,7 @@ type ListPartsInput struct {
 	PartNumberMarker int    `location:"query" locationName:"part-number-marker"`
 	MaxParts         int    `location:"query" locationName:"max-parts"`
 	EncodingType     string `location:"query" locationName:"encoding-type"` // "" or "url"
+	RequestPayer     string `location:"header" locationName:"X-Tos-Request-Payer"`
 }
 
 type ListPartsOutput struct {
@@ -986,6 +2211,32 @@ type DownloadFileInput struct {
 	DataTransferListener  DataTransferListener
 	RateLimiter           RateLimiter
 	CancelHook            CancelHook // user can not set this filed
+	// ObjectInfo optional, known info of the object to skip the initial HeadObjectV2, e.g. from a manifest or a
+	// previous listing. It's validated lazily by If-Match of ranged GETs, and DownloadFileOutput is built from it.
+	ObjectInfo *DownloadObjectInfo
+	// StrictConsistency fail fast when the object is changed during downloading.
+	// Ranged GETs of parts always send If-Match with the ETag observed at start, a part failed with 412 aborts
+	// the download if it's set, the temp file and checkpoint file are removed, and the error is TosClientError
+	// caused by the 412 TosServerError. Otherwise the part is reported as failed like other errors.
+	StrictConsistency bool
+}
+
+// DownloadObjectInfo known info of the object to download, e.g. from a manifest or a previous listing
+type DownloadObjectInfo struct {
+	ContentLength int64
+	ETag          string // required, sent by If-Match of ranged GETs
+	HashCrc64ecma uint64 // 0 means unknown, then CRC of the downloaded file is not checked
+}
+
+func (info *DownloadObjectInfo) headOutput() *HeadObjectV2Output {
+	if info == nil {
+		return nil
+	}
+	return &HeadObjectV2Output{ObjectMetaV2: ObjectMetaV2{
+		ContentLength: info.ContentLength,
+		ETag:          info.ETag,
+		HashCrc64ecma: info.HashCrc64ecma,
+	}}
 }
 
 func (d *DownloadFileInput) withCancelHook(hook CancelHook) {
@@ -1031,8 +2282,23 @@ type UploadFileInput struct {
 	DataTransferListener DataTransferListener
 	UploadEventListener  UploadEventListener
 	RateLimiter          RateLimiter
+	// TrafficLimit optional, bandwidth limit of each UploadPart request enforced by the server, in bit/s
+	TrafficLimit int64
 	// cancelHook 支持取消断点续传任务
 	CancelHook CancelHook
+	// HeartbeatInterval interval of UploadEventHeartbeat events, 0 means no heartbeat
+	HeartbeatInterval time.Duration
+	// PartRetryPolicy how failed parts are handled, failed parts are not rescheduled by default
+	PartRetryPolicy PartRetryPolicy
+}
+
+// PartRetryPolicy handles parts failed after retries of their requests are exhausted
+type PartRetryPolicy struct {
+	// MaxReschedules the max times a failed part is rescheduled, 0 means never
+	MaxReschedules int
+	// FailureThreshold stop the transfer once parts failed this many times in total, 0 means no limit.
+	// The multipart upload and checkpoint are kept, so the transfer can be resumed later.
+	FailureThreshold int
 }
 
 func NewUploadCancelHook() CancelHook {
@@ -1060,12 +2326,54 @@ type UploadEvent struct {
 	CheckpointFile *string // 断点续传文件全路径
 	// upload part 相关事件发生时有值
 	UploadPartInfo *UploadPartInfo
+	// not empty when heartbeat event occurs
+	Heartbeat *TransferHeartbeat
+}
+
+// TransferHeartbeat is sent periodically by UploadFile, even if no bytes move,
+// so that a supervisor can detect and restart a hung transfer
+type TransferHeartbeat struct {
+	Elapsed       time.Duration // since the transfer started
+	ConsumedBytes int64         // bytes transferred since the transfer started
+	InflightParts []PartStall   // parts being transferred
+}
+
+// PartStall is the state of a part being transferred
+type PartStall struct {
+	PartNumber int
+	Elapsed    time.Duration // since the part started
+	Stalled    time.Duration // since bytes of the part last moved
 }
 
 type UploadEventListener interface {
 	EventChange(event *UploadEvent)
 }
 
+type ExportUploadCheckpointInput struct {
+	Bucket         string
+	Key            string
+	FilePath       string
+	CheckpointFile string // the same as UploadFileInput.CheckpointFile
+}
+
+type ExportUploadCheckpointOutput struct {
+	UploadID   string
+	Checkpoint []byte // portable checkpoint, pass it to ImportUploadCheckpoint
+}
+
+type ImportUploadCheckpointInput struct {
+	Bucket         string
+	Key            string
+	FilePath       string // the local copy of file to upload
+	CheckpointFile string // where to write the checkpoint, the same as UploadFileInput.CheckpointFile
+	Checkpoint     []byte // exported by ExportUploadCheckpoint
+}
+
+type ImportUploadCheckpointOutput struct {
+	UploadID       string
+	CheckpointFile string // the checkpoint file written
+}
+
 type UploadFileOutput struct {
 	RequestInfo
 	Bucket        string
@@ -1080,11 +2388,62 @@ type UploadFileOutput struct {
 	EncodingType  string
 }
 
+// CopyFileInput copy SrcBucket/SrcKey to Bucket/Key by UploadPartCopy, metadata of source object is not copied,
+// set them in CreateMultipartUploadV2Input
+type CopyFileInput struct {
+	CreateMultipartUploadV2Input
+
+	SrcBucket    string
+	SrcKey       string
+	SrcVersionID string
+	// CopySourceSSEC* the SSE-C key of source object
+	CopySourceSSECAlgorithm string
+	CopySourceSSECKey       string
+	CopySourceSSECKeyMD5    string
+
+	PartSize         int64
+	TaskNum          int
+	EnableCheckpoint bool
+	// CheckpointFile defaults to a file in ClientV2 CheckpointDir or the temp dir, named after source and destination
+	CheckpointFile string
+	CancelHook     CancelHook
+}
+
+type CopyFileOutput struct {
+	RequestInfo
+	Bucket          string
+	Key             string
+	UploadID        string
+	ETag            string
+	Location        string
+	VersionID       string
+	SourceVersionID string
+	HashCrc64ecma   uint64
+}
+
 type DataTransferStatus struct {
 	TotalBytes    int64
 	ConsumedBytes int64 // bytes read/written
 	RWOnceBytes   int64 // bytes read/written this time
 	Type          enum.DataTransferType
+	// Speed bytes per second over the last second, set in status of DataTransferRW and DataTransferSucceed
+	Speed float64
+	// AverageSpeed bytes per second since the transfer started, set in status of DataTransferRW and DataTransferSucceed
+	AverageSpeed float64
+	// ETA estimated time remaining at Speed, or at AverageSpeed if nothing is transferred in the last second,
+	// -1 if it's unknown, e.g. TotalBytes is unknown. Set in status of DataTransferRW and DataTransferSucceed
+	ETA time.Duration
+	// Object the object being transferred, only set in status of DataTransferStarted, nil if unknown
+	Object *TransferObject
+}
+
+// TransferObject the object being transferred, passed to DataTransferListener and ObjectRateLimiter at transfer start,
+// so implementations can treat e.g. huge archive objects and small hot objects differently
+type TransferObject struct {
+	Bucket       string
+	Key          string
+	Size         int64                 // size of the whole object, -1 if unknown, e.g. when uploading a part by UploadPartV2
+	StorageClass enum.StorageClassType // empty if unknown or the default one
 }
 
 type DataTransferListener interface {
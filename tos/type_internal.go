@@ -3,10 +3,17 @@ package tos
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"hash"
 	"io"
 	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -44,8 +51,9 @@ type deleteMultiObjectsInput struct {
 
 // only for Marshal
 type accessControlList struct {
-	Owner  Owner
-	Grants []Grant
+	Owner                Owner
+	Grants               []Grant
+	BucketOwnerEntrusted bool `json:"BucketOwnerEntrusted,omitempty"`
 }
 
 type canceler struct {
@@ -100,6 +108,7 @@ type downloadPartInfo struct {
 
 type downloadCheckpoint struct {
 	checkpointPath string // this filed should not be marshaled
+	Version        int    `json:"Version"`
 	Bucket         string `json:"Bucket,omitempty"`
 	Key            string `json:"Key,omitempty"`
 	VersionID      string `json:"VersionID,omitempty"`
@@ -118,6 +127,7 @@ type downloadCheckpoint struct {
 }
 
 func (c *downloadCheckpoint) WriteToFile() error {
+	c.Version = checkpointVersion
 	buffer, err := json.Marshal(c)
 	if err != nil {
 		return newTosClientError(err.Error(), err)
@@ -169,6 +179,7 @@ type uploadPartInfo struct {
 
 type uploadCheckpoint struct {
 	checkpointPath string           // this filed should not be marshaled
+	Version        int              `json:"Version"`
 	Bucket         string           `json:"Bucket,omitempty"`
 	Key            string           `json:"Key,omitempty"`
 	UploadID       string           `json:"UploadID,omitempty"`
@@ -207,6 +218,7 @@ func (u *uploadCheckpoint) UpdatePartsInfo(part uploadPartInfo) {
 }
 
 func (u *uploadCheckpoint) WriteToFile() error {
+	u.Version = checkpointVersion
 	result, err := json.Marshal(u)
 	if err != nil {
 		return newTosClientError(err.Error(), err)
@@ -218,6 +230,77 @@ func (u *uploadCheckpoint) WriteToFile() error {
 	return nil
 }
 
+// copyPartInfo is for checkpoint
+type copyPartInfo struct {
+	PartNumber  int    `json:"PartNumber"`
+	RangeStart  int64  `json:"RangeStart"`
+	RangeEnd    int64  `json:"RangeEnd"`
+	ETag        string `json:"ETag,omitempty"`
+	IsCompleted bool   `json:"IsCompleted"`
+}
+
+type copyCheckpoint struct {
+	checkpointPath string // this filed should not be marshaled
+	Version        int    `json:"Version"`
+	Bucket         string `json:"Bucket,omitempty"`
+	Key            string `json:"Key,omitempty"`
+	UploadID       string `json:"UploadID,omitempty"`
+	PartSize       int64  `json:"PartSize"`
+	SrcBucket      string `json:"SrcBucket,omitempty"`
+	SrcKey         string `json:"SrcKey,omitempty"`
+	SrcVersionID   string `json:"SrcVersionID,omitempty"`
+	// SourceInfo the source object the parts are copied from, its VersionID is set even if SrcVersionID is empty
+	SourceInfo copyObjectInfo `json:"SourceInfo"`
+	PartsInfo  []copyPartInfo `json:"PartsInfo,omitempty"`
+}
+
+type copyObjectInfo struct {
+	VersionID     string    `json:"VersionID,omitempty"`
+	Etag          string    `json:"Etag,omitempty"`
+	HashCrc64ecma uint64    `json:"HashCrc64Ecma,omitempty"`
+	LastModified  time.Time `json:"LastModified,omitempty"`
+	ObjectSize    int64     `json:"ObjectSize"`
+}
+
+// Valid report whether the checkpoint is of the same copy, and the source object is not changed
+func (c *copyCheckpoint) Valid(input *CopyFileInput, head *HeadObjectV2Output) bool {
+	if c.UploadID == "" || c.Bucket != input.Bucket || c.Key != input.Key || c.PartSize != input.PartSize ||
+		c.SrcBucket != input.SrcBucket || c.SrcKey != input.SrcKey || c.SrcVersionID != input.SrcVersionID {
+		return false
+	}
+	return c.SourceInfo.VersionID == head.VersionID && c.SourceInfo.Etag == head.ETag &&
+		c.SourceInfo.HashCrc64ecma == head.HashCrc64ecma && c.SourceInfo.LastModified.Equal(head.LastModified) &&
+		c.SourceInfo.ObjectSize == head.ContentLength
+}
+
+func (c *copyCheckpoint) GetParts() []UploadedPartV2 {
+	parts := make([]UploadedPartV2, 0, len(c.PartsInfo))
+	for _, p := range c.PartsInfo {
+		parts = append(parts, UploadedPartV2{
+			PartNumber: p.PartNumber,
+			ETag:       p.ETag,
+		})
+	}
+	return parts
+}
+
+func (c *copyCheckpoint) UpdatePartsInfo(part copyPartInfo) {
+	c.PartsInfo[part.PartNumber-1] = part
+}
+
+func (c *copyCheckpoint) WriteToFile() error {
+	c.Version = checkpointVersion
+	result, err := json.Marshal(c)
+	if err != nil {
+		return newTosClientError(err.Error(), err)
+	}
+	err = ioutil.WriteFile(c.checkpointPath, result, 0666)
+	if err != nil {
+		return newTosClientError(err.Error(), err)
+	}
+	return nil
+}
+
 /*
    taskManager basic usage
    manager:=newTaskManager(n)
@@ -233,93 +316,110 @@ type task interface {
 	getBaseInput() interface{}
 }
 
-//type downloadTask struct {
-//	cli        *ClientV2
-//	ctx        context.Context
-//	input      *DownloadFileInput
-//	consumed   *int64
-//	total      int64
-//	mutex      *sync.Mutex
-//	PartNumber int
-//	RangeStart int64
-//	RangeEnd   int64
-//}
-//
-//// Do the downloadTask, and return downloadPartInfo
-//func (t *downloadTask) do() (result interface{}, err error) {
-//	input := t.getBaseInput().(GetObjectV2Input)
-//	output, err := t.cli.GetObjectV2(t.ctx, &input)
-//	if err != nil {
-//		return nil, err
-//	}
-//	file, err := os.OpenFile(t.input.tempFile, os.O_RDWR, 0)
-//	if err != nil {
-//		return nil, err
-//	}
-//	defer func(file *os.File) {
-//		_ = file.Close()
-//	}(file)
-//	var wrapped = output.Content
-//	if t.input.DataTransferListener != nil {
-//		wrapped = &parallelReadCloserWithListener{
-//			listener: t.input.DataTransferListener,
-//			base:     wrapped,
-//			consumed: t.consumed,
-//			total:    t.total,
-//			m:        t.mutex,
-//		}
-//	}
-//	if t.input.RateLimiter != nil {
-//		wrapped = &ReadCloserWithLimiter{
-//			limiter: t.input.RateLimiter,
-//			base:    wrapped,
-//		}
-//	}
-//	_, err = file.Seek(t.RangeStart, io.SeekStart)
-//	if err != nil {
-//		return nil, err
-//	}
-//	written, err := io.Copy(file, wrapped)
-//	if err != nil {
-//		return nil, err
-//	}
-//	if written != (t.RangeEnd - t.RangeStart + 1) {
-//		return nil, err
-//	}
-//	return downloadPartInfo{
-//		PartNumber:    t.PartNumber,
-//		RangeStart:    t.RangeStart,
-//		RangeEnd:      t.RangeEnd,
-//		HashCrc64ecma: output.HashCrc64ecma,
-//		IsCompleted:   true,
-//	}, nil
-//}
-//
-//func (t *downloadTask) getBaseInput() interface{} {
-//	return GetObjectV2Input{
-//		Bucket:            t.input.Bucket,
-//		Key:               t.input.Key,
-//		VersionID:         t.input.VersionID,
-//		IfMatch:           t.input.IfMatch,
-//		IfModifiedSince:   t.input.IfModifiedSince,
-//		IfNoneMatch:       t.input.IfNoneMatch,
-//		IfUnmodifiedSince: t.input.IfUnmodifiedSince,
-//		SSECAlgorithm:     t.input.SSECAlgorithm,
-//		SSECKey:           t.input.SSECKey,
-//		SSECKeyMD5:        t.input.SSECKeyMD5,
-//		RangeStart:        t.RangeStart,
-//		RangeEnd:          t.RangeEnd,
-//		// we want to Sent parallel Listener on output, so explicitly set listener of GetObjectV2Input nil here.
-//		DataTransferListener: nil,
-//		RateLimiter:          nil,
-//	}
-//}
+type downloadTask struct {
+	cli        *ClientV2
+	ctx        context.Context
+	input      *DownloadFileInput
+	consumed   *int64
+	subtotal   *int64
+	rate       *transferRate
+	total      int64
+	PartNumber int
+	RangeStart int64
+	RangeEnd   int64
+	etag       string // ETag from HEAD or DownloadFileInput.ObjectInfo
+}
+
+// Do the downloadTask, and return downloadPartInfo
+func (t *downloadTask) do() (interface{}, error) {
+	input := t.getBaseInput().(GetObjectV2Input)
+	output, err := t.cli.GetObjectV2(t.ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+	defer output.Content.Close()
+	file, err := os.OpenFile(t.input.tempFile, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, newTosClientError(err.Error(), err)
+	}
+	defer file.Close()
+	var wrapped = output.Content
+	if t.input.DataTransferListener != nil {
+		wrapped = &parallelReadCloserWithListener{
+			listener: t.input.DataTransferListener,
+			base:     wrapped,
+			total:    t.total,
+			subtotal: t.subtotal,
+			consumed: t.consumed,
+			rate:     t.rate,
+		}
+	}
+	if t.input.RateLimiter != nil {
+		wrapped = &ReadCloserWithLimiter{
+			limiter: t.input.RateLimiter,
+			base:    wrapped,
+		}
+	}
+	_, err = file.Seek(t.RangeStart, io.SeekStart)
+	if err != nil {
+		return nil, newTosClientError(err.Error(), err)
+	}
+	written, err := io.Copy(file, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if written != (t.RangeEnd - t.RangeStart + 1) {
+		return nil, newTosClientError(fmt.Sprintf("tos: part %d is truncated, %d of %d bytes are downloaded",
+			t.PartNumber, written, t.RangeEnd-t.RangeStart+1), nil)
+	}
+	return downloadPartInfo{
+		PartNumber:    t.PartNumber,
+		RangeStart:    t.RangeStart,
+		RangeEnd:      t.RangeEnd,
+		HashCrc64ecma: output.HashCrc64ecma,
+		IsCompleted:   true,
+	}, nil
+}
+
+// ifMatch return the ETag every ranged GET must match, so all parts come from the same object
+func (t *downloadTask) ifMatch() string {
+	if len(t.input.IfMatch) > 0 {
+		return t.input.IfMatch
+	}
+	return t.etag
+}
+
+func (t *downloadTask) getBaseInput() interface{} {
+	return GetObjectV2Input{
+		Bucket:    t.input.Bucket,
+		Key:       t.input.Key,
+		VersionID: t.input.VersionID,
+		// pin the object we planned on, an object overwritten since HEAD or since ObjectInfo is collected fails with 412
+		IfMatch:           t.ifMatch(),
+		IfModifiedSince:   t.input.IfModifiedSince,
+		IfNoneMatch:       t.input.IfNoneMatch,
+		IfUnmodifiedSince: t.input.IfUnmodifiedSince,
+		SSECAlgorithm:     t.input.SSECAlgorithm,
+		SSECKey:           t.input.SSECKey,
+		SSECKeyMD5:        t.input.SSECKeyMD5,
+		RequestPayer:      t.input.RequestPayer,
+		RangeStart:        t.RangeStart,
+		RangeEnd:          t.RangeEnd,
+		// we want to Sent parallel Listener on output, so explicitly set listener of GetObjectV2Input nil here.
+		DataTransferListener: nil,
+		RateLimiter:          nil,
+	}
+}
 
 type uploadTask struct {
 	cli        *ClientV2
+	monitor    *transferMonitor // nullable
 	input      *UploadFileInput
+	limiter    RateLimiter // nullable, RateLimiter of input for the file
+	object     *TransferObject
 	consumed   *int64
 	subtotal   *int64
+	rate       *transferRate
 	mutex      *sync.Mutex
 	ctx        context.Context
 	total      int64
@@ -341,6 +441,11 @@ func (t *uploadTask) do() (interface{}, error) {
 		return nil, newTosClientError(err.Error(), err)
 	}
 	var wrapped = ioutil.NopCloser(io.LimitReader(file, t.input.PartSize))
+	if t.monitor != nil {
+		t.monitor.begin(t.PartNumber)
+		defer t.monitor.end(t.PartNumber)
+		wrapped = &readCloserWithMonitor{base: wrapped, monitor: t.monitor, partNumber: t.PartNumber}
+	}
 	if t.input.DataTransferListener != nil {
 		wrapped = &parallelReadCloserWithListener{
 			listener: t.input.DataTransferListener,
@@ -348,11 +453,12 @@ func (t *uploadTask) do() (interface{}, error) {
 			total:    t.total,
 			subtotal: t.subtotal,
 			consumed: t.consumed,
+			rate:     t.rate,
 		}
 	}
-	if t.input.RateLimiter != nil {
+	if t.limiter != nil {
 		wrapped = &ReadCloserWithLimiter{
-			limiter: t.input.RateLimiter,
+			limiter: t.limiter,
 			base:    wrapped,
 		}
 	}
@@ -362,6 +468,7 @@ func (t *uploadTask) do() (interface{}, error) {
 		UploadPartBasicInput: input.UploadPartBasicInput,
 		Content:              wrapped,
 		ContentLength:        input.ContentLength,
+		object:               t.object,
 	})
 	if err != nil {
 		return nil, err
@@ -389,44 +496,163 @@ func (t *uploadTask) getBaseInput() interface{} {
 			SSECKey:              t.input.SSECKey,
 			SSECKeyMD5:           t.input.SSECKeyMD5,
 			ServerSideEncryption: t.input.ServerSideEncryption,
+			TrafficLimit:         t.input.TrafficLimit,
+			RequestPayer:         t.input.RequestPayer,
 		},
 		ContentLength: t.PartSize,
 	}
 }
 
-type retryAction int
+type copyTask struct {
+	cli        *ClientV2
+	ctx        context.Context
+	input      *CopyFileInput
+	checkpoint *copyCheckpoint
+	part       copyPartInfo
+}
 
-const (
-	NoRetry retryAction = iota
-	Retry
-)
+// Do the copyTask, and return copyPartInfo
+func (t *copyTask) do() (interface{}, error) {
+	input := t.getBaseInput().(UploadPartCopyV2Input)
+	output, err := t.cli.UploadPartCopyV2(t.ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+	part := t.part
+	part.ETag = output.ETag
+	part.IsCompleted = true
+	return part, nil
+}
+
+func (t *copyTask) getBaseInput() interface{} {
+	return UploadPartCopyV2Input{
+		Bucket:               t.input.Bucket,
+		Key:                  t.input.Key,
+		UploadID:             t.checkpoint.UploadID,
+		PartNumber:           t.part.PartNumber,
+		SrcBucket:            t.input.SrcBucket,
+		SrcKey:               t.input.SrcKey,
+		SrcVersionID:         t.checkpoint.SourceInfo.VersionID,
+		CopySourceRangeStart: t.part.RangeStart,
+		CopySourceRangeEnd:   t.part.RangeEnd,
+		// pin the source object we planned on, parts of an object overwritten since then fail with 412
+		CopySourceIfMatch:       t.checkpoint.SourceInfo.Etag,
+		CopySourceSSECAlgorithm: t.input.CopySourceSSECAlgorithm,
+		CopySourceSSECKey:       t.input.CopySourceSSECKey,
+		CopySourceSSECKeyMD5:    t.input.CopySourceSSECKeyMD5,
+		RequestPayer:            t.input.RequestPayer,
+	}
+}
 
 const (
 	DefaultRetryBackoffBase = 100 * time.Millisecond
+	DefaultRetryBackoffMax  = 10 * time.Second
+	DefaultRetryJitter      = 0.25
 )
 
-type classifier interface {
-	Classify(error) retryAction
-}
-
+// exponentialBackoff returns n back-offs starting at base and doubling at each step
 func exponentialBackoff(n int, base time.Duration) []time.Duration {
+	if n < 0 {
+		n = 0
+	}
 	backoffs := make([]time.Duration, n)
 	for i := 0; i < len(backoffs); i++ {
 		backoffs[i] = base
-		base *= 1
+		// avoid overflow, the back-off is capped by retryer.maxBackoff anyway
+		if base < math.MaxInt64/2 {
+			base *= 2
+		}
 	}
 	return backoffs
 }
 
 type retryer struct {
-	backoff []time.Duration
-	jitter  float64
+	backoff    []time.Duration
+	maxBackoff time.Duration
+	jitter     float64
+	budget     *retryBudget               // nullable, only set in RetryModeAdaptive
+	onThrottle func(event *ThrottleEvent) // nullable
+	logger     Logger                     // nullable
+}
+
+const (
+	DefaultRetryBudgetCapacity = 500
+	retryBudgetRetryCost       = 5
+	retryBudgetSuccessRefill   = 1
+)
+
+// retryBudget is a token bucket shared by all requests of a client.
+// Each retry consumes tokens and each success refills some, so retries stop
+// once the service keeps failing, instead of piling up a retry storm.
+type retryBudget struct {
+	mu       sync.Mutex
+	tokens   int
+	capacity int
+}
+
+func newRetryBudget(capacity int) *retryBudget {
+	return &retryBudget{tokens: capacity, capacity: capacity}
+}
+
+// acquire try to take tokens for one retry, return false if budget is exhausted.
+// nil budget means unlimited.
+func (b *retryBudget) acquire() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < retryBudgetRetryCost {
+		return false
+	}
+	b.tokens -= retryBudgetRetryCost
+	return true
+}
+
+// release refill tokens after a success, retried indicates whether the success comes from a retry
+func (b *retryBudget) release(retried bool) {
+	if b == nil {
+		return
+	}
+	refill := retryBudgetSuccessRefill
+	if retried {
+		refill = retryBudgetRetryCost
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += refill
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+}
+
+// SetBudget sets the retry budget shared by all requests using this retryer, nil means unlimited.
+func (r *retryer) SetBudget(budget *retryBudget) {
+	r.budget = budget
+}
+
+// SetThrottleCallback sets the callback called before retrying a throttled request, nil means no callback.
+func (r *retryer) SetThrottleCallback(callback func(event *ThrottleEvent)) {
+	r.onThrottle = callback
+}
+
+// SetLogger sets the Logger to log retries, nil means no logs.
+func (r *retryer) SetLogger(logger Logger) {
+	r.logger = logger
 }
 
 func (r *retryer) SetBackoff(backoff []time.Duration) {
 	r.backoff = backoff
 }
 
+// SetMaxBackoff sets the upper bound of each back-off, jitter included. Zero means no limit.
+func (r *retryer) SetMaxBackoff(max time.Duration) {
+	if max < 0 {
+		return
+	}
+	r.maxBackoff = max
+}
+
 // newRetryer constructs a retryer with the given backoff pattern and classifier. The length of the backoff pattern
 // indicates how many times an action will be retried, and the value at each index indicates the amount of time
 // waited before each subsequent retry. The classifier is used to determine which errors should be retried and
@@ -452,30 +678,113 @@ func worthToRetry(ctx context.Context, waitTime time.Duration) bool {
 	return now.UnixNano()+int64(waitTime) <= deadline.UnixNano()
 }
 
+// sleepWithContext sleep d, return false if ctx is done before that
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	if ctx == nil {
+		time.Sleep(d)
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 // Run executes the given work function, then classifies its return value based on the classifier.
 // If the result is Succeed or Fail, the return value of the work function is
 // returned to the caller. If the result is Retry, then Run sleeps according to its backoff policy
 // before retrying. If the total number of retries is exceeded then the return value of the work function
 // is returned to the caller regardless.
-func (r *retryer) Run(ctx context.Context, work func() error, classifier classifier) error {
+func (r *retryer) Run(ctx context.Context, work func() error, classifier Classifier) error {
 	// run
 	ferr := work()
+	retried := false
 	// try retry
 	for i := 0; i < len(r.backoff) && classifier.Classify(ferr) == Retry; i++ {
 		// 重试
 		sleepTime := r.calcSleep(i)
+		se, throttled := throttledError(ferr)
+		var retryAfter time.Duration
+		if throttled {
+			// server-specified interval takes precedence over back-off
+			if retryAfter = parseRetryAfter(se.Header.Get(HeaderRetryAfter), time.Now()); retryAfter > 0 {
+				sleepTime = retryAfter
+			}
+		}
 		if !worthToRetry(ctx, sleepTime) {
 			return ferr
 		}
-		time.Sleep(sleepTime)
+		if !r.budget.acquire() {
+			return ferr
+		}
+		if throttled && r.onThrottle != nil {
+			r.onThrottle(&ThrottleEvent{
+				StatusCode: se.StatusCode,
+				RequestID:  se.RequestID,
+				Attempt:    i + 1,
+				RetryAfter: retryAfter,
+				Backoff:    sleepTime,
+			})
+		}
+		if r.logger != nil {
+			r.logger.Warn("tos: retry request", "attempt", i+1, "backoff", sleepTime, "error", ferr)
+		}
+		retried = true
+		if !sleepWithContext(ctx, sleepTime) {
+			return ferr
+		}
 		ferr = work()
 	}
+	if ferr == nil {
+		r.budget.release(retried)
+	}
 	return ferr
 }
 
+// throttledError return the TosServerError if err indicates the request is throttled by server
+func throttledError(err error) (*TosServerError, bool) {
+	se, ok := err.(*TosServerError)
+	if !ok {
+		return nil, false
+	}
+	return se, se.StatusCode == http.StatusTooManyRequests || se.StatusCode == http.StatusServiceUnavailable
+}
+
+// parseRetryAfter parse Retry-After header, which is either delay-seconds or HTTP-date.
+// Return 0 if the header is absent or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 || seconds > math.MaxInt64/int64(time.Second) {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := date.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
 func (r *retryer) calcSleep(i int) time.Duration {
-	// take a random float in the range (-r.jitter, +r.jitter) and multiply it by the base amount
-	return r.backoff[i]
+	sleep := r.backoff[i]
+	if r.jitter > 0 {
+		// take a random float in the range (-r.jitter, +r.jitter) and multiply it by the base amount
+		sleep += time.Duration(float64(sleep) * r.jitter * (rand.Float64()*2 - 1))
+	}
+	if r.maxBackoff > 0 && sleep > r.maxBackoff {
+		sleep = r.maxBackoff
+	}
+	return sleep
 }
 
 // SetJitter sets the amount of jitter on each back-off to a factor between 0.0 and 1.0 (values outside this range
@@ -507,6 +816,114 @@ func (r *readCloserWithCRC) Close() error {
 	return r.base.Close()
 }
 
+// readCloserWithCRCCheck compare crc64 with the one in response header on EOF
+type readCloserWithCRCCheck struct {
+	readCloserWithCRC
+	res *Response
+}
+
+func (r *readCloserWithCRCCheck) Read(p []byte) (n int, err error) {
+	n, err = r.readCloserWithCRC.Read(p)
+	if err == io.EOF {
+		if cerr := checkCrc64(r.res, r.checker); cerr != nil {
+			return n, cerr
+		}
+	}
+	return
+}
+
+// transferMonitor tracks activity of parts being transferred, for heartbeat
+type transferMonitor struct {
+	mu       sync.Mutex
+	start    time.Time
+	consumed int64
+	inflight map[int]*partActivity
+	now      func() time.Time
+}
+
+type partActivity struct {
+	start time.Time
+	last  time.Time
+}
+
+func newTransferMonitor() *transferMonitor {
+	return &transferMonitor{start: time.Now(), inflight: make(map[int]*partActivity), now: time.Now}
+}
+
+// begin mark part as inflight, nil monitor is a no-op
+func (m *transferMonitor) begin(partNumber int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	m.inflight[partNumber] = &partActivity{start: now, last: now}
+}
+
+// touch record n bytes of part moved
+func (m *transferMonitor) touch(partNumber int, n int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consumed += int64(n)
+	if activity, ok := m.inflight[partNumber]; ok {
+		activity.last = m.now()
+	}
+}
+
+func (m *transferMonitor) end(partNumber int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.inflight, partNumber)
+}
+
+func (m *transferMonitor) heartbeat() *TransferHeartbeat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	heartbeat := &TransferHeartbeat{
+		Elapsed:       now.Sub(m.start),
+		ConsumedBytes: m.consumed,
+		InflightParts: make([]PartStall, 0, len(m.inflight)),
+	}
+	for partNumber, activity := range m.inflight {
+		heartbeat.InflightParts = append(heartbeat.InflightParts, PartStall{
+			PartNumber: partNumber,
+			Elapsed:    now.Sub(activity.start),
+			Stalled:    now.Sub(activity.last),
+		})
+	}
+	sort.Slice(heartbeat.InflightParts, func(i, j int) bool {
+		return heartbeat.InflightParts[i].PartNumber < heartbeat.InflightParts[j].PartNumber
+	})
+	return heartbeat
+}
+
+// readCloserWithMonitor report bytes read to transferMonitor
+type readCloserWithMonitor struct {
+	base       io.ReadCloser
+	monitor    *transferMonitor
+	partNumber int
+}
+
+func (r *readCloserWithMonitor) Read(p []byte) (n int, err error) {
+	n, err = r.base.Read(p)
+	if n > 0 {
+		r.monitor.touch(r.partNumber, n)
+	}
+	return
+}
+
+func (r *readCloserWithMonitor) Close() error {
+	return r.base.Close()
+}
+
 // parallelReadCloserWithListener warp multiple io.ReadCloser will be R/W in parallel with a same DataTransferListener
 type parallelReadCloserWithListener struct {
 	listener DataTransferListener
@@ -514,13 +931,14 @@ type parallelReadCloserWithListener struct {
 	consumed *int64
 	subtotal *int64
 	total    int64
+	rate     *transferRate // shared by all parts
 	m        *sync.Mutex
 }
 
 func (r *parallelReadCloserWithListener) Read(p []byte) (n int, err error) {
 	n, err = r.base.Read(p)
 	if err != nil && err != io.EOF {
-		postDataTransferStatus(r.listener, &DataTransferStatus{
+		_ = postDataTransferStatus(r.listener, &DataTransferStatus{
 			Type: enum.DataTransferFailed,
 		})
 		return n, err
@@ -531,77 +949,114 @@ func (r *parallelReadCloserWithListener) Read(p []byte) (n int, err error) {
 	consumed := atomic.AddInt64(r.consumed, int64(n))
 	subtotal := atomic.AddInt64(r.subtotal, int64(n))
 	if subtotal >= 4*1024*1024 {
-		postDataTransferStatus(r.listener, &DataTransferStatus{
+		atomic.StoreInt64(r.subtotal, 0)
+		if perr := r.post(&DataTransferStatus{
 			Type:          enum.DataTransferRW,
 			RWOnceBytes:   subtotal,
 			ConsumedBytes: consumed,
 			TotalBytes:    r.total,
-		})
-		atomic.StoreInt64(r.subtotal, 0)
+		}); perr != nil {
+			return n, perr
+		}
 	}
 	if consumed == r.total {
 		if subtotal < 4*1024*1024 {
-			postDataTransferStatus(r.listener, &DataTransferStatus{
+			if perr := r.post(&DataTransferStatus{
 				Type:          enum.DataTransferRW,
 				RWOnceBytes:   subtotal,
 				ConsumedBytes: consumed,
 				TotalBytes:    r.total,
-			})
+			}); perr != nil {
+				return n, perr
+			}
 		}
-		postDataTransferStatus(r.listener, &DataTransferStatus{
+		if perr := r.post(&DataTransferStatus{
 			Type:          enum.DataTransferSucceed,
 			ConsumedBytes: consumed,
 			TotalBytes:    r.total,
-		})
+		}); perr != nil {
+			return n, perr
+		}
 	}
 	return
 }
 
+// post status with speed and ETA, return TosClientError if listener panics
+func (r *parallelReadCloserWithListener) post(status *DataTransferStatus) error {
+	if r.rate != nil {
+		r.rate.update(status)
+	}
+	return postDataTransferStatus(r.listener, status)
+}
+
 func (r *parallelReadCloserWithListener) Close() error {
 	return r.base.Close()
 }
 
 // readCloserWithListener warp io.ReadCloser with DataTransferListener
 type readCloserWithListener struct {
-	listener DataTransferListener
-	base     io.ReadCloser
-	consumed int64
-	total    int64
+	listener  DataTransferListener
+	base      io.ReadCloser
+	consumed  int64
+	total     int64           // -1 if unknown, then DataTransferSucceed is posted at EOF
+	object    *TransferObject // nullable
+	rate      *transferRate   // set at the first read
+	succeeded bool
 }
 
 func (r *readCloserWithListener) Read(p []byte) (n int, err error) {
-	if r.consumed == 0 {
-		postDataTransferStatus(r.listener, &DataTransferStatus{
-			Type: enum.DataTransferStarted,
-		})
+	if r.rate == nil {
+		r.rate = newTransferRate()
+		if err = postDataTransferStatus(r.listener, &DataTransferStatus{
+			Type:   enum.DataTransferStarted,
+			Object: r.object,
+		}); err != nil {
+			return 0, err
+		}
 	}
 	n, err = r.base.Read(p)
 	if err != nil && err != io.EOF {
-		postDataTransferStatus(r.listener, &DataTransferStatus{
+		_ = postDataTransferStatus(r.listener, &DataTransferStatus{
 			Type: enum.DataTransferFailed,
 		})
 		return n, err
 	}
-	if n <= 0 {
-		return
-	}
-	r.consumed += int64(n)
-	postDataTransferStatus(r.listener, &DataTransferStatus{
-		Type:          enum.DataTransferRW,
-		RWOnceBytes:   int64(n),
-		ConsumedBytes: r.consumed,
-		TotalBytes:    r.total,
-	})
-	if r.consumed == r.total {
-		postDataTransferStatus(r.listener, &DataTransferStatus{
-			Type:          enum.DataTransferSucceed,
+	if n > 0 {
+		r.consumed += int64(n)
+		rw := &DataTransferStatus{
+			Type:          enum.DataTransferRW,
+			RWOnceBytes:   int64(n),
 			ConsumedBytes: r.consumed,
 			TotalBytes:    r.total,
-		})
+		}
+		r.rate.update(rw)
+		if perr := postDataTransferStatus(r.listener, rw); perr != nil {
+			return n, perr
+		}
+	}
+	if (n > 0 && r.consumed == r.total) || (err == io.EOF && r.total < 0) {
+		if perr := r.succeed(); perr != nil {
+			return n, perr
+		}
 	}
 	return
 }
 
+// succeed post DataTransferSucceed once
+func (r *readCloserWithListener) succeed() error {
+	if r.succeeded {
+		return nil
+	}
+	r.succeeded = true
+	status := &DataTransferStatus{
+		Type:          enum.DataTransferSucceed,
+		ConsumedBytes: r.consumed,
+		TotalBytes:    r.total,
+	}
+	r.rate.update(status)
+	return postDataTransferStatus(r.listener, status)
+}
+
 func (r *readCloserWithListener) Close() error {
 	return r.base.Close()
 }
@@ -615,7 +1070,10 @@ type ReadCloserWithLimiter struct {
 func (r ReadCloserWithLimiter) Read(p []byte) (n int, err error) {
 	want := len(p)
 	for {
-		ok, timeToWait := r.limiter.Acquire(int64(want))
+		ok, timeToWait, err := r.acquire(int64(want))
+		if err != nil {
+			return 0, err
+		}
 		if ok {
 			break
 		}
@@ -624,6 +1082,13 @@ func (r ReadCloserWithLimiter) Read(p []byte) (n int, err error) {
 	return r.base.Read(p)
 }
 
+// acquire from limiter, return TosClientError if it panics
+func (r ReadCloserWithLimiter) acquire(want int64) (ok bool, timeToWait time.Duration, err error) {
+	defer recoverPanic("RateLimiter", &err)
+	ok, timeToWait = r.limiter.Acquire(want)
+	return ok, timeToWait, nil
+}
+
 func (r ReadCloserWithLimiter) Close() error {
 	return r.base.Close()
 }
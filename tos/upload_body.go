@@ -0,0 +1,110 @@
+package tos
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+)
+
+// ContentProvider return a new reader of the same content from the start each time it's called,
+// so that failed attempts of uploads can be retried. Readers returned are closed after their attempts.
+type ContentProvider func() (io.ReadCloser, error)
+
+// uploadBody produce the content of each attempt of an upload from the start. Content can be read again if it's
+// from ContentProvider, or it's seekable, or it's buffered in memory, see WithRetryBufferSize.
+// Uploads of content which can't be read again are not retried.
+type uploadBody struct {
+	content  io.Reader       // content of the first attempt, nullable
+	provider ContentProvider // nullable
+	seeker   io.Seeker       // nullable, set if content is seekable
+	start    int64           // offset of seeker where content starts
+	opened   io.ReadCloser   // nullable, the last reader returned by provider
+}
+
+// newUploadBody buffer content in memory if it's not seekable and not larger than bufferSize
+func newUploadBody(content io.Reader, provider ContentProvider, bufferSize int64) (*uploadBody, error) {
+	body := &uploadBody{content: content, provider: provider}
+	if provider != nil || content == nil {
+		return body, nil
+	}
+	if seeker, ok := content.(io.Seeker); ok {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, newTosClientError("tos: get offset of content failed", err)
+		}
+		body.seeker, body.start = seeker, start
+		return body, nil
+	}
+	if bufferSize > 0 {
+		buffered, err := ioutil.ReadAll(io.LimitReader(content, bufferSize+1))
+		if err != nil {
+			return nil, newTosClientError("tos: read content failed", err)
+		}
+		if int64(len(buffered)) <= bufferSize {
+			reader := bytes.NewReader(buffered)
+			body.content, body.seeker = reader, reader
+			return body, nil
+		}
+		body.content = io.MultiReader(bytes.NewReader(buffered), content)
+	}
+	return body, nil
+}
+
+// rewindable report whether content can be read again from the start
+func (b *uploadBody) rewindable() bool {
+	return b.provider != nil || b.seeker != nil
+}
+
+// open return content from the start, the reader returned by the last call is closed
+func (b *uploadBody) open() (io.Reader, error) {
+	if b.provider != nil {
+		b.close()
+		rc, err := b.provider()
+		if err != nil {
+			return nil, newTosClientError("tos: get content from ContentProvider failed", err)
+		}
+		b.opened = rc
+		return rc, nil
+	}
+	if b.seeker != nil {
+		if _, err := b.seeker.Seek(b.start, io.SeekStart); err != nil {
+			return nil, newTosClientError("tos: rewind content failed", err)
+		}
+	}
+	return b.content, nil
+}
+
+// close the last reader returned by provider
+func (b *uploadBody) close() {
+	if b.opened != nil {
+		_ = b.opened.Close()
+		b.opened = nil
+	}
+}
+
+// retry return onRetry replacing content of req with the one returned by reopen before each retry,
+// reopen reads content from the start with open
+func (b *uploadBody) retry(reopen func() (io.Reader, error)) func(req *Request) {
+	attempts := 0
+	return func(req *Request) {
+		attempts++
+		// content of the first attempt is passed to Request
+		if attempts == 1 {
+			return
+		}
+		content, err := reopen()
+		if err != nil {
+			content = &errorReader{err: err}
+		}
+		req.Content = content
+	}
+}
+
+// errorReader fail the attempt with err
+type errorReader struct {
+	err error
+}
+
+func (r *errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,98 @@
+package tos
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// bodyTransport record bodies of requests, and fails the first failures requests with 503 after reading the body
+type bodyTransport struct {
+	failures int
+	bodies   []string
+}
+
+func (rt *bodyTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	data, err := ioutil.ReadAll(req.Content)
+	if err != nil {
+		return nil, newTosClientError(err.Error(), err)
+	}
+	rt.bodies = append(rt.bodies, string(data))
+	if len(rt.bodies) <= rt.failures {
+		return &Response{StatusCode: http.StatusServiceUnavailable, Header: make(http.Header),
+			Body: ioutil.NopCloser(strings.NewReader(`{"Code":"ServiceUnavailable"}`))}, nil
+	}
+	return &Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+// countingCloser count closes of readers returned by ContentProvider
+type countingCloser struct {
+	io.Reader
+	closes *int
+}
+
+func (c *countingCloser) Close() error {
+	*c.closes++
+	return nil
+}
+
+func TestRewindableUploadBody(t *testing.T) {
+	newClient := func(rt Transport, options ...ClientOption) *ClientV2 {
+		options = append(options, WithTransport(rt), WithMaxRetryCount(1), WithRetryBackoff(time.Millisecond, time.Millisecond))
+		client, err := NewClientV2("tos-cn-beijing.volces.com", options...)
+		require.Nil(t, err)
+		return client
+	}
+	put := func(client *ClientV2, input *PutObjectV2Input) error {
+		input.Bucket, input.Key = "bucket", "key"
+		_, err := client.PutObjectV2(context.Background(), input)
+		return err
+	}
+
+	// seekable content is rewound
+	rt := &bodyTransport{failures: 1}
+	require.Nil(t, put(newClient(rt), &PutObjectV2Input{Content: strings.NewReader("hello")}))
+	require.Equal(t, []string{"hello", "hello"}, rt.bodies)
+
+	// content which can't be read again is not retried
+	rt = &bodyTransport{failures: 1}
+	require.NotNil(t, put(newClient(rt), &PutObjectV2Input{Content: iotest.OneByteReader(strings.NewReader("hello"))}))
+	require.Equal(t, []string{"hello"}, rt.bodies)
+
+	// unless it's buffered
+	rt = &bodyTransport{failures: 1}
+	require.Nil(t, put(newClient(rt, WithRetryBufferSize(5)),
+		&PutObjectV2Input{Content: iotest.OneByteReader(strings.NewReader("hello"))}))
+	require.Equal(t, []string{"hello", "hello"}, rt.bodies)
+	rt = &bodyTransport{failures: 1}
+	require.NotNil(t, put(newClient(rt, WithRetryBufferSize(4)),
+		&PutObjectV2Input{Content: iotest.OneByteReader(strings.NewReader("hello"))}))
+	require.Equal(t, []string{"hello"}, rt.bodies)
+
+	// or it's from ContentProvider
+	rt = &bodyTransport{failures: 1}
+	var provided, closes int
+	provider := func() (io.ReadCloser, error) {
+		provided++
+		return &countingCloser{Reader: iotest.OneByteReader(strings.NewReader("hello")), closes: &closes}, nil
+	}
+	require.Nil(t, put(newClient(rt), &PutObjectV2Input{ContentProvider: provider}))
+	require.Equal(t, []string{"hello", "hello"}, rt.bodies)
+	require.Equal(t, 2, provided)
+	require.Equal(t, 2, closes)
+
+	rt = &bodyTransport{failures: 1}
+	_, err := newClient(rt).UploadPartV2(context.Background(), &UploadPartV2Input{
+		UploadPartBasicInput: UploadPartBasicInput{Bucket: "bucket", Key: "key", UploadID: "upload", PartNumber: 1},
+		ContentProvider:      provider,
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"hello", "hello"}, rt.bodies)
+}
@@ -2,10 +2,15 @@ package tos
 
 import (
 	"context"
+	"fmt"
 	"github.com/volcengine/ve-tos-golang-sdk/v2/tos/enum"
+	"hash/crc64"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // initUploadPartsInfo initialize parts info from file stat,return TosClientError if failed
@@ -62,8 +67,43 @@ func initUploadCheckpoint(input *UploadFileInput, created *CreateMultipartUpload
 	return checkPoint, nil
 }
 
+// uploadCheckpointFile return the correct checkpoint path of UploadFile
+func uploadCheckpointFile(filePath, bucket, key, checkpointFile, checkpointDir string) string {
+	if len(checkpointFile) == 0 && len(checkpointDir) > 0 {
+		return fileNameInDir(checkpointDir, filePath, bucket, key, ".upload")
+	} else if len(checkpointFile) == 0 {
+		dirName, baseName := filepath.Split(filePath)
+		fileName := strings.Join([]string{baseName, bucket, key, "upload"}, ".")
+		return filepath.Join(dirName, fileName)
+	}
+	mustFile(&checkpointFile, strings.Join([]string{filePath, bucket, key, "upload"}, "."))
+	return checkpointFile
+}
+
+// legacyUploadCheckpointFile return the default checkpoint path of UploadFile of old versions, which joins the
+// directory of filePath with the whole filePath
+func legacyUploadCheckpointFile(filePath, bucket, key string) string {
+	dirName, _ := filepath.Split(filePath)
+	return filepath.Join(dirName, strings.Join([]string{filePath, bucket, key, "upload"}, "."))
+}
+
+// adoptLegacyUploadCheckpoint move the checkpoint file at legacy path to checkpointFile if checkpointFile doesn't
+// exist, so that uploads started by old versions are resumed rather than started over
+func adoptLegacyUploadCheckpoint(legacy, checkpointFile string) {
+	if legacy == checkpointFile {
+		return
+	}
+	if _, err := os.Stat(checkpointFile); !os.IsNotExist(err) {
+		return
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		return
+	}
+	_ = os.Rename(legacy, checkpointFile)
+}
+
 // validateUploadInput validate upload input, return TosClientError failed
-func validateUploadInput(input *UploadFileInput) error {
+func validateUploadInput(input *UploadFileInput, checkpointDir string) error {
 	if err := isValidNames(input.Bucket, input.Key); err != nil {
 		return err
 	}
@@ -81,13 +121,10 @@ func validateUploadInput(input *UploadFileInput) error {
 		return newTosClientError("tos: does not support directory, please specific your file path.", nil)
 	}
 	if input.EnableCheckpoint {
-		// get correct checkpoint path
-		if len(input.CheckpointFile) == 0 {
-			dirName, _ := filepath.Split(input.FilePath)
-			fileName := strings.Join([]string{input.FilePath, input.Bucket, input.Key, "upload"}, ".")
-			input.CheckpointFile = filepath.Join(dirName, fileName)
-		} else {
-			mustFile(&input.CheckpointFile, strings.Join([]string{input.FilePath, input.Bucket, input.Key, "upload"}, "."))
+		defaulted := len(input.CheckpointFile) == 0 && len(checkpointDir) == 0
+		input.CheckpointFile = uploadCheckpointFile(input.FilePath, input.Bucket, input.Key, input.CheckpointFile, checkpointDir)
+		if defaulted {
+			adoptLegacyUploadCheckpoint(legacyUploadCheckpointFile(input.FilePath, input.Bucket, input.Key), input.CheckpointFile)
 		}
 	}
 	if input.TaskNum < 1 {
@@ -99,23 +136,30 @@ func validateUploadInput(input *UploadFileInput) error {
 	return nil
 }
 
-func postUploadEvent(listener UploadEventListener, event *UploadEvent) {
+// postUploadEvent return TosClientError if listener panics
+func postUploadEvent(listener UploadEventListener, event *UploadEvent) (err error) {
 	if listener != nil {
+		defer recoverPanic("UploadEventListener", &err)
 		listener.EventChange(event)
 	}
+	return nil
 }
 
 // getUploadCheckpoint get struct checkpoint from checkpoint file if checkpointPath is valid,
 // or initialize from scratch with function init
-func getUploadCheckpoint(enabled bool, checkpointPath string,
+func getUploadCheckpoint(enabled bool, checkpointPath string, valid func(checkpoint *uploadCheckpoint) bool,
 	init func() (*uploadCheckpoint, error)) (checkpoint *uploadCheckpoint, err error) {
 	if enabled {
 		_, err = os.Stat(checkpointPath)
 		// if err is not empty, assume checkpoint not exists
 		if err == nil {
-			loadCheckPoint(checkpointPath, checkpoint)
-			if checkpoint != nil {
-				return
+			loaded := &uploadCheckpoint{checkpointPath: checkpointPath}
+			ok, err := loadCheckPoint(checkpointPath, loaded)
+			if err != nil {
+				return nil, err
+			}
+			if ok && valid(loaded) {
+				return loaded, nil
 			}
 		}
 		_, err = os.Create(checkpointPath)
@@ -155,33 +199,70 @@ func bindCancelHookWithCleaner(hook CancelHook, cleaner func()) {
 func (cli *ClientV2) UploadFile(ctx context.Context, input *UploadFileInput) (output *UploadFileOutput, err error) {
 	// avoid modifying on origin pointer
 	input = &(*input)
-	if err = validateUploadInput(input); err != nil {
+	if err = validateUploadInput(input, cli.config.CheckpointDir); err != nil {
 		return nil, err
 	}
-	// create multipart upload task
-	created, err := cli.CreateMultipartUploadV2(ctx, &input.CreateMultipartUploadV2Input)
-	if err != nil {
-		postUploadEvent(input.UploadEventListener, &UploadEvent{
-			Type:           enum.UploadEventCreateMultipartUploadFailed,
-			Err:            err,
+	var (
+		fileSize       int64
+		failedAttempts int
+	)
+	if stat, err := os.Stat(input.FilePath); err == nil {
+		fileSize = stat.Size()
+	}
+	if cli.tracer != nil {
+		var span Span
+		ctx, span = cli.tracer.Start(ctx, "UploadFile")
+		span.SetAttributes(SpanAttribute{Key: SpanAttributeBucket, Value: input.Bucket},
+			SpanAttribute{Key: SpanAttributeKey, Value: input.Key},
+			SpanAttribute{Key: SpanAttributeFileSize, Value: fileSize})
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.End()
+		}()
+	}
+	if cli.metrics != nil {
+		start := time.Now()
+		defer func() {
+			statusCode := http.StatusOK
+			if err != nil {
+				statusCode = StatusCode(err)
+			}
+			cli.metrics.RecordRequest("UploadFile", statusCode, time.Since(start), fileSize, failedAttempts)
+		}()
+	}
+	valid := func(checkpoint *uploadCheckpoint) bool {
+		stat, err := os.Stat(input.FilePath)
+		return err == nil && checkpoint.Valid(stat, input.Bucket, input.Key, input.FilePath)
+	}
+	init := func() (*uploadCheckpoint, error) {
+		// create multipart upload task
+		created, err := cli.CreateMultipartUploadV2(ctx, &input.CreateMultipartUploadV2Input)
+		if err != nil {
+			_ = postUploadEvent(input.UploadEventListener, &UploadEvent{
+				Type:           enum.UploadEventCreateMultipartUploadFailed,
+				Err:            err,
+				Bucket:         input.Bucket,
+				Key:            input.Key,
+				CheckpointFile: &input.CheckpointFile,
+			})
+			return nil, err
+		}
+		if err = postUploadEvent(input.UploadEventListener, &UploadEvent{
+			Type:           enum.UploadEventCreateMultipartUploadSucceed,
 			Bucket:         input.Bucket,
 			Key:            input.Key,
+			UploadID:       &created.UploadID,
 			CheckpointFile: &input.CheckpointFile,
-		})
-		return nil, err
-	}
-	postUploadEvent(input.UploadEventListener, &UploadEvent{
-		Type:           enum.UploadEventCreateMultipartUploadSucceed,
-		Bucket:         input.Bucket,
-		Key:            input.Key,
-		UploadID:       &created.UploadID,
-		CheckpointFile: &input.CheckpointFile,
-	})
-	init := func() (*uploadCheckpoint, error) {
+		}); err != nil {
+			return nil, err
+		}
 		return initUploadCheckpoint(input, created)
 	}
-	// if the checkpoint file not exist, here we will create it
-	checkpoint, err := getUploadCheckpoint(input.EnableCheckpoint, input.CheckpointFile, init)
+	// reuse the multipart upload recorded in checkpoint file if it's valid,
+	// otherwise create a new one and write it to checkpoint file
+	checkpoint, err := getUploadCheckpoint(input.EnableCheckpoint, input.CheckpointFile, valid, init)
 	if err != nil {
 		return nil, err
 	}
@@ -189,24 +270,42 @@ func (cli *ClientV2) UploadFile(ctx context.Context, input *UploadFileInput) (ou
 		_ = os.Remove(input.CheckpointFile)
 	}
 	bindCancelHookWithCleaner(input.CancelHook, cleaner)
-	return cli.uploadPart(ctx, checkpoint, input)
+	return cli.uploadPart(ctx, checkpoint, input, &failedAttempts)
+}
+
+// uploadFileObject return the object uploaded by UploadFile
+func uploadFileObject(input *UploadFileInput, checkpoint *uploadCheckpoint) *TransferObject {
+	return &TransferObject{
+		Bucket:       input.Bucket,
+		Key:          input.Key,
+		Size:         checkpoint.FileInfo.Size,
+		StorageClass: input.StorageClass,
+	}
 }
 
-func prepareUploadTasks(cli *ClientV2, ctx context.Context, checkpoint *uploadCheckpoint, input *UploadFileInput) []task {
+func prepareUploadTasks(cli *ClientV2, ctx context.Context, checkpoint *uploadCheckpoint, input *UploadFileInput,
+	monitor *transferMonitor) []task {
 	tasks := make([]task, 0)
+	object := uploadFileObject(input, checkpoint)
+	limiter := limiterFor(input.RateLimiter, object)
 	consumed := int64(0)
 	subtotal := int64(0)
+	rate := newTransferRate()
 	for _, part := range checkpoint.PartsInfo {
 		if !part.IsCompleted {
 			tasks = append(tasks, &uploadTask{
 				cli:        cli,
+				monitor:    monitor,
 				ctx:        ctx,
 				input:      input,
+				limiter:    limiter,
+				object:     object,
 				total:      checkpoint.FileInfo.Size,
 				UploadID:   checkpoint.UploadID,
 				PartNumber: part.PartNumber,
 				subtotal:   &subtotal,
 				consumed:   &consumed,
+				rate:       rate,
 				Offset:     part.Offset,
 				PartSize:   part.PartSize,
 			})
@@ -277,30 +376,33 @@ func newCompleteMultipartUploadSucceedEvent(input *UploadFileInput, uploadID str
 
 // checkFileCrc64 check if crc64 checksum of file is expected. Return TosClientError if open file failed, or return
 // TosServerError if check sum mismatch
-//func checkFileCrc64(filepath string, want uint64) error {
-//	fd, err := os.Open(filepath)
-//	if err != nil {
-//		return newTosClientError(err.Error(), err)
-//	}
-//	defer fd.Close()
-//	crc := crc64.New(DefaultCrcTable())
-//	_, err = io.Copy(crc, fd)
-//	if err != nil {
-//		return err
-//	}
-//	if crc.Sum64() != want {
-//		// data returned by server is invalid, or we encounter a bug in sdk
-//		return &TosServerError{
-//			TosError: TosError{"tos: crc of entire file mismatch."},
-//		}
-//	}
-//	return nil
-//}
+func checkFileCrc64(filepath string, want uint64) error {
+	fd, err := os.Open(filepath)
+	if err != nil {
+		return newTosClientError(err.Error(), err)
+	}
+	defer fd.Close()
+	crc := crc64.New(DefaultCrcTable())
+	_, err = io.Copy(crc, fd)
+	if err != nil {
+		return err
+	}
+	if crc.Sum64() != want {
+		// data returned by server is invalid, or we encounter a bug in sdk
+		return &TosServerError{
+			TosError: TosError{"tos: crc of entire file mismatch."},
+		}
+	}
+	return nil
+}
 
-func postDataTransferStatus(listener DataTransferListener, status *DataTransferStatus) {
+// postDataTransferStatus return TosClientError if listener panics
+func postDataTransferStatus(listener DataTransferListener, status *DataTransferStatus) (err error) {
 	if listener != nil {
+		defer recoverPanic("DataTransferListener", &err)
 		listener.DataTransferStatusChange(status)
 	}
+	return nil
 }
 
 func min(a int, b int) int {
@@ -329,15 +431,37 @@ func combineCRCInParts(parts []uploadPartInfo) uint64 {
 	return crc
 }
 
-func (cli *ClientV2) uploadPart(ctx context.Context, checkpoint *uploadCheckpoint, input *UploadFileInput) (*UploadFileOutput, error) {
+// taskFailure a task failed after retries of its requests are exhausted
+type taskFailure struct {
+	task task
+	err  error
+}
+
+// uploadPart upload parts and complete the multipart upload, failedAttempts is set to the number of part failures
+func (cli *ClientV2) uploadPart(ctx context.Context, checkpoint *uploadCheckpoint, input *UploadFileInput,
+	failedAttempts *int) (*UploadFileOutput, error) {
 	// prepare tasks
 	// if amount of tasks >= 10000, err "tos: part count too many" will be raised.
-	tasks := prepareUploadTasks(cli, ctx, checkpoint, input)
+	var (
+		monitor   *transferMonitor
+		heartbeat <-chan time.Time
+	)
+	if input.HeartbeatInterval > 0 {
+		monitor = newTransferMonitor()
+		ticker := time.NewTicker(input.HeartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	tasks := prepareUploadTasks(cli, ctx, checkpoint, input, monitor)
+	queue := newPartQueue(cli.metrics, "UploadFile")
+	for _, t := range tasks {
+		queue.enqueue(t)
+	}
 	routinesNum := min(input.TaskNum, len(tasks))
 	taskBufferSize := min(routinesNum, DefaultTaskBufferSize)
 	tasksCh := make(chan task, taskBufferSize)
 	resultsCh := make(chan uploadPartInfo)
-	errCh := make(chan error)
+	errCh := make(chan taskFailure)
 	cancelHandle := getCancelHandle(input.CancelHook)
 	abortHandle := make(chan struct{})
 	worker := func() {
@@ -351,54 +475,94 @@ func (cli *ClientV2) uploadPart(ctx context.Context, checkpoint *uploadCheckpoin
 				if !ok {
 					return
 				}
+				queue.dequeue(t)
 				result, err := t.do()
 				if err != nil {
-					errCh <- err
+					select {
+					case errCh <- taskFailure{task: t, err: err}:
+					case <-cancelHandle:
+						return
+					case <-abortHandle:
+						return
+					}
 				}
 				if part, ok := result.(uploadPartInfo); ok {
-					resultsCh <- part
+					select {
+					case resultsCh <- part:
+					case <-cancelHandle:
+						return
+					case <-abortHandle:
+						return
+					}
 				}
 			}
 		}
 	}
+	// tasksCh is closed after all tasks finish, since failed tasks may be rescheduled
+	schedule := func(t task) {
+		select {
+		case <-cancelHandle:
+		case <-abortHandle:
+		case tasksCh <- t:
+		}
+	}
 	scheduler := func() {
-		func() {
-			for _, t := range tasks {
-				select {
-				case <-cancelHandle:
-					return
-				case <-abortHandle:
-					return
-				default:
-					tasksCh <- t
-				}
+		for _, t := range tasks {
+			select {
+			case <-cancelHandle:
+				return
+			case <-abortHandle:
+				return
+			default:
+				schedule(t)
 			}
-		}()
-		close(tasksCh)
+		}
 	}
 
 	aborter := func() error {
 		_, err := cli.AbortMultipartUpload(ctx,
 			&AbortMultipartUploadInput{
-				Bucket:   input.Bucket,
-				Key:      input.Key,
-				UploadID: checkpoint.UploadID})
+				Bucket:       input.Bucket,
+				Key:          input.Key,
+				UploadID:     checkpoint.UploadID,
+				RequestPayer: input.RequestPayer})
 		return err
 	}
 	bindCancelHookWithAborter(input.CancelHook, aborter)
 
 	// start running workers
 	for i := 0; i < routinesNum; i++ {
-		go worker()
+		delay := cli.config.RampUpPolicy.delay(i, routinesNum)
+		if delay <= 0 {
+			go worker()
+			continue
+		}
+		go func() {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-cancelHandle:
+				return
+			case <-abortHandle:
+				return
+			case <-timer.C:
+			}
+			worker()
+		}()
 	}
 	// start adding tasks
-	postDataTransferStatus(input.DataTransferListener, &DataTransferStatus{
+	if err := postDataTransferStatus(input.DataTransferListener, &DataTransferStatus{
 		TotalBytes: checkpoint.FileInfo.Size,
 		Type:       enum.DataTransferStarted,
-	})
+		Object:     uploadFileObject(input, checkpoint),
+	}); err != nil {
+		close(abortHandle)
+		return nil, err
+	}
 	go scheduler()
 	success := 0
 	fails := 0
+	reschedules := make(map[task]int)
 	// processing tasks
 Loop:
 	for success+fails < len(tasks) {
@@ -407,44 +571,86 @@ Loop:
 			break Loop
 		case <-cancelHandle:
 			break Loop
+		case <-heartbeat:
+			if err := postUploadEvent(input.UploadEventListener, &UploadEvent{
+				Type:           enum.UploadEventHeartbeat,
+				Bucket:         input.Bucket,
+				Key:            input.Key,
+				UploadID:       &checkpoint.UploadID,
+				CheckpointFile: &input.CheckpointFile,
+				Heartbeat:      monitor.heartbeat(),
+			}); err != nil {
+				// keep the multipart upload and checkpoint, so the upload can be resumed later
+				close(abortHandle)
+				return nil, err
+			}
 		case part := <-resultsCh:
 			success++
 			checkpoint.UpdatePartsInfo(part)
 			if input.EnableCheckpoint {
-				checkpoint.WriteToFile()
+				if err := checkpoint.WriteToFile(); err != nil {
+					cli.logger.Warn("tos: write checkpoint file failed", "checkpointFile", input.CheckpointFile, "error", err)
+				}
 			}
-			postUploadEvent(input.UploadEventListener, newUploadPartSucceedEvent(input, part))
-		case taskErr := <-errCh:
+			if err := postUploadEvent(input.UploadEventListener, newUploadPartSucceedEvent(input, part)); err != nil {
+				close(abortHandle)
+				return nil, err
+			}
+		case failure := <-errCh:
+			taskErr := failure.err
+			cli.logger.Warn("tos: upload part failed", "bucket", input.Bucket, "key", input.Key,
+				"uploadID", checkpoint.UploadID, "error", taskErr)
 			if StatusCode(taskErr) == 403 || StatusCode(taskErr) == 404 || StatusCode(taskErr) == 405 {
 				close(abortHandle)
 				_ = os.Remove(input.CheckpointFile)
 				if err := aborter(); err != nil {
-					// TODO: log abort err
+					cli.logger.Error("tos: abort multipart upload failed", "bucket", input.Bucket, "key", input.Key,
+						"uploadID", checkpoint.UploadID, "error", err)
 					return nil, taskErr
 				}
-				postUploadEvent(input.UploadEventListener, newUploadPartAbortedEvent(input, checkpoint.UploadID, taskErr))
+				_ = postUploadEvent(input.UploadEventListener, newUploadPartAbortedEvent(input, checkpoint.UploadID, taskErr))
 				break Loop
+			}
+			_ = postUploadEvent(input.UploadEventListener, newUploadPartFailedEvent(input, checkpoint.UploadID, taskErr))
+			*failedAttempts++
+			if threshold := input.PartRetryPolicy.FailureThreshold; threshold > 0 && *failedAttempts >= threshold {
+				// keep the multipart upload and checkpoint, so the upload can be resumed later
+				close(abortHandle)
+				cli.logger.Error("tos: upload stopped after too many part failures", "bucket", input.Bucket, "key", input.Key,
+					"uploadID", checkpoint.UploadID, "failures", *failedAttempts)
+				return nil, newTosClientError(fmt.Sprintf("tos: upload stopped after %d part failures", *failedAttempts), taskErr)
+			}
+			if reschedules[failure.task] < input.PartRetryPolicy.MaxReschedules {
+				reschedules[failure.task]++
+				queue.enqueue(failure.task)
+				go schedule(failure.task)
 			} else {
-				postUploadEvent(input.UploadEventListener, newUploadPartFailedEvent(input, checkpoint.UploadID, taskErr))
 				fails++
 			}
 		}
 	}
+	if success+fails == len(tasks) {
+		close(tasksCh)
+	}
 	// handle results
 	if success < len(tasks) {
 		return nil, newTosClientError("tos: some upload tasks failed.", nil)
 	}
 	complete, err := cli.CompleteMultipartUploadV2(ctx, &CompleteMultipartUploadV2Input{
-		Bucket:   input.Bucket,
-		Key:      input.Key,
-		UploadID: checkpoint.UploadID,
-		Parts:    checkpoint.GetParts(),
+		Bucket:       input.Bucket,
+		Key:          input.Key,
+		UploadID:     checkpoint.UploadID,
+		RequestPayer: input.RequestPayer,
+		Parts:        checkpoint.GetParts(),
 	})
 	if err != nil {
-		postUploadEvent(input.UploadEventListener, newCompleteMultipartUploadFailedEvent(input, checkpoint.UploadID, err))
+		_ = postUploadEvent(input.UploadEventListener, newCompleteMultipartUploadFailedEvent(input, checkpoint.UploadID, err))
 		return nil, err
 	}
-	postUploadEvent(input.UploadEventListener, newCompleteMultipartUploadSucceedEvent(input, checkpoint.UploadID))
+	if err = postUploadEvent(input.UploadEventListener, newCompleteMultipartUploadSucceedEvent(input, checkpoint.UploadID)); err != nil {
+		// the object is uploaded already, don't fail the call
+		cli.logger.Warn("tos: post upload event failed", "bucket", input.Bucket, "key", input.Key, "error", err)
+	}
 
 	if combineCRCInParts(checkpoint.PartsInfo) != complete.HashCrc64ecma {
 		return nil, &TosServerError{
@@ -0,0 +1,193 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferMonitor(t *testing.T) {
+	monitor := newTransferMonitor()
+	now := monitor.start
+	monitor.now = func() time.Time { return now }
+
+	monitor.begin(2)
+	monitor.begin(1)
+	reader := &readCloserWithMonitor{base: ioutil.NopCloser(bytes.NewReader(make([]byte, 10))), monitor: monitor, partNumber: 1}
+	now = now.Add(time.Second)
+	_, err := ioutil.ReadAll(reader)
+	require.Nil(t, err)
+	now = now.Add(time.Second)
+
+	heartbeat := monitor.heartbeat()
+	require.Equal(t, 2*time.Second, heartbeat.Elapsed)
+	require.Equal(t, int64(10), heartbeat.ConsumedBytes)
+	require.Equal(t, []PartStall{
+		{PartNumber: 1, Elapsed: 2 * time.Second, Stalled: time.Second},
+		{PartNumber: 2, Elapsed: 2 * time.Second, Stalled: 2 * time.Second},
+	}, heartbeat.InflightParts)
+
+	monitor.end(1)
+	monitor.end(2)
+	require.Len(t, monitor.heartbeat().InflightParts, 0)
+
+	// nil monitor is a no-op
+	var nilMonitor *transferMonitor
+	nilMonitor.begin(1)
+	nilMonitor.touch(1, 1)
+	nilMonitor.end(1)
+}
+
+func TestRampUpPolicy(t *testing.T) {
+	require.Equal(t, time.Duration(0), RampUpPolicy{}.delay(3, 4))
+
+	policy := RampUpPolicy{Duration: 4 * time.Second}
+	for i := 0; i < 4; i++ {
+		delay := policy.delay(i, 4)
+		require.True(t, delay >= time.Duration(i)*time.Second)
+		require.True(t, delay < time.Duration(i+1)*time.Second)
+	}
+
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransferRampUp(time.Second))
+	require.Nil(t, err)
+	require.Equal(t, time.Second, client.config.RampUpPolicy.Duration)
+}
+
+// multipartTransport serves multipart upload requests, the first failParts UploadPart requests fail with 500
+type multipartTransport struct {
+	mu        sync.Mutex
+	failParts int
+	uploads   int
+	creates   int
+	completed string // upload ID of the completed multipart upload
+}
+
+func (rt *multipartTransport) RoundTrip(ctx context.Context, req *Request) (*Response, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if req.Content != nil {
+		_, _ = ioutil.ReadAll(req.Content)
+	}
+	respond := func(code int, body string) (*Response, error) {
+		header := make(http.Header)
+		header.Set(HeaderETag, "etag")
+		return &Response{StatusCode: code, Header: header, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+	}
+	switch {
+	case req.Method == http.MethodPost && req.Query.Get("uploadId") == "":
+		rt.creates++
+		return respond(http.StatusOK, `{"Bucket":"bucket","Key":"key","UploadId":"upload"}`)
+	case req.Method == http.MethodPost:
+		rt.completed = req.Query.Get("uploadId")
+		return respond(http.StatusOK, `{"Bucket":"bucket","Key":"key","ETag":"etag"}`)
+	}
+	rt.uploads++
+	if rt.uploads <= rt.failParts {
+		return respond(http.StatusInternalServerError, `{"Code":"InternalError"}`)
+	}
+	return respond(http.StatusOK, "")
+}
+
+func TestPartRetryPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-upload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	require.Nil(t, ioutil.WriteFile(file, []byte("hello"), 0666))
+	upload := func(failParts int, policy PartRetryPolicy) (*multipartTransport, error) {
+		transport := &multipartTransport{failParts: failParts}
+		client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+		require.Nil(t, err)
+		_, err = client.UploadFile(context.Background(), &UploadFileInput{
+			CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+			FilePath:                     file,
+			PartRetryPolicy:              policy,
+		})
+		return transport, err
+	}
+
+	_, err = upload(1, PartRetryPolicy{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "some upload tasks failed")
+
+	transport, err := upload(2, PartRetryPolicy{MaxReschedules: 2})
+	require.Nil(t, err)
+	require.Equal(t, 3, transport.uploads)
+
+	transport, err = upload(2, PartRetryPolicy{MaxReschedules: 2, FailureThreshold: 2})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "after 2 part failures")
+	require.Equal(t, 2, transport.uploads)
+}
+
+func TestUploadFileLegacyCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-upload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	wd, err := os.Getwd()
+	require.Nil(t, err)
+	require.Nil(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+	// old versions put the checkpoint of data/file at data/data/file.bucket.key.upload
+	require.Nil(t, os.MkdirAll(filepath.Join("data", "data"), 0755))
+	file := filepath.Join("data", "file")
+	require.Nil(t, ioutil.WriteFile(file, []byte("hello"), 0666))
+	stat, err := os.Stat(file)
+	require.Nil(t, err)
+	legacy := legacyUploadCheckpointFile(file, "bucket", "key")
+	require.Equal(t, filepath.Join("data", "data", "file.bucket.key.upload"), legacy)
+	checkpoint := &uploadCheckpoint{
+		checkpointPath: legacy,
+		Bucket:         "bucket",
+		Key:            "key",
+		UploadID:       "legacy",
+		PartSize:       MinPartSize,
+		FilePath:       file,
+		FileInfo:       fileInfo{Size: stat.Size(), LastModified: stat.ModTime().Unix()},
+		PartsInfo:      []uploadPartInfo{{PartNumber: 1, PartSize: stat.Size(), ETag: "etag", IsCompleted: true}},
+	}
+	require.Nil(t, checkpoint.WriteToFile())
+
+	transport := &multipartTransport{}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(transport))
+	require.Nil(t, err)
+	_, err = client.UploadFile(context.Background(), &UploadFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:                     file,
+		EnableCheckpoint:             true,
+	})
+	require.Nil(t, err)
+	// the upload is resumed from the legacy checkpoint
+	require.Equal(t, 0, transport.creates)
+	require.Equal(t, 0, transport.uploads)
+	require.Equal(t, "legacy", transport.completed)
+	_, err = os.Stat(legacy)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestUploadFileTransferObject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tos-upload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	require.Nil(t, ioutil.WriteFile(file, []byte("hello"), 0666))
+	limiter := &sizeLimiter{size: 100}
+	client, err := NewClientV2("tos-cn-beijing.volces.com", WithTransport(&multipartTransport{}), WithRateLimiter(limiter))
+	require.Nil(t, err)
+	_, err = client.UploadFile(context.Background(), &UploadFileInput{
+		CreateMultipartUploadV2Input: CreateMultipartUploadV2Input{Bucket: "bucket", Key: "key"},
+		FilePath:                     file,
+	})
+	require.Nil(t, err)
+	// parts are limited by the size of the file instead of an unknown size
+	require.Equal(t, []*TransferObject{{Bucket: "bucket", Key: "key", Size: 5}}, limiter.objects)
+}
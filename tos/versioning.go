@@ -16,12 +16,13 @@ type GetBucketVersioningOutput struct {
 }
 
 // GetBucketVersioning get the multi-version status of a bucket
-func (cli *Client) GetBucketVersioning(ctx context.Context, bucket string) (*GetBucketVersioningOutput, error) {
+func (cli *Client) GetBucketVersioning(ctx context.Context, bucket string, options ...Option) (*GetBucketVersioningOutput, error) {
 	if err := IsValidBucketName(bucket); err != nil {
 		return nil, err
 	}
 
-	res, err := cli.newBuilder(bucket, "").
+	res, err := cli.newBuilder(bucket, "", options...).
+		WithOperation(OperationGetBucketVersioning).
 		WithQuery("versioning", "").
 		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
 	if err != nil {
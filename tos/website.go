@@ -0,0 +1,116 @@
+package tos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// PutBucketWebsiteV2 replace static website hosting of a bucket
+func (cli *ClientV2) PutBucketWebsiteV2(ctx context.Context, input *PutBucketWebsiteV2Input, options ...Option) (*PutBucketWebsiteV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	if err := validateWebsiteConfiguration(&input.WebsiteConfiguration); err != nil {
+		return nil, err
+	}
+	in, contentMD5, err := marshalInput("PutBucketWebsiteV2Input", input.WebsiteConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationPutBucketWebsite).
+		WithQuery("website", "").
+		WithHeader(HeaderContentMD5, contentMD5).
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodPut, bytes.NewReader(in), cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &PutBucketWebsiteV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+// GetBucketWebsiteV2 get static website hosting of a bucket, it fails with code NoSuchWebsiteConfiguration if it's
+// not set
+func (cli *ClientV2) GetBucketWebsiteV2(ctx context.Context, input *GetBucketWebsiteV2Input, options ...Option) (*GetBucketWebsiteV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationGetBucketWebsite).
+		WithQuery("website", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodGet, nil, cli.roundTripper(http.StatusOK))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	output := GetBucketWebsiteV2Output{RequestInfo: res.RequestInfo()}
+	if err = marshalOutput(output.RequestID, res.Body, &output.WebsiteConfiguration); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteBucketWebsiteV2 disable static website hosting of a bucket
+func (cli *ClientV2) DeleteBucketWebsiteV2(ctx context.Context, input *DeleteBucketWebsiteV2Input, options ...Option) (*DeleteBucketWebsiteV2Output, error) {
+	if err := IsValidBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+	res, err := cli.newBuilder(input.Bucket, "", options...).
+		WithOperation(OperationDeleteBucketWebsite).
+		WithQuery("website", "").
+		WithRetry(nil, StatusCodeClassifier{}).
+		Request(ctx, http.MethodDelete, nil, cli.roundTripper(http.StatusNoContent))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	return &DeleteBucketWebsiteV2Output{RequestInfo: res.RequestInfo()}, nil
+}
+
+func validateWebsiteConfiguration(website *WebsiteConfiguration) error {
+	invalid := func(reason string) error {
+		return newTosClientError("tos: invalid website configuration, "+reason, nil)
+	}
+	if redirect := website.RedirectAllRequestsTo; redirect != nil {
+		if website.IndexDocument != nil || website.ErrorDocument != nil || len(website.RoutingRules) > 0 {
+			return invalid("RedirectAllRequestsTo can't be set with other fields")
+		}
+		if len(redirect.HostName) == 0 {
+			return invalid("HostName of RedirectAllRequestsTo is required")
+		}
+		return validateWebsiteProtocol(redirect.Protocol)
+	}
+	if website.IndexDocument == nil || len(website.IndexDocument.Suffix) == 0 {
+		return invalid("one of RedirectAllRequestsTo and IndexDocument is required")
+	}
+	if website.ErrorDocument != nil && len(website.ErrorDocument.Key) == 0 {
+		return invalid("Key of ErrorDocument is required")
+	}
+	for i, rule := range website.RoutingRules {
+		if len(rule.Condition.KeyPrefixEquals) == 0 && rule.Condition.HttpErrorCodeReturnedEquals == 0 {
+			return invalid(fmt.Sprintf("condition of routing rule %d is empty", i))
+		}
+		redirect := rule.Redirect
+		if len(redirect.ReplaceKeyPrefixWith) > 0 && len(redirect.ReplaceKeyWith) > 0 {
+			return invalid(fmt.Sprintf("only one of ReplaceKeyPrefixWith and ReplaceKeyWith of routing rule %d can be set", i))
+		}
+		if code := redirect.HttpRedirectCode; code != 0 && code != http.StatusMovedPermanently && code != http.StatusFound {
+			return invalid(fmt.Sprintf("HttpRedirectCode of routing rule %d must be 301 or 302", i))
+		}
+		if err := validateWebsiteProtocol(redirect.Protocol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateWebsiteProtocol(protocol string) error {
+	if len(protocol) > 0 && protocol != "http" && protocol != "https" {
+		return newTosClientError("tos: invalid website configuration, protocol must be http or https", nil)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package tos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsiteRedirectLocation(t *testing.T) {
+	header := make(http.Header)
+	header.Set(HeaderWebsiteRedirectLocation, "/index.html")
+	client, transport := newRecordClient(t, http.StatusOK, "")
+	transport.res.Header = header
+
+	_, err := client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", WebsiteRedirectLocation: "/index.html"},
+		Content:             strings.NewReader("a")})
+	require.Nil(t, err)
+	require.Equal(t, "/index.html", transport.requests[0].Header.Get(HeaderWebsiteRedirectLocation))
+
+	out, err := client.HeadObjectV2(context.Background(), &HeadObjectV2Input{Bucket: "bucket", Key: "key"})
+	require.Nil(t, err)
+	require.Equal(t, "/index.html", out.WebsiteRedirectLocation)
+
+	transport.respond(http.StatusOK, `{"ETag":"etag"}`)
+	_, err = client.CopyObject(context.Background(), &CopyObjectInput{Bucket: "bucket", Key: "key",
+		SrcBucket: "src", SrcKey: "src", WebsiteRedirectLocation: "https://example.com/a"})
+	require.Nil(t, err)
+	require.Equal(t, "https://example.com/a", transport.requests[2].Header.Get(HeaderWebsiteRedirectLocation))
+
+	// neither an object nor an absolute URL
+	_, err = client.PutObjectV2(context.Background(), &PutObjectV2Input{
+		PutObjectBasicInput: PutObjectBasicInput{Bucket: "bucket", Key: "key", WebsiteRedirectLocation: "index.html"},
+		Content:             strings.NewReader("a")})
+	require.NotNil(t, err)
+	_, err = client.CreateMultipartUploadV2(context.Background(), &CreateMultipartUploadV2Input{Bucket: "bucket",
+		Key: "key", WebsiteRedirectLocation: "/" + strings.Repeat("a", 2048)})
+	require.NotNil(t, err)
+	require.Len(t, transport.requests, 3)
+}
@@ -0,0 +1,69 @@
+package tos
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketWebsiteV2(t *testing.T) {
+	client, transport := newRecordClient(t, http.StatusOK, "")
+
+	website := WebsiteConfiguration{
+		IndexDocument: &IndexDocument{Suffix: "index.html"},
+		ErrorDocument: &ErrorDocument{Key: "404.html"},
+		RoutingRules: []RoutingRule{{
+			Condition: RoutingRuleCondition{KeyPrefixEquals: "docs/"},
+			Redirect:  RoutingRuleRedirect{ReplaceKeyPrefixWith: "documents/", HttpRedirectCode: http.StatusFound},
+		}},
+	}
+	_, err := client.PutBucketWebsiteV2(context.Background(), &PutBucketWebsiteV2Input{Bucket: "bucket",
+		WebsiteConfiguration: website})
+	require.Nil(t, err)
+	req := transport.requests[0]
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Contains(t, req.Query, "website")
+	data, err := ioutil.ReadAll(req.Content)
+	require.Nil(t, err)
+	var sent WebsiteConfiguration
+	require.Nil(t, json.Unmarshal(data, &sent))
+	require.Equal(t, website, sent)
+
+	transport.respond(http.StatusOK, `{"RedirectAllRequestsTo":{"HostName":"example.com","Protocol":"https"}}`)
+	out, err := client.GetBucketWebsiteV2(context.Background(), &GetBucketWebsiteV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, WebsiteConfiguration{
+		RedirectAllRequestsTo: &RedirectAllRequestsTo{HostName: "example.com", Protocol: "https"}},
+		out.WebsiteConfiguration)
+	require.Equal(t, http.MethodGet, transport.requests[1].Method)
+	require.Contains(t, transport.requests[1].Query, "website")
+
+	transport.respond(http.StatusNoContent, "")
+	_, err = client.DeleteBucketWebsiteV2(context.Background(), &DeleteBucketWebsiteV2Input{Bucket: "bucket"})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodDelete, transport.requests[2].Method)
+
+	for _, invalid := range []WebsiteConfiguration{
+		{},
+		{RedirectAllRequestsTo: &RedirectAllRequestsTo{HostName: "example.com"}, IndexDocument: &IndexDocument{Suffix: "index.html"}},
+		{RedirectAllRequestsTo: &RedirectAllRequestsTo{HostName: "example.com", Protocol: "ftp"}},
+		{IndexDocument: &IndexDocument{Suffix: "index.html"}, RoutingRules: []RoutingRule{{}}},
+		{IndexDocument: &IndexDocument{Suffix: "index.html"}, RoutingRules: []RoutingRule{{
+			Condition: RoutingRuleCondition{HttpErrorCodeReturnedEquals: 404},
+			Redirect:  RoutingRuleRedirect{ReplaceKeyPrefixWith: "a", ReplaceKeyWith: "b"}}}},
+	} {
+		_, err = client.PutBucketWebsiteV2(context.Background(), &PutBucketWebsiteV2Input{Bucket: "bucket",
+			WebsiteConfiguration: invalid})
+		require.NotNil(t, err)
+	}
+	transport.respond(http.StatusOK, "")
+	_, err = client.PutBucketWebsiteV2(context.Background(), &PutBucketWebsiteV2Input{Bucket: "bucket",
+		WebsiteConfiguration: WebsiteConfiguration{
+			RedirectAllRequestsTo: &RedirectAllRequestsTo{HostName: "example.com", Protocol: "https"}}})
+	require.Nil(t, err)
+	require.Len(t, transport.requests, 4)
+}